@@ -4,6 +4,7 @@ import (
 	"flag"
 	"fmt"
 
+	"github.com/abcfe-op/abcfe-node/blockchain"
 	"github.com/abcfe-op/abcfe-node/cli"
 	"github.com/abcfe-op/abcfe-node/config"
 	"github.com/abcfe-op/abcfe-node/db"
@@ -13,7 +14,7 @@ import (
 )
 
 var port = flag.Int("port", 4000, "Set port of the server")
-var mode = flag.String("mode", "rest", "Choose between 'auto' and 'rest'")
+var mode = flag.String("mode", "rest", "Choose between 'auto', 'rest' and 'swap'")
 var configPath = flag.String("config", "../config/config.toml", "Set path of the config file")
 
 type App struct {
@@ -33,8 +34,26 @@ func New() (*App, error) {
 	log.InitLogger(r.cfg)
 	log.Info(fmt.Sprintf("Starting %s at Port: %d Mode: %s", r.cfg.Common.ServiceName, *port, *mode))
 
+	if *mode == "swap" { // 자기 노드의 REST API만 사용하므로 DB를 열지 않고, 한 단계를 실행한 뒤 끝냄
+		err := cli.Swap()
+		r.Terminate()
+		return r, err
+	}
+
 	defer db.Close()
 	db.InitDB()
+	blockchain.EnableIndexes(r.cfg.Index.TxIndex, r.cfg.Index.AddressIndex)
+	blockchain.SetMempoolPolicy(blockchain.MempoolPolicy{
+		MaxCount:     r.cfg.Mempool.MaxCount,
+		MaxBytes:     r.cfg.Mempool.MaxBytes,
+		MaxPerSender: r.cfg.Mempool.MaxPerSender,
+		ExpirySec:    r.cfg.Mempool.ExpiryHour * 3600,
+	})
+	blockchain.SetDenomination(blockchain.Denomination{
+		Symbol:   r.cfg.Denomination.Symbol,
+		Decimals: r.cfg.Denomination.Decimals,
+	})
+	blockchain.SetCommitteeSize(r.cfg.Consensus.CommitteeSize)
 
 	go rpc.Start(*port)
 	cli.Start(*port, *mode)
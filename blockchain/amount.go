@@ -0,0 +1,105 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"strings"
+)
+
+// 금액: 기본 단위(가장 작은 단위)의 부호 없는 64비트 정수
+// 잔액, 수수료, 보상 계산에서의 덧셈과 뺄셈은 넘침과 음수를 확인하는 Add, Sub를 사용한다
+type Amount uint64
+
+const maxDenominationDecimals = 19 // 10^19까지 uint64로 표현 가능
+
+var (
+	ErrAmountOverflow  = errors.New("amount overflow")
+	ErrAmountUnderflow = errors.New("amount underflow")
+)
+
+// 두 금액의 합 (uint64 범위를 넘으면 에러)
+func (a Amount) Add(b Amount) (Amount, error) {
+	sum, carry := bits.Add64(uint64(a), uint64(b), 0)
+	if carry != 0 {
+		return 0, fmt.Errorf("%w: %d + %d", ErrAmountOverflow, a, b)
+	}
+	return Amount(sum), nil
+}
+
+// 두 금액의 차 (결과가 음수이면 에러)
+func (a Amount) Sub(b Amount) (Amount, error) {
+	if b > a {
+		return 0, fmt.Errorf("%w: %d - %d", ErrAmountUnderflow, a, b)
+	}
+	return a - b, nil
+}
+
+// 자산별 금액 합
+type amounts map[string]Amount
+
+// 자산의 합에 금액을 더함 (넘치면 에러)
+func (m amounts) add(asset string, v Amount) error {
+	sum, err := m[asset].Add(v)
+	if err != nil {
+		return err
+	}
+	m[asset] = sum
+	return nil
+}
+
+// 두 금액의 합 (uint64 범위를 넘으면 최댓값). 색인처럼 블록을 거부할 수 없는 곳에서 사용
+func (a Amount) saturatingAdd(b Amount) Amount {
+	if sum, err := a.Add(b); err == nil {
+		return sum
+	}
+	return math.MaxUint64
+}
+
+// 받은 금액 - 보낸 금액 (int64 범위를 넘으면 범위의 끝 값으로 자름)
+func clampedDelta(received, sent Amount) int64 {
+	if received >= sent {
+		if d := received - sent; d <= math.MaxInt64 {
+			return int64(d)
+		}
+		return math.MaxInt64
+	}
+	if d := sent - received; d <= math.MaxInt64 {
+		return -int64(d)
+	}
+	return math.MinInt64
+}
+
+// 금액 표시 단위: 기본 단위 10^Decimals 개가 1 Symbol (표시에만 사용하며 합의 규칙과는 무관)
+type Denomination struct {
+	Symbol   string
+	Decimals int
+}
+
+var denomination = Denomination{Symbol: "ABCFE", Decimals: 0}
+
+// 표시 단위 설정 (노드 시작 시 호출). 비어있는 기호는 기본값을 유지하고, 범위를 벗어난 자릿수는 무시
+func SetDenomination(d Denomination) {
+	if d.Symbol != "" {
+		denomination.Symbol = d.Symbol
+	}
+	if d.Decimals >= 0 && d.Decimals <= maxDenominationDecimals {
+		denomination.Decimals = d.Decimals
+	}
+}
+
+// 표시 단위로 나타낸 금액 (예: 소수점 2자리라면 1250 -> "12.50 ABCFE")
+func (a Amount) Format() string {
+	digits := fmt.Sprintf("%0*d", denomination.Decimals+1, uint64(a))
+	point := len(digits) - denomination.Decimals
+	var b strings.Builder
+	b.WriteString(digits[:point])
+	if denomination.Decimals > 0 {
+		b.WriteString(".")
+		b.WriteString(digits[point:])
+	}
+	b.WriteString(" ")
+	b.WriteString(denomination.Symbol)
+	return b.String()
+}
@@ -0,0 +1,117 @@
+package blockchain
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestAmountArithmetic(t *testing.T) {
+	tests := []struct {
+		name string
+		op   func(a, b Amount) (Amount, error)
+		a, b Amount
+		want Amount
+		err  error
+	}{
+		{"add", Amount.Add, 2, 3, 5, nil},
+		{"add up to the maximum", Amount.Add, math.MaxUint64 - 1, 1, math.MaxUint64, nil},
+		{"add overflow", Amount.Add, math.MaxUint64, 1, 0, ErrAmountOverflow},
+		{"add overflow of halves", Amount.Add, math.MaxUint64/2 + 1, math.MaxUint64/2 + 1, 0, ErrAmountOverflow},
+		{"sub", Amount.Sub, 5, 3, 2, nil},
+		{"sub to zero", Amount.Sub, 3, 3, 0, nil},
+		{"sub underflow", Amount.Sub, 3, 5, 0, ErrAmountUnderflow},
+		{"sub from zero", Amount.Sub, 0, 1, 0, ErrAmountUnderflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.op(tt.a, tt.b)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if got != tt.want {
+				t.Fatalf("result = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmountsPerAsset(t *testing.T) {
+	m := make(amounts)
+	for _, v := range []Amount{3, 4} {
+		if err := m.add(NativeAsset, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := m.add("aa", math.MaxUint64); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.add("aa", 1); !errors.Is(err, ErrAmountOverflow) {
+		t.Fatalf("err = %v, want %v", err, ErrAmountOverflow)
+	}
+	if m[NativeAsset] != 7 || m["aa"] != math.MaxUint64 {
+		t.Fatalf("sums = %v, want 7 and the unchanged maximum", m)
+	}
+}
+
+func TestClampedDelta(t *testing.T) {
+	tests := []struct {
+		name           string
+		received, sent Amount
+		want           int64
+	}{
+		{"received", 10, 3, 7},
+		{"sent", 3, 10, -7},
+		{"even", 5, 5, 0},
+		{"largest gain", math.MaxInt64, 0, math.MaxInt64},
+		{"largest loss", 0, math.MaxInt64, -math.MaxInt64},
+		{"gain out of range", math.MaxUint64, 0, math.MaxInt64},
+		{"loss out of range", 0, math.MaxUint64, math.MinInt64},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := clampedDelta(tt.received, tt.sent); got != tt.want {
+				t.Fatalf("clampedDelta = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmountFormat(t *testing.T) {
+	saved := denomination
+	t.Cleanup(func() { denomination = saved })
+	tests := []struct {
+		name   string
+		set    Denomination
+		amount Amount
+		want   string
+	}{
+		{"default", Denomination{}, 1250, "1250 ABCFE"},
+		{"decimals", Denomination{Symbol: "ABC", Decimals: 2}, 1250, "12.50 ABC"},
+		{"below one", Denomination{Symbol: "ABC", Decimals: 3}, 5, "0.005 ABC"},
+		{"zero", Denomination{Symbol: "ABC", Decimals: 2}, 0, "0.00 ABC"},
+		{"empty symbol keeps the default", Denomination{Decimals: 1}, 15, "1.5 ABCFE"},
+		{"largest amount", Denomination{Decimals: maxDenominationDecimals}, math.MaxUint64, "1.8446744073709551615 ABCFE"},
+		{"too many decimals are ignored", Denomination{Decimals: maxDenominationDecimals + 1}, 15, "15 ABCFE"},
+		{"negative decimals are ignored", Denomination{Decimals: -1}, 15, "15 ABCFE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			denomination = saved
+			SetDenomination(tt.set)
+			if got := tt.amount.Format(); got != tt.want {
+				t.Fatalf("Format = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTxOutputsMustNotOverflow(t *testing.T) {
+	bc := newTestChain(t)
+	fund(t, bc)
+	inputs := utxosByAddress(nodeKey.address)
+	tx := spendTx(nodeKey, inputs, &TxOut{Address: "ab", Amount: math.MaxUint64}, &TxOut{Address: "ab", Amount: 2})
+	if err := Mempool().SubmitTx(tx); !errors.Is(err, ErrAmountOverflow) {
+		t.Fatalf("err = %v, want %v", err, ErrAmountOverflow)
+	}
+}
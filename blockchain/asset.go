@@ -0,0 +1,252 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+	"unicode"
+
+	"github.com/abcfe-op/abcfe-node/common/utils"
+	"github.com/abcfe-op/abcfe-node/db"
+	"github.com/abcfe-op/abcfe-node/wallet"
+)
+
+const NativeAsset = "" // 체인 기본 코인의 자산 ID (출력의 Asset이 비어있으면 기본 코인)
+
+const (
+	maxAssetNameLen  = 32 // 자산 이름의 최대 길이
+	maxAssetDecimals = 18 // 자산의 최대 소수점 자릿수
+)
+
+var (
+	ErrInvalidIssuance        = errors.New("invalid asset issuance")
+	ErrInvalidIssuerSignature = errors.New("invalid issuer signature")
+	ErrAssetNotConserved      = errors.New("asset inputs and outputs do not match")
+	ErrAssetNotFound          = errors.New("asset not found")
+	ErrStakeAsset             = errors.New("only the native coin can be staked")
+)
+
+// 자산 발행: 발행자가 서명한 트랜잭션만이 자산을 새로 만들 수 있다
+// 자산 ID는 발행자, 이름, 소수점 자릿수로 정해지므로, 같은 발행자가 같은 내용으로 다시 발행하면 같은 자산이 추가로 발행된다
+type Issuance struct {
+	Issuer    string `json:"issuer"` // 발행자 공개키 (지갑 주소)
+	Name      string `json:"name"`
+	Decimals  int    `json:"decimals"`
+	Amount    Amount `json:"amount"`    // 이번에 새로 발행하는 수량
+	Signature string `json:"signature"` // 발행자의 트랜잭션 ID 서명 (ID 계산에서 제외)
+}
+
+// 자산 정보 (체인에 기록된 발행들을 모은 결과)
+type Asset struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Decimals  int    `json:"decimals"`
+	Issuer    string `json:"issuer"`
+	Supply    Amount `json:"supply"`    // 지금까지 발행된 총량
+	Issuances int    `json:"issuances"` // 발행 횟수
+	Height    int    `json:"height"`    // 처음 발행된 블록 높이
+	TxID      string `json:"txId"`      // 처음 발행한 트랜잭션
+}
+
+// 자산별 잔액
+type AssetBalance struct {
+	Asset    string `json:"asset"`
+	Name     string `json:"name"`
+	Decimals int    `json:"decimals"`
+	Balance  Amount `json:"balance"`
+}
+
+// 디비에 저장하는 발행 기록
+type issuanceRecord struct {
+	Issuance *Issuance
+	Height   int
+	TxID     string
+}
+
+// 발행자, 이름, 소수점 자릿수로 만든 자산 ID
+func AssetID(issuer, name string, decimals int) string {
+	return utils.HashBytes(serializeAsset(issuer, name, decimals))
+}
+
+// 발행하는 자산의 ID
+func (i *Issuance) Asset() string {
+	return AssetID(i.Issuer, i.Name, i.Decimals)
+}
+
+// 발행 내용 검증 (서명 제외)
+func (i *Issuance) validate() error {
+	if i.Issuer == "" || i.Amount == 0 || i.Decimals < 0 || i.Decimals > maxAssetDecimals {
+		return fmt.Errorf("%w: %s %d", ErrInvalidIssuance, i.Name, i.Amount)
+	}
+	if i.Name == "" || len(i.Name) > maxAssetNameLen {
+		return fmt.Errorf("%w: name length %d", ErrInvalidIssuance, len(i.Name))
+	}
+	for _, r := range i.Name {
+		if !unicode.IsPrint(r) || unicode.IsSpace(r) {
+			return fmt.Errorf("%w: name %q", ErrInvalidIssuance, i.Name)
+		}
+	}
+	return nil
+}
+
+// 발행 내용과 발행자의 서명 검증
+func (i *Issuance) verify(txID string) error {
+	if err := i.validate(); err != nil {
+		return err
+	}
+	if !wallet.Verify(i.Signature, txID, i.Issuer) {
+		return fmt.Errorf("%w: %s", ErrInvalidIssuerSignature, txID)
+	}
+	return nil
+}
+
+// 출력의 기본 코인 수량 (다른 자산의 출력이면 0)
+func nativeAmount(txOut *TxOut) Amount {
+	if txOut.Asset != NativeAsset {
+		return 0
+	}
+	return txOut.Amount
+}
+
+// 트랜잭션의 자산별 입력 합과 출력 합 비교: 기본 코인은 입력 합 - 출력 합이 수수료가 되고, 그 외 자산은 (발행량을 포함한) 입력 합과 출력 합이 같아야 함
+func checkAssetConservation(tx *Tx, inputs, outputs amounts) error {
+	if tx.Kind == KindIssue {
+		issue := tx.Payload.Issue
+		if err := inputs.add(issue.Asset(), issue.Amount); err != nil {
+			return fmt.Errorf("%w: %s", err, tx.ID)
+		}
+	}
+	for asset, amount := range inputs {
+		if asset != NativeAsset && outputs[asset] != amount {
+			return fmt.Errorf("%w: %s %d != %d", ErrAssetNotConserved, asset, outputs[asset], amount)
+		}
+	}
+	for asset, amount := range outputs {
+		if asset == NativeAsset {
+			if amount > inputs[asset] {
+				return fmt.Errorf("%w: %s", ErrOutputsExceedInputs, tx.ID)
+			}
+		} else if inputs[asset] != amount {
+			return fmt.Errorf("%w: %s %d != %d", ErrAssetNotConserved, asset, amount, inputs[asset])
+		}
+	}
+	return nil
+}
+
+// 노드의 지갑을 발행자로 자산을 발행하는 트랜잭션을 mempool에 추가 (to가 비어있으면 발행자에게 지급, 수수료는 기본 코인으로 지불)
+func (m *mempool) IssueAsset(name string, decimals int, amount Amount, to string, fee Amount, port string) (*Tx, error) {
+	w := wallet.Wallet(port)
+	issuance := &Issuance{Issuer: w.Address, Name: name, Decimals: decimals, Amount: amount}
+	if err := issuance.validate(); err != nil {
+		return nil, reject(RejectInvalid, err)
+	}
+	if to == "" {
+		to = w.Address
+	}
+	if fee < MinTxFee {
+		return nil, reject(RejectFeeTooLow, fmt.Errorf("%w: %d < %d", ErrFeeTooLow, fee, MinTxFee))
+	}
+	txIns, change, err := selectInputs(w.Address, amounts{NativeAsset: fee}, SelectInOrder)
+	if err != nil {
+		return nil, makeTxReject(err)
+	}
+	payload := newPayload()
+	payload.Issue = issuance
+	tx := &Tx{
+		Timestamp: int(time.Now().Unix()),
+		TxIns:     txIns,
+		TxOuts:    append(change, &TxOut{Address: to, Amount: amount, Asset: issuance.Asset()}),
+		InputData: name,
+		Kind:      KindIssue,
+		Payload:   payload,
+	}
+	tx.getId()
+	tx.sign(port)
+	issuance.Signature = wallet.Sign(tx.ID, w)
+	if err := m.add(tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// 블록에 담긴 발행 기록을 배치에 추가
+func stageIssuances(batch *db.Batch, block *Block) error {
+	for _, tx := range block.Transaction {
+		if tx.Kind != KindIssue {
+			continue
+		}
+		issue := tx.Payload.Issue
+		data, err := utils.ToBytes(&issuanceRecord{issue, block.Height, tx.ID})
+		if err != nil {
+			return err
+		}
+		batch.AddIssuance(issue.Asset(), block.Height, tx.ID, data)
+	}
+	return nil
+}
+
+// 자산 정보 조회 (발행 기록을 오래된 순으로 모아 총 발행량 계산)
+func FindAsset(id string) (*Asset, error) {
+	list := dbStorage.AssetIssuances(id)
+	if len(list) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrAssetNotFound, id)
+	}
+	var asset *Asset
+	for _, data := range list {
+		record := &issuanceRecord{}
+		if err := utils.FromBytes(record, data); err != nil {
+			return nil, err
+		}
+		if asset == nil {
+			i := record.Issuance
+			asset = &Asset{ID: id, Name: i.Name, Decimals: i.Decimals, Issuer: i.Issuer, Height: record.Height, TxID: record.TxID}
+		}
+		supply, err := asset.Supply.Add(record.Issuance.Amount)
+		if err != nil {
+			return nil, err
+		}
+		asset.Supply = supply
+		asset.Issuances++
+	}
+	return asset, nil
+}
+
+// 발행된 자산 전체 조회 (ID 순)
+func Assets() ([]*Asset, error) {
+	assets := []*Asset{}
+	for _, id := range dbStorage.AssetIDs() {
+		asset, err := FindAsset(id)
+		if err != nil {
+			return nil, err
+		}
+		assets = append(assets, asset)
+	}
+	return assets, nil
+}
+
+// 특정 주소의 기본 코인 외 자산별 잔액 (자산 ID 순, 멤풀의 트랜잭션이 사용하려는 출력은 제외)
+func AssetBalancesByAddress(address string) ([]*AssetBalance, error) {
+	balances := make(amounts)
+	for _, e := range utxosByAddress(address) {
+		if e.Output.Asset != NativeAsset && !isOnMempool(e.uTxOut()) {
+			if err := balances.add(e.Output.Asset, e.Output.Amount); err != nil {
+				return nil, err
+			}
+		}
+	}
+	ids := make([]string, 0, len(balances))
+	for id := range balances {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	list := []*AssetBalance{}
+	for _, id := range ids {
+		balance := &AssetBalance{Asset: id, Balance: balances[id]}
+		if asset, err := FindAsset(id); err == nil {
+			balance.Name, balance.Decimals = asset.Name, asset.Decimals
+		}
+		list = append(list, balance)
+	}
+	return list, nil
+}
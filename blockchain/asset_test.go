@@ -0,0 +1,165 @@
+package blockchain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/abcfe-op/abcfe-node/common/utils"
+)
+
+func TestIssuanceValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		issuance Issuance
+		ok       bool
+	}{
+		{"valid", Issuance{Issuer: "aa", Name: "TOK", Decimals: 2, Amount: 1}, true},
+		{"no issuer", Issuance{Name: "TOK", Amount: 1}, false},
+		{"zero amount", Issuance{Issuer: "aa", Name: "TOK"}, false},
+		{"negative decimals", Issuance{Issuer: "aa", Name: "TOK", Decimals: -1, Amount: 1}, false},
+		{"too many decimals", Issuance{Issuer: "aa", Name: "TOK", Decimals: maxAssetDecimals + 1, Amount: 1}, false},
+		{"empty name", Issuance{Issuer: "aa", Amount: 1}, false},
+		{"long name", Issuance{Issuer: "aa", Name: strings.Repeat("T", maxAssetNameLen+1), Amount: 1}, false},
+		{"space in the name", Issuance{Issuer: "aa", Name: "MY TOK", Amount: 1}, false},
+		{"control character in the name", Issuance{Issuer: "aa", Name: "TOK\n", Amount: 1}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.issuance.validate(); (err == nil) != tt.ok || (err != nil && !errors.Is(err, ErrInvalidIssuance)) {
+				t.Fatalf("err = %v, want ok = %v", err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestCheckAssetConservation(t *testing.T) {
+	issue := &Issuance{Issuer: "aa", Name: "TOK", Amount: 50}
+	issued := issue.Asset()
+	issueTx := &Tx{Kind: KindIssue, Payload: &TxPayload{Issue: issue}}
+	tests := []struct {
+		name            string
+		tx              *Tx
+		inputs, outputs amounts
+		want            error
+	}{
+		{"native coin pays a fee", &Tx{}, amounts{NativeAsset: 10}, amounts{NativeAsset: 9}, nil},
+		{"native outputs exceed inputs", &Tx{}, amounts{NativeAsset: 10}, amounts{NativeAsset: 11}, ErrOutputsExceedInputs},
+		{"asset moves in full", &Tx{}, amounts{NativeAsset: 1, "bb": 5}, amounts{"bb": 5}, nil},
+		{"asset burned", &Tx{}, amounts{NativeAsset: 1, "bb": 5}, amounts{"bb": 4}, ErrAssetNotConserved},
+		{"asset created from nothing", &Tx{}, amounts{NativeAsset: 1}, amounts{"bb": 1}, ErrAssetNotConserved},
+		{"asset paid as a fee instead of the coin", &Tx{}, amounts{"bb": 5}, amounts{"bb": 4}, ErrAssetNotConserved},
+		{"issuance adds to the inputs", issueTx, amounts{NativeAsset: 1}, amounts{issued: 50}, nil},
+		{"issuance over the issued amount", issueTx, amounts{NativeAsset: 1}, amounts{issued: 51}, ErrAssetNotConserved},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkAssetConservation(tt.tx, tt.inputs, tt.outputs); !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func assetBalance(t *testing.T, address, asset string) Amount {
+	t.Helper()
+	balances, err := AssetBalancesByAddress(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, b := range balances {
+		if b.Asset == asset {
+			return b.Balance
+		}
+	}
+	return 0
+}
+
+func TestIssueAndTransferAsset(t *testing.T) {
+	bc := newTestChain(t)
+	fund(t, bc)
+	tx, err := Mempool().IssueAsset("TOK", 2, 1000, "", 1, nodeKey.port)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mine(t, bc, fromMempool)
+	id := tx.Payload.Issue.Asset()
+	if id != AssetID(nodeKey.address, "TOK", 2) {
+		t.Fatal("asset id is not derived from the issuer, name and decimals")
+	}
+	native := balance(t, bc, nodeKey.address)
+
+	if _, err := Mempool().AddTxOuts([]*TxOut{{Address: "ab", Amount: 300, Asset: id}}, 1, SelectInOrder, "", 0, nodeKey.port); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Mempool().IssueAsset("TOK", 2, 500, "", 1, nodeKey.port); err != nil {
+		t.Fatal(err)
+	}
+	mine(t, bc, fromMempool)
+	if got := assetBalance(t, nodeKey.address, id); got != 1200 {
+		t.Fatalf("issuer balance = %d, want 1200", got)
+	}
+	if got := assetBalance(t, "ab", id); got != 300 {
+		t.Fatalf("recipient balance = %d, want 300", got)
+	}
+	if balance(t, bc, "ab") != 0 {
+		t.Fatal("asset counted as the native coin")
+	}
+	// 두 트랜잭션의 수수료만큼 기본 코인이 줄어들고, 이번 블록의 보상이 더해짐
+	block := tipBlock(t, bc)
+	var earned Amount
+	for _, txOut := range block.Transaction[len(block.Transaction)-1].TxOuts {
+		if txOut.Address == nodeKey.address {
+			earned += txOut.Amount
+		}
+	}
+	if got := balance(t, bc, nodeKey.address); got != native-2+earned {
+		t.Fatalf("native balance = %d, want %d", got, native-2+earned)
+	}
+
+	asset, err := FindAsset(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if asset.Supply != 1500 || asset.Issuances != 2 || asset.Name != "TOK" || asset.Decimals != 2 || asset.TxID != tx.ID {
+		t.Fatalf("asset = %+v", asset)
+	}
+	if _, err := FindAsset(utils.HashBytes([]byte("none"))); !errors.Is(err, ErrAssetNotFound) {
+		t.Fatalf("err = %v, want %v", err, ErrAssetNotFound)
+	}
+}
+
+func TestIssuanceNeedsIssuerSignature(t *testing.T) {
+	bc := newTestChain(t)
+	fund(t, bc)
+	// 노드 지갑이 다른 발행자의 이름으로 자산을 발행하려 함
+	other := testKeys[genesisValidators[1].Address]
+	e := freeUTXO(t)
+	issue := &Issuance{Issuer: other.address, Name: "TOK", Amount: 100}
+	payload := newPayload()
+	payload.Issue = issue
+	tx := &Tx{
+		Timestamp: 1700000000,
+		TxIns:     []*TxIn{{TxID: e.TxID, Index: e.Index}},
+		TxOuts:    []*TxOut{{Address: nodeKey.address, Amount: e.Output.Amount - 1}, {Address: nodeKey.address, Amount: 100, Asset: issue.Asset()}},
+		Kind:      KindIssue,
+		Payload:   payload,
+	}
+	tx.getId()
+	tx.TxIns[0].Signature = nodeKey.sign(tx.ID)
+	issue.Signature = nodeKey.sign(tx.ID)
+	if err := Mempool().SubmitTx(tx); !errors.Is(err, ErrInvalidIssuerSignature) {
+		t.Fatalf("err = %v, want %v", err, ErrInvalidIssuerSignature)
+	}
+	issue.Signature = other.sign(tx.ID)
+	if err := Mempool().SubmitTx(tx); err != nil {
+		t.Fatalf("issuance signed by the issuer was rejected: %v", err)
+	}
+}
+
+func TestOnlyNativeCoinCanBeStaked(t *testing.T) {
+	err := validateStakeOutput(&TxOut{Address: utils.StakingAddress, Amount: utils.StakingQuantity, Asset: "bb", Lock: stakingLock()})
+	if !errors.Is(err, ErrStakeAsset) {
+		t.Fatalf("err = %v, want %v", err, ErrStakeAsset)
+	}
+}
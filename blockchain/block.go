@@ -7,7 +7,6 @@ import (
 	"time"
 
 	"github.com/abcfe-op/abcfe-node/common/utils"
-	"github.com/abcfe-op/abcfe-node/wallet"
 
 	log "github.com/abcfe-op/abcfe-node/common/logger"
 )
@@ -22,58 +21,139 @@ type RoleInfo struct {
 	ValidatorSelectedHeight int      `json:"validatorSelectedHeight"` // 검증자가 선출된 블록 높이
 }
 
+// 블록 헤더에 대한 구조체 (블록 해시는 헤더만을 대상으로 계산)
+type BlockHeader struct {
+	PrevHash     string `json:"prevHash,omitempty"`     // 직전 블록의 해시 값
+	Height       int    `json:"height"`                 // 블록 높이
+	Timestamp    int    `json:"timestamp"`              // 블록 생성 타임스탬프
+	MerkleRoot   string `json:"merkleRoot"`             // 블록내 트랜잭션들의 머클 루트
+	WitnessRoot  string `json:"witnessRoot"`            // 서명과 증인 값까지 포함한 트랜잭션들의 머클 루트
+	RoleInfoHash string `json:"roleInfoHash"`           // 역할 정보의 다이제스트
+	RandaoReveal string `json:"randaoReveal,omitempty"` // 제안자가 이전 무작위 값과 높이로 만든 VRF 증명
+	RandaoMix    string `json:"randaoMix"`              // 제네시스부터 제안자들의 VRF 출력을 누적한 무작위 값 (다음 높이의 선출 시드)
+}
+
 // 블록 정보에 대한 구조체
 type Block struct {
-	Hash        string               `json:"hash"`               // 블록의 해시 값
-	PrevHash    string               `json:"prevHash,omitempty"` // 직전 블록의 해시 값
-	Height      int                  `json:"height"`             // 블록 높이
-	Timestamp   int                  `json:"timestamp"`          // 블록 생성 타임스탬프
-	Transaction []*Tx                `json:"transaction"`        // 블록내의 트랜잭션
-	RoleInfo    *RoleInfo            `json:"roleinfo"`           // 블록 추가를 위해 구성된 제안자, 검증자 정보
-	Signature   []*ValidateSignature `json:"signature"`          // 블록의 유효성을 확인한 검증자들의 서명
+	Hash string `json:"hash"` // 블록의 해시 값
+	// 블록 헤더 (필드는 Block에 그대로 노출)
+	BlockHeader
+	Transaction []*Tx                `json:"transaction"` // 블록내의 트랜잭션
+	RoleInfo    *RoleInfo            `json:"roleinfo"`    // 블록 추가를 위해 구성된 제안자, 검증자 정보
+	Signature   []*ValidateSignature `json:"signature"`   // 블록의 유효성을 확인한 검증자들의 서명
 }
 
 // 검증 과정 중 검증자의 서명에 대한 구조체
 type ValidateSignature struct {
-	Port      string `json:"port"`      // 검증자의 노트 포트
-	Address   string `json:"address"`   // 검증자의 주소
-	Signature string `json:"signature"` // 검증자가 블록을 서명한 값
+	Port      string `json:"port"`            // 검증자의 노트 포트
+	Address   string `json:"address"`         // 검증자의 주소
+	Signature string `json:"signature"`       // 검증자가 블록을 서명한 값
+	Round     int    `json:"round,omitempty"` // 서명한 합의 라운드 (라운드마다 서명 대상이 달라 다른 라운드의 서명을 재사용할 수 없음)
 }
 
-// 검증 정보에 대한 구조체
-type ValidatedInfo struct {
-	ProposerPort  string             `json:"proposerPort"`  // 제안자의 노드 포트
-	ProposalBlock *Block             `json:"proposerBlock"` // 제안자가 제안한 블록
-	Port          string             `json:"port"`          // 검증자 노드 포트
-	Result        bool               `json:"result"`        // 검증 결과
-	Signature     *ValidateSignature `json:"signature"`     // 검증자 서명 정보
+var (
+	ErrNotFound            = errors.New("block not found")           // 블록을 찾지 못 했을 경우의 에러
+	ErrInvalidBlockHash    = errors.New("block hash mismatch")       // 블록 해시가 헤더 내용과 일치하지 않을 경우의 에러
+	ErrInvalidTxID         = errors.New("transaction id mismatch")   // 트랜잭션 ID가 내용과 일치하지 않을 경우의 에러
+	ErrInvalidMerkleRoot   = errors.New("merkle root mismatch")      // 머클 루트가 트랜잭션과 일치하지 않을 경우의 에러
+	ErrInvalidWitnessRoot  = errors.New("witness root mismatch")     // 증인 루트가 트랜잭션의 서명, 증인 값과 일치하지 않을 경우의 에러
+	ErrInvalidRoleInfoHash = errors.New("role info digest mismatch") // 역할 정보 다이제스트가 일치하지 않을 경우의 에러
+)
+
+// bytes 형태의 블록정보를 json으로 복구
+func (b *Block) restore(data []byte) {
+	utils.FromBytes(b, data)
 }
 
-// 블록을 찾지 못 했을 경우의 에러
-var ErrNotFound = errors.New("block not found")
+// 역할 정보의 정규 직렬화 값을 해시화
+func (r *RoleInfo) digest() string {
+	if r == nil {
+		return ""
+	}
+	return utils.HashBytes(r.serialize())
+}
+
+// 블록 헤더의 정규 직렬화 값을 해시화
+func (h *BlockHeader) hash() string {
+	return utils.HashBytes(h.serialize())
+}
+
+// 블록 헤더로 블록 해시 계산 (트랜잭션과 그 서명, 역할 정보는 헤더의 머클 루트, 증인 루트, 다이제스트로 반영됨)
+func (b *Block) calculateHash() string {
+	return b.BlockHeader.hash()
+}
 
-// 풀노드의 db에 최신 블록을 업데이트
-func PersistBlock(b *Block) {
-	bytes, err := utils.ToBytes(b)
+// 트랜잭션과 역할 정보를 헤더에 반영한 뒤 블록 해시 계산 (형식이 잘못된 트랜잭션 ID가 있다면 에러)
+func (b *Block) seal() error {
+	root, err := merkleRoot(b.Transaction)
 	if err != nil {
-		log.Error(err)
+		return err
 	}
-	dbStorage.SaveBlock(b.Hash, bytes)
+	b.MerkleRoot = root
+	b.WitnessRoot = witnessRoot(b.Transaction)
+	b.RoleInfoHash = b.RoleInfo.digest()
+	b.Hash = b.calculateHash()
+	return nil
 }
 
-// bytes 형태의 블록정보를 json으로 복구
-func (b *Block) restore(data []byte) {
-	utils.FromBytes(b, data)
+// 수신한 블록의 트랜잭션 ID, 머클 루트, 증인 루트, 역할 정보 다이제스트, 블록 해시를 다시 계산하여 내용과 일치하는지 확인
+func (b *Block) verifyHash() error {
+	for _, tx := range b.Transaction {
+		if tx.ID != tx.calculateID() {
+			return fmt.Errorf("%w: %s", ErrInvalidTxID, tx.ID)
+		}
+	}
+	root, err := merkleRoot(b.Transaction)
+	if err != nil {
+		return err
+	}
+	if b.MerkleRoot != root {
+		return fmt.Errorf("%w: %s", ErrInvalidMerkleRoot, b.Hash)
+	}
+	if b.WitnessRoot != witnessRoot(b.Transaction) {
+		return fmt.Errorf("%w: %s", ErrInvalidWitnessRoot, b.Hash)
+	}
+	if b.RoleInfoHash != b.RoleInfo.digest() {
+		return fmt.Errorf("%w: %s", ErrInvalidRoleInfoHash, b.Hash)
+	}
+	if b.Hash != b.calculateHash() {
+		return fmt.Errorf("%w: %s", ErrInvalidBlockHash, b.Hash)
+	}
+	return nil
 }
 
-// 블록 해시에 서명
-func BlockSign(b *Block, port string) *ValidateSignature {
-	sig := &ValidateSignature{
-		Port:      port,
-		Address:   wallet.Wallet(port).Address,
-		Signature: wallet.Sign(b.Hash, wallet.Wallet(port)),
-	}
-	return sig
+// 합의 투표 종류 (VotePayload의 kind)
+const (
+	PrevoteKind   = "prevote"   // 사전 투표
+	PrecommitKind = "precommit" // 사전 커밋 (블록에 대한 사전 커밋의 서명은 블록의 커밋 서명)
+)
+
+// 합의 메시지의 서명 대상: 메시지 종류, 높이, 라운드, 블록 해시를 정규 직렬화하여 해시화
+// 종류를 함께 기록하므로 한 종류의 서명을 다른 종류(또는 블록 해시 자체)의 서명으로 쓸 수 없다
+func VotePayload(kind string, height, round int, hash string) string {
+	e := &canonicalEncoder{}
+	e.writeString("abcfe/consensus")
+	e.writeString(kind)
+	e.writeInt(height)
+	e.writeInt(round)
+	e.writeString(hash)
+	return utils.HashBytes(e.bytes())
+}
+
+// 라운드 제안자가 제안 블록에 서명하는 값 (polRound는 블록이 2/3의 사전 투표를 받은 이전 라운드)
+func ProposalPayload(height, round, polRound int, hash string) string {
+	e := &canonicalEncoder{}
+	e.writeString("abcfe/proposal")
+	e.writeInt(height)
+	e.writeInt(round)
+	e.writeInt(polRound)
+	e.writeString(hash)
+	return utils.HashBytes(e.bytes())
+}
+
+// 합의 라운드에서 블록을 커밋하는 검증자가 서명하는 값 (블록에 대한 사전 커밋 투표의 서명 대상과 같음)
+func CommitPayload(height, round int, hash string) string {
+	return VotePayload(PrecommitKind, height, round, hash)
 }
 
 // 블록 해시로 특정 블록을 조회
@@ -87,64 +167,36 @@ func FindBlock(hash string) (*Block, error) {
 	return block, nil
 }
 
-// 블록 구성 함수
-func CreateBlock(prevHash string, height int, port string, roleInfo *RoleInfo, update bool) *Block {
-	block := &Block{
-		Hash:     "",
-		PrevHash: prevHash,
-		Height:   height,
-	}
-	block.Transaction = Mempool().TxToConfirm(port, roleInfo)
-	block.Timestamp = int(time.Now().Unix())
-	if roleInfo == nil {
-		roleInfo = &RoleInfo{
-			ProposerAddress:         "Staking Address",
-			ProposerPort:            "Staking Port",
-			ProposerSelectedHeight:  b.Height,
-			ValidatorAddress:        []string{"Staking Address", "Staking Address", "Staking Address"},
-			ValidatorPort:           []string{"Staking Port", "Staking Port", "Staking Port"},
-			ValidatorSelectedHeight: b.Height,
-		}
-	}
-	block.RoleInfo = roleInfo
-	block.Hash = utils.Hash(b)
-	if update {
-		PersistBlock(block)
+// 블록 높이로 메인 체인의 블록 조회
+func FindBlockByHeight(height int) (*Block, error) {
+	hash := dbStorage.FindHashByHeight(height)
+	if hash == "" {
+		return nil, ErrNotFound
 	}
-	return block
+	return FindBlock(hash)
 }
 
-// 블록 유효성 검증
-func ValidateBlock(roleInfo *RoleInfo, proposalBlock *Block, createdBlock *Block, port string) *ValidatedInfo {
-	var result = true
-	var sig *ValidateSignature
-
-	if proposalBlock.PrevHash != createdBlock.PrevHash {
-		fmt.Println("Not pass: prev")
-		result = false
-	}
-	if proposalBlock.Height != createdBlock.Height {
-		fmt.Println("Not pass: height")
-		result = false
-	}
-	if !compareTransactions(proposalBlock.Transaction, createdBlock.Transaction) {
-		fmt.Println("Not pass: tx")
-		result = false
-	}
-	if !compareRoleInfo(proposalBlock.RoleInfo, createdBlock.RoleInfo) {
-		fmt.Println("Not pass: roleinfo")
-		result = false
+// 블록 구성 함수 (역할 정보는 선출 결과여야 하며, 검증자의 서명은 합의 엔진이 커밋할 때 붙인다). 이전 블록이 없다면 nil
+func CreateBlock(prevHash string, height int, port string, roleInfo *RoleInfo) *Block {
+	prev, err := FindBlock(prevHash)
+	if err != nil {
+		log.Error(err)
+		return nil
 	}
-	if result {
-		sig = BlockSign(proposalBlock, port)
+	block := &Block{
+		Hash: "",
+		BlockHeader: BlockHeader{
+			PrevHash: prevHash,
+			Height:   height,
+		},
 	}
-	v := &ValidatedInfo{
-		ProposerPort:  roleInfo.ProposerPort,
-		ProposalBlock: proposalBlock,
-		Port:          port,
-		Result:        result,
-		Signature:     sig,
+	block.reveal(prev, port)
+	block.Transaction = Mempool().TxToConfirm(port, roleInfo, height)
+	block.Timestamp = int(time.Now().Unix())
+	block.RoleInfo = roleInfo
+	if err := block.seal(); err != nil {
+		log.Error(err)
+		return nil
 	}
-
-	return v
+	return block
 }
@@ -2,6 +2,8 @@ package blockchain
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"sync"
 
@@ -13,25 +15,39 @@ import (
 
 // 블록체인 정보에 대한 구조체
 type blockchain struct {
-	NewestHash string     `json:"newestHash"` // 블록체인 중 최근 블록 해시 값
-	Height     int        `json:"height"`     // 블록체인의 현 블록 높이
-	m          sync.Mutex // data race를 방지하기 위한 라이브러리
+	NewestHash string         `json:"newestHash"` // 블록체인 중 최근 블록 해시 값
+	Height     int            `json:"height"`     // 블록체인의 현 블록 높이
+	Committed  int            `json:"committed"`  // 확정 높이 (이 높이까지의 블록은 체인 재구성으로 되돌리지 않음)
+	sideBlocks map[string]int // 확정 높이 위에 저장한 곁가지 블록 (블록 해시 -> 높이, 디비의 곁가지 블록 기록과 같음)
+	m          sync.Mutex     // data race를 방지하기 위한 라이브러리
 }
 
-// 스테이킹 정보에 대한 구조체
-type StakingInfo struct {
-	ID        string `json:"id"`        // 스테이킹 트랜잭션의 해시 값
-	Address   string `json:"address"`   // 스테이커 주소
-	Port      string `json:"port"`      // 스테이커 노드 포트
-	TimeStamp int    `json:"timestamp"` // 스테이킹 트랜잭션의 타임스탬프
+const (
+	defaultBlocksLimit = 20  // 블록 범위 조회 시 기본 개수
+	maxBlocksLimit     = 100 // 블록 범위 조회 시 최대 개수
+)
+
+// 블록 범위 조회 결과 (최신 순)
+type BlocksPage struct {
+	Blocks     []*Block `json:"blocks"`
+	NextCursor int      `json:"nextCursor,omitempty"` // 다음 페이지의 to 값 (더 조회할 블록이 없다면 생략)
 }
 
 type storage interface {
 	FindBlock(hash string) []byte
-	SaveBlock(hash string, data []byte)
-	SaveChain(data []byte)
 	LoadChain() []byte
-	DeleteAllBlocks()
+	FindUTXO(outpoint string) []byte
+	UTXOsByAddress(address string) [][]byte
+	FindUndo(hash string) []byte
+	FindHashByHeight(height int) string
+	FindTxIndex(txID string) []byte
+	AddressHistory(address, cursor string, limit int) ([][]byte, string)
+	LoadIndexTip(name string) string
+	AssetIssuances(asset string) [][]byte
+	AssetIDs() []string
+	Validators() [][]byte
+	SideBlocks() map[string]int
+	Write(batch *db.Batch) error
 }
 
 var (
@@ -45,33 +61,94 @@ func (b *blockchain) restore(data []byte) {
 	utils.FromBytes(b, data)
 }
 
-// 풀노드의 db에 현 블록체인 상태 저장
-func persistBlockchain(b *blockchain) {
-	bytes, err := utils.ToBytes(b)
-	if err != nil {
-		log.Error(err)
+// 블록을 체인 끝에 연결: 블록 데이터, UTXO 셋, 체인 상태를 하나의 배치로 원자적으로 저장 (호출하는 쪽에서 b.m을 잠가야 함)
+func (b *blockchain) connectBlock(block *Block) error {
+	batch := db.NewBatch()
+	if err := stageBlock(batch, block, b.tipState().connect(block)); err != nil {
+		return err
 	}
-	dbStorage.SaveChain(bytes)
-}
 
-// 블록체인 상태 업데이트
-func (b *blockchain) UpdateBlockchain(block *Block) {
+	prevHash, prevHeight, prevCommitted := b.NewestHash, b.Height, b.Committed
 	b.NewestHash = block.Hash
 	b.Height = block.Height
-	persistBlockchain(b)
+	b.Committed = committedHeight(b.Height, b.Committed)
+	sideBlocks := b.stageSideBlocks(batch, nil, nil, b.Committed)
+	chainBytes, err := utils.ToBytes(b)
+	if err == nil {
+		batch.SaveChain(chainBytes)
+		err = dbStorage.Write(batch)
+	}
+	if err != nil {
+		b.NewestHash, b.Height, b.Committed = prevHash, prevHeight, prevCommitted
+		return err
+	}
+	b.sideBlocks = sideBlocks
+	return nil
 }
 
-// 블록체인에 블록 추가
-func (b *blockchain) AddBlock(port string, roleInfo *RoleInfo) *Block {
-	block := CreateBlock(b.NewestHash, b.Height+1, port, roleInfo, true)
-	b.UpdateBlockchain(block)
-	return block
+// 블록 임포트 파이프라인. 동기화, 가십, 로컬 제안 블록 모두 이 함수로 검증한 뒤 체인에 연결한다
+func (b *blockchain) ImportBlock(block *Block) error {
+	b.m.Lock()
+	defer b.m.Unlock()
+	return b.importBlock(block)
+}
+
+// 최신 블록에 이어지는 블록은 바로 연결하고, 그 외의 블록은 곁가지로 처리 (호출하는 쪽에서 b.m을 잠가야 함)
+func (b *blockchain) importBlock(block *Block) error {
+	if block == nil {
+		return ErrNilBlock
+	}
+	if err := block.verifyHash(); err != nil {
+		return err
+	}
+	if dbStorage.FindBlock(block.Hash) != nil {
+		return nil // 이미 저장된 블록 (메인 체인 또는 곁가지)
+	}
+	if block.PrevHash != b.NewestHash {
+		return b.importSideBlock(block)
+	}
+	if err := validateBlock(block, b.tipState()); err != nil {
+		return err
+	}
+	if err := b.connectBlock(block); err != nil {
+		return err
+	}
+	Mempool().removeConfirmed(block)
+	return nil
+}
+
+// 현재 최신 블록과 디비의 UTXO 셋을 기준으로 한 체인 상태 (호출하는 쪽에서 b.m을 잠가야 함)
+func (b *blockchain) tipState() *chainState {
+	s := newChainState()
+	s.hash = b.NewestHash
+	s.height = b.Height
+	s.base = findUTXO
+	return s
+}
+
+// 최신 블록의 해시와 높이. 블록 연결과 동시에 읽는 다른 패키지(합의, p2p, rpc)에서 사용
+func (b *blockchain) Tip() (hash string, height int) {
+	b.m.Lock()
+	defer b.m.Unlock()
+	return b.NewestHash, b.Height
+}
+
+// 락을 잡고 읽은 체인 상태. 블록 연결과 동시에 트랜잭션을 검증하는 쪽에서 사용
+func (b *blockchain) snapshot() *chainState {
+	b.m.Lock()
+	defer b.m.Unlock()
+	return b.tipState()
 }
 
 // 전체 블록 탐색 후 반환
 func Blocks(b *blockchain) []*Block {
 	b.m.Lock()
 	defer b.m.Unlock()
+	return b.blocks()
+}
+
+// 최신 블록부터 제네시스 블록까지 탐색 (호출하는 쪽에서 b.m을 잠가야 함)
+func (b *blockchain) blocks() []*Block {
 	var blocks []*Block
 	hashCursor := b.NewestHash
 	for {
@@ -86,6 +163,39 @@ func Blocks(b *blockchain) []*Block {
 	return blocks // 모든 블록이 담긴 slice 반환
 }
 
+// 높이가 from 이상 to 이하인 블록을 최신 순으로 최대 limit개 조회 (0 이하의 값은 각각 제네시스 블록, 최신 블록, 기본 개수를 의미)
+func BlocksByRange(b *blockchain, from, to, limit int) (*BlocksPage, error) {
+	b.m.Lock()
+	height := b.Height
+	b.m.Unlock()
+	if from < genesisHeight {
+		from = genesisHeight
+	}
+	if to <= 0 || to > height {
+		to = height
+	}
+	if limit <= 0 {
+		limit = defaultBlocksLimit
+	}
+	if limit > maxBlocksLimit {
+		limit = maxBlocksLimit
+	}
+
+	page := &BlocksPage{Blocks: []*Block{}}
+	for h := to; h >= from; h-- {
+		if len(page.Blocks) == limit {
+			page.NextCursor = h
+			break
+		}
+		block, err := FindBlockByHeight(h)
+		if err != nil {
+			return nil, err
+		}
+		page.Blocks = append(page.Blocks, block)
+	}
+	return page, nil
+}
+
 // 전체 트랜잭션 반환
 func Txs(b *blockchain) []*Tx {
 	var txs []*Tx
@@ -95,8 +205,12 @@ func Txs(b *blockchain) []*Tx {
 	return txs
 }
 
-// 특정 트랜잭션 정보 반환
+// 특정 트랜잭션 정보 반환 (트랜잭션 색인을 사용하지 않는다면 전체 체인을 탐색)
 func FindTx(b *blockchain, targetID string) *Tx {
+	if txIndexEnabled {
+		tx, _, _ := FindTransaction(targetID)
+		return tx
+	}
 	for _, tx := range Txs(b) {
 		if tx.ID == targetID {
 			return tx
@@ -105,43 +219,33 @@ func FindTx(b *blockchain, targetID string) *Tx {
 	return nil
 }
 
-// 트랜잭션의 input으로 사용되지 않은 UTXO들을 반환
+// 트랜잭션의 input으로 사용되지 않은 UTXO들을 반환 (멤풀의 트랜잭션이 사용하려는 UTXO는 제외)
 func UTxOutsByAddress(address string, b *blockchain) []*UTxOut {
 	var uTxOuts []*UTxOut
-	creatorTxs := make(map[string]bool)
-	for _, block := range Blocks(b) {
-		for _, tx := range block.Transaction {
-			for _, input := range tx.TxIns { // 트랜잭션안의 input을 추적
-				if input.Signature == "COINBASE" {
-					break
-				}
-				if FindTx(b, input.TxID).TxOuts[input.Index].Address == address {
-					creatorTxs[input.TxID] = true // 어떤 트랜잭션이 output을 input으로 사용했는지 bool로 마킹
-				}
-			}
-			for index, output := range tx.TxOuts {
-				if output.Address == address {
-					if _, ok := creatorTxs[tx.ID]; !ok { // ok는 이 map안에 값의 유무 bool
-						uTxOut := &UTxOut{tx.ID, index, output.Amount, tx.InputData}
-						if !isOnMempool(uTxOut) { // UTXO의 output을 확인해서, mempool에 있는지 확인
-							uTxOuts = append(uTxOuts, uTxOut)
-						}
-					}
-				}
-			}
+	for _, e := range utxosByAddress(address) {
+		uTxOut := e.uTxOut()
+		if !isOnMempool(uTxOut) { // UTXO의 output을 확인해서, mempool에 있는지 확인
+			uTxOuts = append(uTxOuts, uTxOut)
 		}
 	}
 	return uTxOuts
 }
 
-// 특정 주소의 코인 잔액
-func BalanceByAddress(address string, b *blockchain) int {
+// 특정 주소의 기본 코인 잔액 (다른 자산의 잔액은 AssetBalancesByAddress)
+func BalanceByAddress(address string, b *blockchain) (Amount, error) {
 	txOuts := UTxOutsByAddress(address, b)
-	var amount int
+	var amount Amount
 	for _, txOut := range txOuts {
-		amount += txOut.Amount
+		if txOut.Asset != NativeAsset {
+			continue
+		}
+		sum, err := amount.Add(txOut.Amount)
+		if err != nil {
+			return 0, err
+		}
+		amount = sum
 	}
-	return amount
+	return amount, nil
 }
 
 // 풀노드 재 구동 시 저장된 블록체인 상태 불러오기
@@ -153,9 +257,14 @@ func Blockchain() *blockchain {
 		checkpoint := dbStorage.LoadChain()
 
 		if checkpoint == nil {
+			b.sideBlocks = make(map[string]int)
 			b.AddGenesisBlock()
 		} else {
 			b.restore(checkpoint)
+			b.sideBlocks = dbStorage.SideBlocks() // 재시작 전에 저장한 곁가지 블록도 상한 계산에 반영
+			if err := b.reindex(); err != nil {   // UTXO 셋이나 색인이 체인과 어긋나 있다면 다시 구성
+				log.Error(err)
+			}
 		}
 	})
 	return b
@@ -170,95 +279,33 @@ func Status(b *blockchain, rw http.ResponseWriter) {
 	}
 }
 
-// 노드간 브로드캐스팅을 통해, 블록 높이 비교 후 대체
-func (b *blockchain) Replace(newBlocks []*Block) {
+// 노드간 브로드캐스팅으로 전달받은 체인의 블록을 오래된 순으로 임포트 (포크 선택 규칙에 따라 필요하면 체인을 재구성)
+// 확정 높이 아래에서 갈라진 블록과 그 자손은 보낸 peer의 잘못이 아니므로 건너뛰며, 전달받은 체인이 선택되지 않았다면 ErrShorterChain 반환
+func (b *blockchain) Replace(newBlocks []*Block) error {
 	b.m.Lock()
 	defer b.m.Unlock()
-	b.Height = len(newBlocks)
-	b.NewestHash = newBlocks[0].Hash
-	persistBlockchain(b)
-	dbStorage.DeleteAllBlocks()
-	for _, block := range newBlocks {
-		PersistBlock(block)
-	}
-}
-
-// 노드간 새로 추가된 블록을 저장
-func (b *blockchain) AddPeerBlock(newBlock *Block) {
-	b.m.Lock()
-	m.m.Lock()
-	defer b.m.Unlock()
-	defer m.m.Unlock()
-
-	b.Height += 1
-	b.NewestHash = newBlock.Hash
-
-	persistBlockchain(b)
-	PersistBlock(newBlock)
-
-	for _, tx := range newBlock.Transaction {
-		_, ok := m.Txs[tx.ID] // 만약 Txs map에 이 ID를 가진 tx가 있다고 한다면, 이미 다른 노드에 의해 사용된 멤풀의 트잭이므로, 우리 노드의 멤풀에서 삭제
-		if ok {
-			delete(m.Txs, tx.ID)
+	skipped := make(map[string]bool)
+	for i := len(newBlocks) - 1; i >= 0; i-- { // newBlocks는 최신 블록부터 정렬되어 있음
+		block := newBlocks[i]
+		if block != nil && skipped[block.PrevHash] {
+			skipped[block.Hash] = true
+			continue
 		}
-	}
-}
-
-// 스테이커의 스테이킹과 관련된 UTXO 반환
-func UTxOutsByStakingAddress(stakingAddress string, b *blockchain) ([]*UTxOut, []*Tx, []int) {
-	var uTxOuts []*UTxOut
-	var Txs []*Tx
-	var indexes []int
-
-	creatorTxs := make(map[string]bool)
-	for _, block := range Blocks(b) {
-		for _, tx := range block.Transaction {
-			for _, input := range tx.TxIns {
-				if input.Signature == "COINBASE" {
-					break
-				}
-				if FindTx(b, input.TxID).TxOuts[input.Index].Address == stakingAddress {
-					creatorTxs[input.TxID] = true
-				}
-			}
-			for index, output := range tx.TxOuts {
-				if output.Address == stakingAddress && output.Amount == utils.StakingQuantity {
-					if _, ok := creatorTxs[tx.ID]; !ok {
-						uTxOut := &UTxOut{tx.ID, index, output.Amount, tx.InputData}
-						if !isOnMempool(uTxOut) {
-							uTxOuts = append(uTxOuts, uTxOut)
-							indexes = append(indexes, index)
-							Txs = append(Txs, tx)
-						}
-					}
-				}
+		if err := b.importBlock(block); err != nil {
+			if errors.Is(err, ErrBelowCommitted) {
+				skipped[block.Hash] = true
+				continue
 			}
+			return err
 		}
 	}
-	return uTxOuts, Txs, indexes
-}
-
-// 스테이커 리스트 반환
-func GetStakingList(Txs []*Tx, b *blockchain) []*StakingInfo {
-	var sInfos []*StakingInfo
-	var stakerAddr string
-	for _, tx := range Txs {
-		for _, input := range tx.TxIns {
-			stakerAddr = FindTx(b, input.TxID).TxOuts[input.Index].Address
-		}
-		sInfo := &StakingInfo{tx.ID, stakerAddr, tx.InputData, tx.Timestamp}
-		sInfos = append(sInfos, sInfo)
+	if len(newBlocks) > 0 && newBlocks[0].Hash != b.NewestHash {
+		return fmt.Errorf("%w: %d <= %d", ErrShorterChain, newBlocks[0].Height, b.Height)
 	}
-	return sInfos
+	return nil
 }
 
-// 스테이킹 유무 확인
-func CheckStaking(stakingInfoList []*StakingInfo, targetAddress string) *StakingInfo {
-	var sInfo *StakingInfo
-	for _, info := range stakingInfoList {
-		if info.Address == targetAddress {
-			sInfo = info
-		}
-	}
-	return sInfo
+// 노드간 새로 추가된 블록을 저장
+func (b *blockchain) AddPeerBlock(newBlock *Block) error {
+	return b.ImportBlock(newBlock)
 }
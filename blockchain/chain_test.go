@@ -0,0 +1,62 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestBlocksByRange(t *testing.T) {
+	bc := newTestChain(t)
+	for i := 0; i < 4; i++ {
+		mine(t, bc, coinbaseOnly)
+	}
+	tip := genesisHeight + 4
+	tests := []struct {
+		name            string
+		from, to, limit int
+		want            []int
+		next            int
+	}{
+		{"whole chain", 0, 0, 0, []int{tip, tip - 1, tip - 2, tip - 3, genesisHeight}, 0},
+		{"limited page", 0, 0, 2, []int{tip, tip - 1}, tip - 2},
+		{"next page", 0, tip - 2, 2, []int{tip - 2, tip - 3}, genesisHeight},
+		{"last page", 0, genesisHeight, 2, []int{genesisHeight}, 0},
+		{"bounded range", genesisHeight + 1, genesisHeight + 2, 0, []int{genesisHeight + 2, genesisHeight + 1}, 0},
+		{"to beyond the tip", tip - 1, tip + 10, 0, []int{tip, tip - 1}, 0},
+		{"empty range", tip, tip - 1, 0, nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := BlocksByRange(bc, tt.from, tt.to, tt.limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []int
+			for _, block := range page.Blocks {
+				got = append(got, block.Height)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) || page.NextCursor != tt.next {
+				t.Fatalf("heights = %v (next %d), want %v (next %d)", got, page.NextCursor, tt.want, tt.next)
+			}
+		})
+	}
+}
+
+func TestFindBlockByHeight(t *testing.T) {
+	bc := newTestChain(t)
+	genesis := tipBlock(t, bc)
+	block := mine(t, bc, coinbaseOnly)
+	for _, want := range []*Block{genesis, block} {
+		got, err := FindBlockByHeight(want.Height)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Hash != want.Hash {
+			t.Fatalf("block at height %d = %s, want %s", want.Height, got.Hash, want.Hash)
+		}
+	}
+	if _, err := FindBlockByHeight(block.Height + 1); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("err = %v, want %v", err, ErrNotFound)
+	}
+}
@@ -0,0 +1,220 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/abcfe-op/abcfe-node/wallet"
+)
+
+// 트랜잭션 입력으로 쓸 UTXO를 고르는 전략
+type CoinSelection string
+
+const (
+	SelectInOrder        CoinSelection = ""              // 체인에 기록된 순서대로 (기본)
+	SelectLargestFirst   CoinSelection = "largest-first" // 금액이 큰 UTXO부터 (입력 수 최소화)
+	SelectBranchAndBound CoinSelection = "bnb"           // 거스름돈이 생기지 않는 조합 탐색, 없다면 largest-first
+	SelectRandom         CoinSelection = "random"        // 무작위 순서 (금액이나 생성 순서로 지갑의 UTXO를 묶어 추적하기 어렵게 함)
+)
+
+const (
+	maxBnBTries              = 100000 // branch-and-bound 탐색 시 최대 시도 횟수
+	defaultConsolidateInputs = 100    // UTXO 통합 시 기본 입력 수
+	maxConsolidateInputs     = 500    // UTXO 통합 시 최대 입력 수
+	consolidateMaxLoad       = 50     // 멤풀 사용량(%)이 이보다 낮을 때만 UTXO 통합 (멤풀이 붐비지 않으면 최소 수수료로 충분)
+)
+
+var (
+	ErrUnknownCoinSelection = errors.New("unknown coin selection strategy")
+	ErrNothingToConsolidate = errors.New("not enough small outputs to consolidate")
+	ErrMempoolBusy          = errors.New("mempool is busy, consolidate later")
+)
+
+func (c CoinSelection) validate() error {
+	switch c {
+	case SelectInOrder, SelectLargestFirst, SelectBranchAndBound, SelectRandom:
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrUnknownCoinSelection, c)
+}
+
+// 후보 UTXO 중 합이 target 이상이 되도록 고름 (모자라면 false)
+func (c CoinSelection) choose(candidates []*utxoEntry, target Amount) ([]*utxoEntry, bool) {
+	ordered := append([]*utxoEntry(nil), candidates...)
+	switch c {
+	case SelectLargestFirst:
+		sortByAmount(ordered, true)
+	case SelectBranchAndBound:
+		if chosen := branchAndBound(candidates, target); chosen != nil {
+			return chosen, true
+		}
+		sortByAmount(ordered, true)
+	case SelectRandom:
+		rand.Shuffle(len(ordered), func(i, j int) {
+			ordered[i], ordered[j] = ordered[j], ordered[i]
+		})
+	}
+	var chosen []*utxoEntry
+	var total Amount
+	for _, e := range ordered {
+		if total >= target {
+			break
+		}
+		chosen = append(chosen, e)
+		total = saturatingAdd(total, e.Output.Amount)
+	}
+	return chosen, total >= target
+}
+
+// 합이 정확히 target인 UTXO 조합을 깊이 우선으로 탐색 (금액이 큰 순서로, 남은 합이 모자라면 가지치기). 없거나 시도 횟수를 넘기면 nil
+func branchAndBound(candidates []*utxoEntry, target Amount) []*utxoEntry {
+	if target == 0 {
+		return nil
+	}
+	sorted := append([]*utxoEntry(nil), candidates...)
+	sortByAmount(sorted, true)
+	remaining := make([]Amount, len(sorted)+1) // remaining[i]: sorted[i:]의 합
+	for i := len(sorted) - 1; i >= 0; i-- {
+		remaining[i] = saturatingAdd(remaining[i+1], sorted[i].Output.Amount)
+	}
+	var path []*utxoEntry
+	tries := 0
+	var search func(i int, sum Amount) bool
+	search = func(i int, sum Amount) bool {
+		if sum == target {
+			return true
+		}
+		if i == len(sorted) || tries >= maxBnBTries || target-sum > remaining[i] {
+			return false
+		}
+		tries++
+		if amount := sorted[i].Output.Amount; amount <= target-sum {
+			path = append(path, sorted[i])
+			if search(i+1, sum+amount) {
+				return true
+			}
+			path = path[:len(path)-1]
+		}
+		// 같은 금액의 UTXO를 제외하는 가지는 이미 탐색한 조합과 같으므로 건너뜀
+		j := i + 1
+		for j < len(sorted) && sorted[j].Output.Amount == sorted[i].Output.Amount {
+			j++
+		}
+		return search(j, sum)
+	}
+	if !search(0, 0) {
+		return nil
+	}
+	return path
+}
+
+func sortByAmount(entries []*utxoEntry, descending bool) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if descending {
+			return entries[i].Output.Amount > entries[j].Output.Amount
+		}
+		return entries[i].Output.Amount < entries[j].Output.Amount
+	})
+}
+
+// 넘치면 최댓값에 머무는 덧셈 (선택 중 비교용이며, 실제 합은 Add로 다시 계산)
+func saturatingAdd(a, b Amount) Amount {
+	if sum, err := a.Add(b); err == nil {
+		return sum
+	}
+	return math.MaxUint64
+}
+
+// 지금 입력으로 쓸 수 있는 UTXO인가 (멤풀의 트랜잭션이 사용하려는 출력과 시간 잠금이 풀리지 않은 출력은 제외)
+func spendable(e *utxoEntry, at lockContext) bool {
+	if isOnMempool(e.uTxOut()) {
+		return false
+	}
+	return e.Output.Lock == nil || e.Output.Lock.check(e, at) == nil
+}
+
+// 노드 지갑의 작은 기본 코인 UTXO들(maxAmount 이하, 0이면 금액 제한 없음)을 작은 것부터 최대 maxInputs개 모아
+// 지갑 주소의 출력 하나로 합치는 트랜잭션을 mempool에 추가. 멤풀이 붐빌 때는 수수료가 낮지 않으므로 거절한다
+func (m *mempool) Consolidate(maxAmount Amount, maxInputs int, fee Amount, port string) (*Tx, error) {
+	if m.load() >= consolidateMaxLoad {
+		return nil, reject(RejectBusy, ErrMempoolBusy)
+	}
+	tx, err := makeConsolidationTx(wallet.Wallet(port).Address, maxAmount, maxInputs, fee, port)
+	if err != nil {
+		return nil, makeTxReject(err)
+	}
+	if err := m.add(tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// 멤풀 사용량 (트랜잭션 수와 크기 중 제한 대비 큰 쪽의 백분율)
+func (m *mempool) load() int {
+	m.m.Lock()
+	defer m.m.Unlock()
+	load := 0
+	if limit := mempoolPolicy.MaxCount; limit > 0 {
+		load = len(m.txs) * 100 / limit
+	}
+	if limit := mempoolPolicy.MaxBytes; limit > 0 && m.bytes*100/limit > load {
+		load = m.bytes * 100 / limit
+	}
+	return load
+}
+
+func makeConsolidationTx(from string, maxAmount Amount, maxInputs int, fee Amount, port string) (*Tx, error) {
+	if fee < MinTxFee {
+		return nil, fmt.Errorf("%w: %d < %d", ErrFeeTooLow, fee, MinTxFee)
+	}
+	if maxInputs <= 0 {
+		maxInputs = defaultConsolidateInputs
+	}
+	if maxInputs > maxConsolidateInputs {
+		maxInputs = maxConsolidateInputs
+	}
+	at := Blockchain().snapshot().nextLockContext()
+	var candidates []*utxoEntry
+	for _, e := range utxosByAddress(from) {
+		if e.Output.Asset != NativeAsset || (maxAmount != 0 && e.Output.Amount > maxAmount) || !spendable(e, at) {
+			continue
+		}
+		candidates = append(candidates, e)
+	}
+	sortByAmount(candidates, false)
+	if len(candidates) > maxInputs {
+		candidates = candidates[:maxInputs]
+	}
+	if len(candidates) < 2 {
+		return nil, ErrNothingToConsolidate
+	}
+	var txIns []*TxIn
+	var total Amount
+	for _, e := range candidates {
+		var err error
+		if total, err = total.Add(e.Output.Amount); err != nil {
+			return nil, err
+		}
+		txIns = append(txIns, &TxIn{TxID: e.TxID, Index: e.Index, Signature: from})
+	}
+	if total <= fee {
+		return nil, ErrorNoMoney
+	}
+	tx := &Tx{
+		Timestamp: int(time.Now().Unix()),
+		TxIns:     txIns,
+		TxOuts:    []*TxOut{{Address: from, Amount: total - fee}},
+		InputData: "consolidate",
+		Kind:      KindTransfer,
+	}
+	tx.getId()
+	tx.sign(port)
+	if err := validate(tx); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrorNotValid, err)
+	}
+	return tx, nil
+}
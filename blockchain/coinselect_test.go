@@ -0,0 +1,189 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// 주어진 금액들의 UTXO 후보 (체인 순서)
+func candidates(amounts ...Amount) []*utxoEntry {
+	entries := make([]*utxoEntry, len(amounts))
+	for i, v := range amounts {
+		entries[i] = &utxoEntry{TxID: fmt.Sprintf("%02x", i), Index: 0, Output: &TxOut{Address: "aa", Amount: v}}
+	}
+	return entries
+}
+
+func chosenAmounts(entries []*utxoEntry) []Amount {
+	var amounts []Amount
+	for _, e := range entries {
+		amounts = append(amounts, e.Output.Amount)
+	}
+	return amounts
+}
+
+func TestCoinSelectionChoose(t *testing.T) {
+	tests := []struct {
+		name       string
+		selection  CoinSelection
+		candidates []*utxoEntry
+		target     Amount
+		want       []Amount
+		ok         bool
+	}{
+		{"in order", SelectInOrder, candidates(5, 20, 10), 22, []Amount{5, 20}, true},
+		{"largest first", SelectLargestFirst, candidates(5, 20, 10), 22, []Amount{20, 10}, true},
+		{"largest first keeps the chain order of ties", SelectLargestFirst, candidates(5, 10, 10), 10, []Amount{10}, true},
+		{"exact match", SelectBranchAndBound, candidates(8, 20, 7, 3), 15, []Amount{8, 7}, true},
+		{"exact match of a single output", SelectBranchAndBound, candidates(8, 20, 7, 3), 20, []Amount{20}, true},
+		{"no exact match falls back to largest first", SelectBranchAndBound, candidates(8, 20, 7), 16, []Amount{20}, true},
+		{"not enough", SelectLargestFirst, candidates(5, 10), 16, []Amount{10, 5}, false},
+		{"no candidates", SelectInOrder, nil, 1, nil, false},
+		{"overflowing candidates", SelectInOrder, candidates(math.MaxUint64, math.MaxUint64), math.MaxUint64, []Amount{math.MaxUint64}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chosen, ok := tt.selection.choose(tt.candidates, tt.target)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if got := chosenAmounts(chosen); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("chosen = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBranchAndBound(t *testing.T) {
+	tests := []struct {
+		name       string
+		candidates []*utxoEntry
+		target     Amount
+		want       []Amount
+	}{
+		{"largest combination first", candidates(1, 2, 3, 4, 5), 9, []Amount{5, 4}},
+		{"needs a smaller output", candidates(6, 5, 4), 9, []Amount{5, 4}},
+		{"all outputs", candidates(6, 5, 4), 15, []Amount{6, 5, 4}},
+		{"duplicate amounts", candidates(3, 3, 3, 3), 6, []Amount{3, 3}},
+		{"above the total", candidates(6, 5, 4), 16, nil},
+		{"no exact sum", candidates(10, 10), 15, nil},
+		{"zero target", candidates(1), 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := chosenAmounts(branchAndBound(tt.candidates, tt.target)); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("chosen = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectRandomCoversTarget(t *testing.T) {
+	pool := candidates(1, 2, 3, 4, 5, 6, 7, 8)
+	firsts := make(map[string]bool)
+	for i := 0; i < 200; i++ {
+		chosen, ok := SelectRandom.choose(pool, 10)
+		if !ok {
+			t.Fatal("random selection did not cover the target")
+		}
+		var total, last Amount
+		seen := make(map[string]bool)
+		for _, e := range chosen {
+			if seen[e.TxID] {
+				t.Fatalf("output %s chosen twice", e.TxID)
+			}
+			seen[e.TxID] = true
+			total += e.Output.Amount
+			last = e.Output.Amount
+		}
+		if total < 10 || total-last >= 10 {
+			t.Fatalf("chosen %v do not just cover 10", chosenAmounts(chosen))
+		}
+		firsts[chosen[0].TxID] = true
+	}
+	if len(firsts) < 2 {
+		t.Fatal("random selection always starts with the same output")
+	}
+	if got := chosenAmounts(pool); !reflect.DeepEqual(got, []Amount{1, 2, 3, 4, 5, 6, 7, 8}) {
+		t.Fatalf("candidates were reordered: %v", got)
+	}
+}
+
+func TestCoinSelectionValidate(t *testing.T) {
+	for _, c := range []CoinSelection{SelectInOrder, SelectLargestFirst, SelectBranchAndBound, SelectRandom} {
+		if err := c.validate(); err != nil {
+			t.Fatalf("%q: %v", c, err)
+		}
+	}
+	if err := CoinSelection("smallest-first").validate(); !errors.Is(err, ErrUnknownCoinSelection) {
+		t.Fatalf("err = %v, want %v", err, ErrUnknownCoinSelection)
+	}
+	bc := newTestChain(t)
+	fund(t, bc)
+	_, err := Mempool().AddTxOuts([]*TxOut{{Address: "ab", Amount: 1}}, MinTxFee, "smallest-first", "", 0, nodeKey.port)
+	if !errors.Is(err, ErrUnknownCoinSelection) || RejectReason(err) != RejectInvalid {
+		t.Fatalf("err = %v, want a rejected %v", err, ErrUnknownCoinSelection)
+	}
+}
+
+func TestConsolidate(t *testing.T) {
+	// 노드 지갑의 UTXO: 50, 10, 50, 10
+	tests := []struct {
+		name       string
+		maxAmount  Amount
+		maxInputs  int
+		fee        Amount
+		wantInputs int
+		wantOutput Amount
+		want       error
+	}{
+		{"small outputs", 10, 0, 1, 2, 19, nil},
+		{"all outputs", 0, 0, 1, 4, 119, nil},
+		{"smallest outputs up to the input limit", 0, 3, 2, 3, 68, nil},
+		{"only one output fits", 10, 1, 1, 0, 0, ErrNothingToConsolidate},
+		{"no small outputs", 5, 0, 1, 0, 0, ErrNothingToConsolidate},
+		{"fee eats the outputs", 10, 0, 20, 0, 0, ErrorNoMoney},
+		{"fee below the minimum", 10, 0, MinTxFee - 1, 0, 0, ErrFeeTooLow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bc := newTestChain(t)
+			fund(t, bc)
+			tx, err := Mempool().Consolidate(tt.maxAmount, tt.maxInputs, tt.fee, nodeKey.port)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			if err != nil {
+				return
+			}
+			if len(tx.TxIns) != tt.wantInputs || len(tx.TxOuts) != 1 || tx.TxOuts[0].Amount != tt.wantOutput || tx.TxOuts[0].Address != nodeKey.address {
+				t.Fatalf("consolidation spends %d inputs into %v, want %d into %d", len(tx.TxIns), tx.TxOuts, tt.wantInputs, tt.wantOutput)
+			}
+			mine(t, bc, fromMempool)
+			for _, txIn := range tx.TxIns {
+				if findUTXO(txIn.TxID, txIn.Index) != nil {
+					t.Fatalf("consolidated output %s is still unspent", outpoint(txIn.TxID, txIn.Index))
+				}
+			}
+			if e := findUTXO(tx.ID, 0); e == nil || e.Output.Amount != tt.wantOutput {
+				t.Fatal("consolidated output is not in the utxo set")
+			}
+		})
+	}
+}
+
+func TestConsolidateWaitsForQuietMempool(t *testing.T) {
+	bc := newTestChain(t)
+	fund(t, bc)
+	withPolicy(t, MempoolPolicy{MaxCount: 2, MaxBytes: 8 << 20, MaxPerSender: 25, ExpirySec: DayToSec})
+	if _, err := Mempool().AddTx("ab", 1, MinTxFee, "", nodeKey.port); err != nil {
+		t.Fatal(err)
+	}
+	_, err := Mempool().Consolidate(0, 0, MinTxFee, nodeKey.port)
+	if !errors.Is(err, ErrMempoolBusy) || RejectReason(err) != RejectBusy {
+		t.Fatalf("err = %v, want a busy rejection", err)
+	}
+}
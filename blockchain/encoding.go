@@ -0,0 +1,200 @@
+package blockchain
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// 해시 계산에 사용하는 정규 직렬화 인코더
+// Go의 출력 포맷(%v)이나 포인터 주소에 의존하지 않도록, 필드를 정해진 순서와 고정된 형식으로 기록한다.
+//   - 정수: 8바이트 빅엔디언 (int64)
+//   - 문자열: 8바이트 길이 + UTF-8 바이트
+//   - 슬라이스: 8바이트 개수 + 각 원소
+type canonicalEncoder struct {
+	buf bytes.Buffer
+}
+
+// 부호 없는 64비트 정수 기록
+func (e *canonicalEncoder) writeUint64(v uint64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], v)
+	e.buf.Write(b[:])
+}
+
+// 정수 기록
+func (e *canonicalEncoder) writeInt(v int) {
+	e.writeUint64(uint64(int64(v)))
+}
+
+// 길이가 앞에 붙은 문자열 기록
+func (e *canonicalEncoder) writeString(s string) {
+	e.writeUint64(uint64(len(s)))
+	e.buf.WriteString(s)
+}
+
+// 개수가 앞에 붙은 문자열 슬라이스 기록
+func (e *canonicalEncoder) writeStrings(ss []string) {
+	e.writeUint64(uint64(len(ss)))
+	for _, s := range ss {
+		e.writeString(s)
+	}
+}
+
+// 참/거짓을 1/0으로 변환
+func boolToInt(v bool) int {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+// 지금까지 기록된 바이트열 반환
+func (e *canonicalEncoder) bytes() []byte {
+	return e.buf.Bytes()
+}
+
+// 트랜잭션의 정규 직렬화. ID와 서명(발행자 서명 포함)은 해시 대상(서명 대상)이 아니므로 제외
+func (t *Tx) serialize() []byte {
+	e := &canonicalEncoder{}
+	e.writeInt(t.Timestamp)
+	e.writeUint64(uint64(len(t.TxIns)))
+	for _, txIn := range t.TxIns {
+		e.writeString(txIn.TxID)
+		e.writeInt(txIn.Index)
+	}
+	e.writeUint64(uint64(len(t.TxOuts)))
+	for _, txOut := range t.TxOuts {
+		e.writeString(txOut.Address)
+		e.writeUint64(uint64(txOut.Amount))
+		if txOut.Multisig == nil { // 일반 출력은 M = 0, 공개키 0개로 기록
+			e.writeInt(0)
+			e.writeStrings(nil)
+		} else {
+			e.writeInt(txOut.Multisig.M)
+			e.writeStrings(txOut.Multisig.PubKeys)
+		}
+		if txOut.Lock == nil { // 잠금이 없는 출력은 빈 기준, 값 0으로 기록
+			e.writeString("")
+			e.writeInt(0)
+			e.writeInt(0)
+		} else {
+			e.writeString(string(txOut.Lock.Kind))
+			e.writeInt(boolToInt(txOut.Lock.Relative))
+			e.writeInt(txOut.Lock.Value)
+		}
+		e.writeString(txOut.Script)
+		e.writeString(txOut.Asset)
+	}
+	e.writeString(t.InputData)
+	e.writeInt(t.LockTime)
+	e.writeString(string(t.Kind))
+	t.Payload.serialize(e)
+	return e.bytes()
+}
+
+// 서명과 증인 값까지 포함한 트랜잭션 전체의 정규 직렬화 (블록 헤더의 증인 루트 계산에 사용)
+// 서명은 ID에서 제외되므로, 블록이 증인 루트로 함께 고정하지 않으면 중계하는 노드가 다른 유효한 서명이나 스크립트 경로로 바꿔치기할 수 있다
+func (t *Tx) serializeWitness() []byte {
+	e := &canonicalEncoder{}
+	e.buf.Write(t.serialize())
+	for _, txIn := range t.TxIns {
+		e.writeString(txIn.Signature)
+		e.writeStrings(txIn.Signatures)
+		e.writeStrings(txIn.Witness)
+	}
+	if t.Payload != nil && t.Payload.Issue != nil {
+		e.writeString(t.Payload.Issue.Signature)
+	} else {
+		e.writeString("")
+	}
+	return e.bytes()
+}
+
+// 페이로드의 정규 직렬화. 페이로드가 없으면 버전 0만 기록하고, 종류별 항목은 있음/없음(1/0) 다음에 내용을 기록한다
+func (p *TxPayload) serialize(e *canonicalEncoder) {
+	if p == nil {
+		e.writeInt(0)
+		return
+	}
+	e.writeInt(p.Version)
+	e.writeInt(boolToInt(p.Stake != nil))
+	if p.Stake != nil {
+		e.writeString(p.Stake.Staker)
+		e.writeString(p.Stake.Port)
+	}
+	e.writeInt(boolToInt(p.Unstake != nil))
+	if p.Unstake != nil {
+		e.writeString(p.Unstake.StakeTxID)
+		e.writeInt(p.Unstake.Index)
+	}
+	e.writeInt(boolToInt(p.Coinbase != nil))
+	if p.Coinbase != nil {
+		e.writeInt(p.Coinbase.Height)
+	}
+	e.writeInt(boolToInt(p.Slash != nil))
+	if p.Slash != nil {
+		e.writeString(p.Slash.Offender)
+		e.writeUint64(uint64(len(p.Slash.Votes)))
+		for _, v := range p.Slash.Votes {
+			e.writeInt(boolToInt(v != nil)) // 빈 투표는 표시만 기록 (검증에서 거부됨)
+			if v != nil {
+				e.writeString(v.Type)
+				e.writeInt(v.Height)
+				e.writeInt(v.Round)
+				e.writeString(v.Hash)
+				e.writeString(v.Signature)
+			}
+		}
+	}
+	e.writeInt(boolToInt(p.Issue != nil))
+	if p.Issue != nil { // 발행자 서명은 트랜잭션 ID에 대한 서명이므로 제외
+		e.writeString(p.Issue.Issuer)
+		e.writeString(p.Issue.Name)
+		e.writeInt(p.Issue.Decimals)
+		e.writeUint64(uint64(p.Issue.Amount))
+	}
+}
+
+// 자산 ID 계산에 사용하는 정규 직렬화
+func serializeAsset(issuer, name string, decimals int) []byte {
+	e := &canonicalEncoder{}
+	e.writeString("asset")
+	e.writeString(issuer)
+	e.writeString(name)
+	e.writeInt(decimals)
+	return e.bytes()
+}
+
+// 다중서명 잠금 조건의 정규 직렬화 (다중서명 주소 계산에 사용)
+func (l *MultisigLock) serialize() []byte {
+	e := &canonicalEncoder{}
+	e.writeInt(l.M)
+	e.writeStrings(l.PubKeys)
+	return e.bytes()
+}
+
+// 블록 헤더의 정규 직렬화. 블록 해시는 헤더만을 대상으로 계산한다
+func (h *BlockHeader) serialize() []byte {
+	e := &canonicalEncoder{}
+	e.writeString(h.PrevHash)
+	e.writeInt(h.Height)
+	e.writeInt(h.Timestamp)
+	e.writeString(h.MerkleRoot)
+	e.writeString(h.WitnessRoot)
+	e.writeString(h.RoleInfoHash)
+	e.writeString(h.RandaoReveal)
+	e.writeString(h.RandaoMix)
+	return e.bytes()
+}
+
+// 역할 정보의 정규 직렬화
+func (r *RoleInfo) serialize() []byte {
+	e := &canonicalEncoder{}
+	e.writeString(r.ProposerAddress)
+	e.writeString(r.ProposerPort)
+	e.writeInt(r.ProposerSelectedHeight)
+	e.writeStrings(r.ValidatorAddress)
+	e.writeStrings(r.ValidatorPort)
+	e.writeInt(r.ValidatorSelectedHeight)
+	return e.bytes()
+}
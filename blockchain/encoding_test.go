@@ -0,0 +1,200 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/abcfe-op/abcfe-node/common/utils"
+)
+
+func sampleTx() *Tx {
+	tx := &Tx{
+		Timestamp: 1700000000,
+		TxIns:     []*TxIn{{TxID: "aa", Index: 1, Signature: "sig"}},
+		TxOuts: []*TxOut{
+			{Address: "bb", Amount: 10},
+			{Address: "cc", Amount: 20, Multisig: &MultisigLock{M: 1, PubKeys: []string{"dd", "ee"}}},
+			{Address: "ff", Amount: 30, Lock: &TimeLock{Kind: LockByHeight, Relative: true, Value: 5}},
+		},
+		InputData: "memo",
+		LockTime:  7,
+		Kind:      KindTransfer,
+	}
+	tx.getId()
+	return tx
+}
+
+func TestTxIDIsPinned(t *testing.T) {
+	// 정규 직렬화 형식이 바뀌면 노드마다 트랜잭션 ID가 달라지므로 고정 값으로 확인
+	const want = "83561e3337a91945332f171c418e708c113b9104eb01a1f1335ad4069d74471a"
+	if got := sampleTx().ID; got != want {
+		t.Fatalf("tx id = %s, want %s", got, want)
+	}
+}
+
+func TestTxIDCoversContent(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(tx *Tx)
+	}{
+		{"timestamp", func(tx *Tx) { tx.Timestamp++ }},
+		{"input outpoint", func(tx *Tx) { tx.TxIns[0].Index++ }},
+		{"output address", func(tx *Tx) { tx.TxOuts[0].Address = "b0" }},
+		{"output amount", func(tx *Tx) { tx.TxOuts[0].Amount++ }},
+		{"output order", func(tx *Tx) { tx.TxOuts[0], tx.TxOuts[1] = tx.TxOuts[1], tx.TxOuts[0] }},
+		{"multisig threshold", func(tx *Tx) { tx.TxOuts[1].Multisig.M = 2 }},
+		{"multisig keys", func(tx *Tx) { tx.TxOuts[1].Multisig.PubKeys = []string{"dde", "e"} }},
+		{"time lock kind", func(tx *Tx) { tx.TxOuts[2].Lock.Kind = LockByTime }},
+		{"time lock relative", func(tx *Tx) { tx.TxOuts[2].Lock.Relative = false }},
+		{"script", func(tx *Tx) { tx.TxOuts[0].Script = "OP_TRUE" }},
+		{"asset", func(tx *Tx) { tx.TxOuts[0].Asset = "ab" }},
+		{"input data", func(tx *Tx) { tx.InputData = "other" }},
+		{"lock time", func(tx *Tx) { tx.LockTime = 0 }},
+		{"kind", func(tx *Tx) { tx.Kind = KindStake }},
+		{"payload", func(tx *Tx) {
+			tx.Payload = newPayload()
+			tx.Payload.Stake = &StakePayload{Staker: "bb", Port: "4000"}
+		}},
+	}
+	base := sampleTx().ID
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := sampleTx()
+			tt.mutate(tx)
+			if tx.calculateID() == base {
+				t.Fatal("changing the field did not change the tx id")
+			}
+		})
+	}
+}
+
+func TestTxIDExcludesSignatures(t *testing.T) {
+	tx := sampleTx()
+	tx.TxIns[0].Signature = "other"
+	tx.TxIns[0].Signatures = []string{"a", "b"}
+	tx.TxIns[0].Witness = []string{"c"}
+	if tx.calculateID() != tx.ID {
+		t.Fatal("signatures changed the tx id")
+	}
+}
+
+func TestTxIDSurvivesEncodingRoundTrip(t *testing.T) {
+	tx := sampleTx()
+	data, err := json.Marshal(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fromJSON Tx
+	if err := json.Unmarshal(data, &fromJSON); err != nil {
+		t.Fatal(err)
+	}
+	if fromJSON.calculateID() != tx.ID {
+		t.Fatal("tx id changed after a JSON round trip")
+	}
+	data, err = utils.ToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fromGob Tx
+	if err := utils.FromBytes(&fromGob, data); err != nil {
+		t.Fatal(err)
+	}
+	if fromGob.calculateID() != tx.ID {
+		t.Fatal("tx id changed after a gob round trip")
+	}
+}
+
+func TestCanonicalEncoderIsUnambiguous(t *testing.T) {
+	encode := func(write func(e *canonicalEncoder)) string {
+		e := &canonicalEncoder{}
+		write(e)
+		return string(e.bytes())
+	}
+	tests := []struct {
+		name string
+		a, b func(e *canonicalEncoder)
+	}{
+		{
+			"string boundaries",
+			func(e *canonicalEncoder) { e.writeString("ab"); e.writeString("c") },
+			func(e *canonicalEncoder) { e.writeString("a"); e.writeString("bc") },
+		},
+		{
+			"slice boundaries",
+			func(e *canonicalEncoder) { e.writeStrings([]string{"a", "b"}); e.writeStrings(nil) },
+			func(e *canonicalEncoder) { e.writeStrings([]string{"a"}); e.writeStrings([]string{"b"}) },
+		},
+		{
+			"empty slice and empty string",
+			func(e *canonicalEncoder) { e.writeStrings(nil) },
+			func(e *canonicalEncoder) { e.writeStrings([]string{""}) },
+		},
+		{
+			"negative integers",
+			func(e *canonicalEncoder) { e.writeInt(-1) },
+			func(e *canonicalEncoder) { e.writeInt(1) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if encode(tt.a) == encode(tt.b) {
+				t.Fatal("different values share an encoding")
+			}
+		})
+	}
+}
+
+func TestBlockHashCoversHeader(t *testing.T) {
+	sample := func() *Block {
+		block := &Block{
+			BlockHeader: BlockHeader{PrevHash: "aa", Height: 2, Timestamp: 1700000000, RandaoReveal: "bb", RandaoMix: "cc"},
+			Transaction: []*Tx{sampleTx()},
+			RoleInfo:    &RoleInfo{ProposerAddress: "dd", ProposerPort: "4000", ValidatorAddress: []string{"ee"}, ValidatorPort: []string{"4001"}},
+		}
+		block.seal()
+		return block
+	}
+	tests := []struct {
+		name   string
+		mutate func(b *Block)
+		want   error
+	}{
+		{"prev hash", func(b *Block) { b.PrevHash = "ab" }, ErrInvalidBlockHash},
+		{"height", func(b *Block) { b.Height++ }, ErrInvalidBlockHash},
+		{"timestamp", func(b *Block) { b.Timestamp++ }, ErrInvalidBlockHash},
+		{"randao reveal", func(b *Block) { b.RandaoReveal = "ba" }, ErrInvalidBlockHash},
+		{"randao mix", func(b *Block) { b.RandaoMix = "ca" }, ErrInvalidBlockHash},
+		{"transaction", func(b *Block) { b.Transaction[0].InputData = "other"; b.Transaction[0].getId() }, ErrInvalidMerkleRoot},
+		{"transaction id", func(b *Block) { b.Transaction[0].InputData = "other" }, ErrInvalidTxID},
+		{"role info", func(b *Block) { b.RoleInfo.ValidatorPort = []string{"4002"} }, ErrInvalidRoleInfoHash},
+		{"input signature", func(b *Block) { b.Transaction[0].TxIns[0].Signature = "other" }, ErrInvalidWitnessRoot},
+		{"multisig signatures", func(b *Block) { b.Transaction[0].TxIns[0].Signatures = []string{"s1", "s2"} }, ErrInvalidWitnessRoot},
+		{"script witness", func(b *Block) { b.Transaction[0].TxIns[0].Witness = []string{"01"} }, ErrInvalidWitnessRoot},
+		{"issuer signature", func(b *Block) {
+			b.Transaction[0].Payload = &TxPayload{Issue: &Issuance{Signature: "other"}}
+			b.Transaction[0].getId()
+			b.MerkleRoot, _ = merkleRoot(b.Transaction)
+		}, ErrInvalidWitnessRoot},
+		{"witness root", func(b *Block) { b.WitnessRoot = "ab" }, ErrInvalidWitnessRoot},
+	}
+	if err := sample().verifyHash(); err != nil {
+		t.Fatalf("sealed block does not verify: %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			block := sample()
+			tt.mutate(block)
+			if err := block.verifyHash(); !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+	// 서명만 바꿔 다시 봉인하면 트랜잭션 ID는 그대로지만 블록 해시는 달라짐
+	block, other := sample(), sample()
+	other.Transaction[0].TxIns[0].Signature = "other"
+	other.seal()
+	if other.Transaction[0].ID != block.Transaction[0].ID || other.Hash == block.Hash {
+		t.Fatal("block hash does not cover the transaction signatures")
+	}
+}
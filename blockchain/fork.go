@@ -0,0 +1,360 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/abcfe-op/abcfe-node/common/utils"
+	"github.com/abcfe-op/abcfe-node/db"
+
+	log "github.com/abcfe-op/abcfe-node/common/logger"
+)
+
+var (
+	ErrUnknownParent     = errors.New("parent block is unknown")
+	ErrMissingUndo       = errors.New("undo record is missing")
+	ErrBelowCommitted    = errors.New("block conflicts with a committed block")
+	ErrSideBranchTooDeep = errors.New("side branch is too deep")
+	ErrTooManySideBlocks = errors.New("too many side blocks")
+)
+
+const (
+	maxSideDepth  = Epoch // 곁가지 하나에 보관하는 블록 수의 상한
+	maxSideBlocks = 16    // 확정 높이 위에 보관하는 곁가지 블록 수의 상한
+)
+
+// 확정 높이: 이전 에포크의 마지막 블록까지. 에포크의 검증자는 그 블록까지의 상태로 선출되므로,
+// 에포크가 바뀐 뒤에는 그 이전의 블록을 되돌리지 않고 곁가지는 현재 에포크 안에서만 경쟁한다 (확정 높이는 줄어들지 않음)
+func committedHeight(height, committed int) int {
+	return max(committed, epochStart(height)-1)
+}
+
+// 곁가지 블록 기록 변경을 배치에 추가하고, 배치를 반영한 뒤 b.sideBlocks로 쓸 기록을 반환
+// added는 새로 곁가지가 된 블록, removed는 메인 체인에 연결된 블록이며, 확정 높이 아래로 내려간 곁가지 블록은
+// 더 이상 선택될 수 없으므로 상한 계산에서 제외하도록 함께 지운다 (블록 데이터는 남겨둠)
+func (b *blockchain) stageSideBlocks(batch *db.Batch, added, removed []*Block, committed int) map[string]int {
+	next := make(map[string]int, len(b.sideBlocks)+len(added))
+	for hash, height := range b.sideBlocks {
+		next[hash] = height
+	}
+	for _, block := range removed {
+		if _, ok := next[block.Hash]; ok {
+			delete(next, block.Hash)
+			batch.DeleteSideBlock(block.Hash)
+		}
+	}
+	for _, block := range added {
+		next[block.Hash] = block.Height
+		batch.SaveSideBlock(block.Hash, block.Height)
+	}
+	for hash, height := range next {
+		if height <= committed {
+			delete(next, hash)
+			batch.DeleteSideBlock(hash)
+		}
+	}
+	return next
+}
+
+// 블록의 undo 기록 불러오기 (트랜잭션마다 입력으로 사용된 출력 목록)
+func loadUndo(hash string) ([][]*utxoEntry, error) {
+	data := dbStorage.FindUndo(hash)
+	if data == nil {
+		return nil, fmt.Errorf("%w: %s", ErrMissingUndo, hash)
+	}
+	var spent [][]*utxoEntry
+	if err := utils.FromBytes(&spent, data); err != nil {
+		return nil, err
+	}
+	return spent, nil
+}
+
+// 블록의 undo 기록 저장을 배치에 추가
+func stageUndo(batch *db.Batch, hash string, spent [][]*utxoEntry) error {
+	data, err := utils.ToBytes(spent)
+	if err != nil {
+		return err
+	}
+	batch.SaveUndo(hash, data)
+	return nil
+}
+
+// 체인 상태에서 최신 블록을 되돌림: 블록이 만든 출력은 제거하고, 사용한 출력은 복구
+func (s *chainState) disconnect(block *Block, spent [][]*utxoEntry) {
+	for i := len(block.Transaction) - 1; i >= 0; i-- {
+		tx := block.Transaction[i]
+		for _, e := range blockUTXOs(block, tx) {
+			s.utxos[outpoint(e.TxID, e.Index)] = nil
+		}
+		if i < len(spent) {
+			for _, e := range spent[i] {
+				s.utxos[outpoint(e.TxID, e.Index)] = e
+			}
+		}
+	}
+	s.hash = block.PrevHash
+	s.height = block.Height - 1
+}
+
+// 블록을 되돌리는 변경 작업(UTXO 셋 복구, 색인 제거)을 배치에 추가. 블록 데이터와 undo 기록은 곁가지 블록으로 남겨둔다
+func stageDisconnect(batch *db.Batch, block *Block, spent [][]*utxoEntry) error {
+	for i := len(block.Transaction) - 1; i >= 0; i-- {
+		tx := block.Transaction[i]
+		for _, e := range blockUTXOs(block, tx) {
+			batch.SpendUTXO(outpoint(e.TxID, e.Index))
+		}
+		if i < len(spent) {
+			for _, e := range spent[i] {
+				data, err := utils.ToBytes(e)
+				if err != nil {
+					return err
+				}
+				batch.AddUTXO(outpoint(e.TxID, e.Index), e.Output.Address, data)
+			}
+		}
+		if tx.Kind == KindIssue {
+			batch.DeleteIssuance(tx.Payload.Issue.Asset(), block.Height, tx.ID)
+		}
+		var txSpent []*utxoEntry
+		if i < len(spent) {
+			txSpent = spent[i]
+		}
+		if err := unstageValidators(batch, block, tx, txSpent); err != nil {
+			return err
+		}
+		if txIndexEnabled {
+			batch.DeleteTxIndex(tx.ID)
+		}
+		if addressIndexEnabled {
+			for _, txOut := range tx.TxOuts {
+				batch.DeleteHistory(txOut.Address, block.Height, tx.ID)
+			}
+			if i < len(spent) {
+				for _, e := range spent[i] {
+					batch.DeleteHistory(e.Output.Address, block.Height, tx.ID)
+				}
+			}
+		}
+	}
+	batch.DeleteHeight(block.Height)
+	return nil
+}
+
+// 검증자 자리 중 서명을 남긴 검증자 수 (서명 자체의 유효성은 validateBlockSignatures에서 확인)
+func signatureCount(block *Block) int {
+	if block.RoleInfo == nil {
+		return 0
+	}
+	signed := make(map[string]bool)
+	for _, sig := range block.Signature {
+		if sig != nil && contains(block.RoleInfo.ValidatorAddress, sig.Address) {
+			signed[sig.Address] = true
+		}
+	}
+	return len(signed)
+}
+
+// 메인 체인에 연결된 블록인가 (높이 색인이 같은 해시를 가리키는지 확인)
+func onMainChain(block *Block) bool {
+	return dbStorage.FindHashByHeight(block.Height) == block.Hash
+}
+
+// 곁가지의 최신 블록에서부터 메인 체인과 만나는 분기점까지 거슬러 올라가며 곁가지 블록을 모음 (최신 순)
+func findFork(tip *Block) ([]*Block, *Block, error) {
+	var branch []*Block
+	block := tip
+	for !onMainChain(block) {
+		branch = append(branch, block)
+		parent, err := FindBlock(block.PrevHash)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %s", ErrUnknownParent, block.PrevHash)
+		}
+		block = parent
+	}
+	return branch, block, nil
+}
+
+// 메인 체인의 최신 블록부터 분기점 직전까지의 블록 (최신 순, 호출하는 쪽에서 b.m을 잠가야 함)
+func (b *blockchain) blocksAbove(fork *Block) ([]*Block, error) {
+	var blocks []*Block
+	for hash := b.NewestHash; hash != fork.Hash; {
+		block, err := FindBlock(hash)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, block)
+		hash = block.PrevHash
+	}
+	return blocks, nil
+}
+
+// 메인 체인의 높이 시점의 체인 상태 (최신 블록부터 undo 기록으로 되돌림, 호출하는 쪽에서 b.m을 잠가야 함)
+func (b *blockchain) stateAt(height int) (*chainState, error) {
+	s := b.tipState()
+	for s.height > height {
+		block, err := FindBlock(s.hash)
+		if err != nil {
+			return nil, err
+		}
+		spent, err := loadUndo(block.Hash)
+		if err != nil {
+			return nil, err
+		}
+		s.disconnect(block, spent)
+	}
+	return s, nil
+}
+
+// 포크 선택 규칙: 분기점 이후 검증자 서명이 더 많은 체인 > 더 높은 체인 > 최신 블록 해시가 더 작은 체인
+// 모든 노드가 같은 블록들을 보고 같은 체인을 선택하므로, 같은 높이에 블록이 동시에 추가되어도 네트워크가 하나의 체인으로 수렴한다
+// (서명 수를 먼저 비교하므로, 적은 수의 검증자가 만든 긴 체인이 정족수가 더 많이 서명한 체인을 밀어내지 못함)
+func preferBranch(branch, main []*Block) bool {
+	branchSigs, mainSigs := 0, 0
+	for _, block := range branch {
+		branchSigs += signatureCount(block)
+	}
+	for _, block := range main {
+		mainSigs += signatureCount(block)
+	}
+	if branchSigs != mainSigs {
+		return branchSigs > mainSigs
+	}
+	branchTip, mainTip := branch[0], main[0]
+	if branchTip.Height != mainTip.Height {
+		return branchTip.Height > mainTip.Height
+	}
+	return branchTip.Hash < mainTip.Hash
+}
+
+// 분기점 시점의 상태에 곁가지 블록을 오래된 순으로 연결한 체인 상태 (main은 분기점 이후의 메인 체인 블록, branch는 곁가지 블록, 둘 다 최신 순)
+func (b *blockchain) branchState(main, branch []*Block) (*chainState, error) {
+	s := b.tipState()
+	for _, block := range main {
+		spent, err := loadUndo(block.Hash)
+		if err != nil {
+			return nil, err
+		}
+		s.disconnect(block, spent)
+	}
+	for i := len(branch) - 1; i >= 0; i-- {
+		s.connect(branch[i])
+	}
+	return s, nil
+}
+
+// 최신 블록에 이어지지 않는 블록 처리: 부모 블록을 알고 있고 확정 높이 위에서 갈라진 블록이라면,
+// 분기점과 곁가지를 반영한 상태로 전체 검증을 마친 뒤 곁가지 블록으로 저장하고,
+// 포크 선택 규칙상 곁가지가 더 나은 체인이라면 체인을 재구성 (호출하는 쪽에서 b.m을 잠가야 함)
+func (b *blockchain) importSideBlock(block *Block) error {
+	if block.PrevHash == "" {
+		return fmt.Errorf("%w: %s", ErrInvalidGenesis, block.Hash)
+	}
+	parent, err := FindBlock(block.PrevHash)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrPrevHashMismatch, block.PrevHash)
+	}
+	if block.Height != parent.Height+1 {
+		return fmt.Errorf("%w: expected %d, got %d", ErrHeightMismatch, parent.Height+1, block.Height)
+	}
+	ancestors, fork, err := findFork(parent)
+	if err != nil {
+		return err
+	}
+	if fork.Height < b.Committed {
+		return fmt.Errorf("%w: fork at %d, committed %d", ErrBelowCommitted, fork.Height, b.Committed)
+	}
+	if len(ancestors)+1 > maxSideDepth {
+		return fmt.Errorf("%w: %d blocks", ErrSideBranchTooDeep, len(ancestors)+1)
+	}
+	if len(b.sideBlocks) >= maxSideBlocks {
+		return fmt.Errorf("%w: %d", ErrTooManySideBlocks, len(b.sideBlocks))
+	}
+	main, err := b.blocksAbove(fork)
+	if err != nil {
+		return err
+	}
+	s, err := b.branchState(main, ancestors)
+	if err != nil {
+		return err
+	}
+	if err := validateBlock(block, s); err != nil {
+		return err
+	}
+
+	blockBytes, err := utils.ToBytes(block)
+	if err != nil {
+		return err
+	}
+	batch := db.NewBatch()
+	batch.SaveBlock(block.Hash, blockBytes)
+	sideBlocks := b.stageSideBlocks(batch, []*Block{block}, nil, b.Committed)
+	if err := dbStorage.Write(batch); err != nil {
+		return err
+	}
+	b.sideBlocks = sideBlocks
+
+	branch := append([]*Block{block}, ancestors...)
+	if len(main) > 0 && !preferBranch(branch, main) {
+		log.Info(fmt.Sprintf("Stored side block %s at height %d", block.Hash, block.Height))
+		return nil
+	}
+	return b.reorganize(fork, main, branch)
+}
+
+// 체인 재구성: 분기점 이후의 메인 체인 블록을 undo 기록으로 되돌린 뒤, 곁가지 블록을 검증하며 연결.
+// 모든 변경은 하나의 배치로 반영되므로, 곁가지 검증에 실패하면 메인 체인은 그대로 유지된다 (호출하는 쪽에서 b.m을 잠가야 함)
+func (b *blockchain) reorganize(fork *Block, main, branch []*Block) error {
+	if len(main) > 0 && fork.Height < b.Committed {
+		return fmt.Errorf("%w: fork at %d, committed %d", ErrBelowCommitted, fork.Height, b.Committed)
+	}
+	s := b.tipState()
+	batch := db.NewBatch()
+	for _, block := range main {
+		spent, err := loadUndo(block.Hash)
+		if err != nil {
+			return err
+		}
+		s.disconnect(block, spent)
+		if err := stageDisconnect(batch, block, spent); err != nil {
+			return err
+		}
+	}
+	for i := len(branch) - 1; i >= 0; i-- {
+		block := branch[i]
+		if err := validateBlock(block, s); err != nil {
+			discard := db.NewBatch()
+			discard.DeleteBlock(block.Hash) // 검증에 실패한 블록은 다시 고려하지 않도록 제거
+			discard.DeleteSideBlock(block.Hash)
+			if err := dbStorage.Write(discard); err != nil {
+				log.Error(err)
+			}
+			delete(b.sideBlocks, block.Hash)
+			return err
+		}
+		if err := stageBlock(batch, block, s.connect(block)); err != nil {
+			return err
+		}
+	}
+
+	committed := committedHeight(s.height, b.Committed)
+	chainBytes, err := utils.ToBytes(&blockchain{NewestHash: s.hash, Height: s.height, Committed: committed})
+	if err != nil {
+		return err
+	}
+	batch.SaveChain(chainBytes)
+	sideBlocks := b.stageSideBlocks(batch, main, branch, committed)
+	if err := dbStorage.Write(batch); err != nil {
+		return err
+	}
+	b.NewestHash, b.Height, b.Committed = s.hash, s.height, committed
+	b.sideBlocks = sideBlocks
+	if len(main) > 0 {
+		log.Warn(fmt.Sprintf("Chain reorganized at height %d: %d blocks disconnected, %d connected", fork.Height, len(main), len(branch)))
+	}
+
+	for i := len(branch) - 1; i >= 0; i-- {
+		Mempool().removeConfirmed(branch[i])
+	}
+	Mempool().restoreDisconnected(main, b.tipState())
+	return nil
+}
@@ -0,0 +1,285 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/abcfe-op/abcfe-node/db"
+)
+
+// 검증자 자리와 서명 주소만 채운 블록 (포크 선택 규칙 확인용)
+func weighedBlock(hash string, height int, signers ...string) *Block {
+	block := &Block{Hash: hash, BlockHeader: BlockHeader{Height: height}}
+	block.RoleInfo = &RoleInfo{ValidatorAddress: []string{"v1", "v2", "v3", "v4"}}
+	for _, address := range signers {
+		block.Signature = append(block.Signature, &ValidateSignature{Address: address})
+	}
+	return block
+}
+
+func TestPreferBranch(t *testing.T) {
+	tests := []struct {
+		name         string
+		branch, main []*Block
+		want         bool
+	}{
+		{
+			"more signatures beat a longer chain",
+			[]*Block{weighedBlock("bb", 3, "v1", "v2", "v3", "v4"), weighedBlock("ba", 2, "v1", "v2", "v3", "v4")},
+			[]*Block{weighedBlock("ac", 4, "v1", "v2", "v3"), weighedBlock("ab", 3, "v1", "v2"), weighedBlock("aa", 2, "v1", "v2")},
+			true,
+		},
+		{
+			"fewer signatures lose to a shorter chain",
+			[]*Block{weighedBlock("bb", 3, "v1", "v2", "v3"), weighedBlock("ba", 2, "v1", "v2", "v3")},
+			[]*Block{weighedBlock("aa", 2, "v1", "v2", "v3", "v4"), weighedBlock("a0", 1, "v1", "v2", "v3")},
+			false,
+		},
+		{
+			"equal signatures prefer the higher chain",
+			[]*Block{weighedBlock("bb", 3, "v1", "v2"), weighedBlock("ba", 2, "v1", "v2")},
+			[]*Block{weighedBlock("aa", 2, "v1", "v2", "v3", "v4")},
+			true,
+		},
+		{
+			"equal weight and height prefer the smaller hash",
+			[]*Block{weighedBlock("aa", 2, "v1", "v2", "v3")},
+			[]*Block{weighedBlock("bb", 2, "v2", "v3", "v4")},
+			true,
+		},
+		{
+			"larger hash loses the tie",
+			[]*Block{weighedBlock("bb", 2, "v1", "v2", "v3")},
+			[]*Block{weighedBlock("aa", 2, "v2", "v3", "v4")},
+			false,
+		},
+		{
+			"duplicate and outside signatures are not counted",
+			[]*Block{weighedBlock("aa", 2, "v1", "v1", "v1", "x1", "x2")},
+			[]*Block{weighedBlock("bb", 2, "v1", "v2")},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := preferBranch(tt.branch, tt.main); got != tt.want {
+				t.Fatalf("preferBranch = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReorganizeToHeavierBranch(t *testing.T) {
+	bc := newTestChain(t)
+	fork := mine(t, bc, coinbaseOnly)
+	tx, err := Mempool().AddTx("ab", 3, MinTxFee, "", nodeKey.port)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale := mine(t, bc, fromMempool)
+	if balance(t, bc, "ab") != 3 {
+		t.Fatal("transfer was not confirmed")
+	}
+
+	side := certifiedBlock(t, fork, 1, coinbaseOnly)
+	if err := bc.ImportBlock(side); err != nil {
+		t.Fatal(err)
+	}
+	if bc.NewestHash != stale.Hash && bc.NewestHash != side.Hash {
+		t.Fatal("tip is neither block of the tie")
+	}
+	tip := certifiedBlock(t, side, 0, coinbaseOnly)
+	if err := bc.ImportBlock(tip); err != nil {
+		t.Fatal(err)
+	}
+
+	if bc.NewestHash != tip.Hash || bc.Height != tip.Height {
+		t.Fatalf("tip = %s at %d, want %s at %d", bc.NewestHash, bc.Height, tip.Hash, tip.Height)
+	}
+	for _, block := range []*Block{fork, side, tip} {
+		if got, err := FindBlockByHeight(block.Height); err != nil || got.Hash != block.Hash {
+			t.Fatalf("height %d does not point to the new branch", block.Height)
+		}
+	}
+	if _, err := FindBlock(stale.Hash); err != nil {
+		t.Fatal("disconnected block was not kept as a side block")
+	}
+	if balance(t, bc, "ab") != 0 {
+		t.Fatal("disconnected transfer is still in the utxo set")
+	}
+	coinbase := stale.Transaction[len(stale.Transaction)-1]
+	for index := range coinbase.TxOuts {
+		if findUTXO(coinbase.ID, index) != nil {
+			t.Fatalf("coinbase output %d of the disconnected block survived", index)
+		}
+	}
+	if _, ok := Mempool().txs[tx.ID]; !ok {
+		t.Fatal("disconnected transfer did not return to the mempool")
+	}
+	assertUTXOSetMatchesRescan(t, bc)
+
+	// 새 에포크로 넘어가 확정된 분기점 아래의 블록은 되돌릴 수 없으므로, 되돌린 블록에 이어지는 블록은 거절됨
+	if bc.Committed < fork.Height+1 {
+		t.Fatalf("committed height = %d, want at least %d", bc.Committed, fork.Height+1)
+	}
+	back := certifiedBlock(t, stale, 0, coinbaseOnly)
+	if err := bc.ImportBlock(back); !errors.Is(err, ErrBelowCommitted) {
+		t.Fatalf("err = %v, want %v", err, ErrBelowCommitted)
+	}
+	if bc.NewestHash != tip.Hash {
+		t.Fatal("block on a committed-away branch moved the tip")
+	}
+}
+
+func TestSideBlocksSurviveRestart(t *testing.T) {
+	withCommitteeSize(t, 4)
+	bc := newTestChain(t)
+	genesis := tipBlock(t, bc)
+	main := certifiedBlock(t, genesis, 0, coinbaseOnly)
+	signBlock(main, 0, 4)
+	if err := bc.ImportBlock(main); err != nil {
+		t.Fatal(err)
+	}
+	side := certifiedBlock(t, genesis, 1, coinbaseOnly)
+	if err := bc.ImportBlock(side); err != nil {
+		t.Fatal(err)
+	}
+	if bc.NewestHash != main.Hash {
+		t.Fatal("lighter side block took over the chain")
+	}
+	b, once = nil, sync.Once{}
+	restarted := Blockchain()
+	if want := map[string]int{side.Hash: side.Height}; !reflect.DeepEqual(restarted.sideBlocks, want) {
+		t.Fatalf("side blocks after restart = %v, want %v", restarted.sideBlocks, want)
+	}
+}
+
+func TestForkChoiceCountsSignaturesBeforeHash(t *testing.T) {
+	// 4명의 검증자 중 정족수는 3명이므로 같은 높이의 블록도 서명 수가 다를 수 있음
+	tests := []struct {
+		name               string
+		mainSigs, sideSigs int
+		wantSide           bool
+	}{
+		{"side block with more signatures wins", 3, 4, true},
+		{"side block with fewer signatures loses", 4, 3, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withCommitteeSize(t, 4)
+			bc := newTestChain(t)
+			genesis := tipBlock(t, bc)
+			main := certifiedBlock(t, genesis, 0, coinbaseOnly)
+			signBlock(main, 0, tt.mainSigs)
+			if err := bc.ImportBlock(main); err != nil {
+				t.Fatal(err)
+			}
+			side := certifiedBlock(t, genesis, 1, coinbaseOnly)
+			signBlock(side, 1, tt.sideSigs)
+			if err := bc.ImportBlock(side); err != nil {
+				t.Fatal(err)
+			}
+			want := main.Hash
+			if tt.wantSide {
+				want = side.Hash
+			}
+			if bc.NewestHash != want {
+				t.Fatalf("tip = %s, want %s", bc.NewestHash, want)
+			}
+		})
+	}
+}
+
+func TestReplaceReportsLighterChain(t *testing.T) {
+	withCommitteeSize(t, 4)
+	bc := newTestChain(t)
+	genesis := tipBlock(t, bc)
+	main := certifiedBlock(t, genesis, 0, coinbaseOnly)
+	signBlock(main, 0, 4)
+	if err := bc.ImportBlock(main); err != nil {
+		t.Fatal(err)
+	}
+	side := certifiedBlock(t, genesis, 1, coinbaseOnly)
+	signBlock(side, 1, 3)
+	if err := bc.Replace([]*Block{side, genesis}); !errors.Is(err, ErrShorterChain) {
+		t.Fatalf("err = %v, want %v", err, ErrShorterChain)
+	}
+	if _, err := FindBlock(side.Hash); err != nil {
+		t.Fatal("lighter chain was not kept as a side block")
+	}
+}
+
+func TestSideBlockLimits(t *testing.T) {
+	// 두 번째 에포크의 마지막 높이까지 쌓으면 확정 높이는 첫 에포크의 마지막 블록이 됨
+	mainChain := func(t *testing.T, bc *blockchain) []*Block {
+		blocks := []*Block{tipBlock(t, bc)}
+		for bc.Height < genesisHeight+2*Epoch-1 {
+			// 모든 검증자가 서명하여 정족수만 서명한 곁가지보다 무겁게 함
+			block := certifiedBlock(t, blocks[len(blocks)-1], 0, coinbaseOnly)
+			signBlock(block, 0, len(block.RoleInfo.ValidatorAddress))
+			if err := bc.ImportBlock(block); err != nil {
+				t.Fatal(err)
+			}
+			blocks = append(blocks, block)
+		}
+		if want := genesisHeight + Epoch - 1; bc.Committed != want {
+			t.Fatalf("committed height = %d, want %d", bc.Committed, want)
+		}
+		return blocks // 높이 순
+	}
+	t.Run("fork below the committed height", func(t *testing.T) {
+		bc := newTestChain(t)
+		blocks := mainChain(t, bc)
+		side := certifiedBlock(t, blocks[1], 1, coinbaseOnly)
+		if err := bc.ImportBlock(side); !errors.Is(err, ErrBelowCommitted) {
+			t.Fatalf("err = %v, want %v", err, ErrBelowCommitted)
+		}
+		// 동기화로 받은 블록이라면 그 자손과 함께 건너뛰고, 보낸 peer의 잘못으로 보지 않음
+		child := certifiedBlock(t, side, 0, coinbaseOnly)
+		if err := bc.Replace([]*Block{child, side}); !errors.Is(err, ErrShorterChain) {
+			t.Fatalf("err = %v, want %v", err, ErrShorterChain)
+		}
+	})
+	t.Run("side branch deeper than an epoch", func(t *testing.T) {
+		withCommitteeSize(t, 4)
+		bc := newTestChain(t)
+		blocks := mainChain(t, bc)
+		// 확정된 블록에서 갈라져 서명이 적은 곁가지를 메인 체인과 같은 높이까지 쌓음
+		prev := blocks[bc.Committed-genesisHeight]
+		for i := 0; i < maxSideDepth; i++ {
+			side := certifiedBlock(t, prev, 1, coinbaseOnly)
+			signBlock(side, 1, 3)
+			if err := bc.ImportBlock(side); err != nil {
+				t.Fatal(err)
+			}
+			prev = side
+		}
+		if bc.NewestHash != blocks[len(blocks)-1].Hash {
+			t.Fatal("lighter side branch took over the chain")
+		}
+		side := certifiedBlock(t, prev, 1, coinbaseOnly)
+		if err := bc.ImportBlock(side); !errors.Is(err, ErrSideBranchTooDeep) {
+			t.Fatalf("err = %v, want %v", err, ErrSideBranchTooDeep)
+		}
+	})
+	t.Run("too many side blocks", func(t *testing.T) {
+		bc := newTestChain(t)
+		blocks := mainChain(t, bc)
+		for i := 0; i < maxSideBlocks; i++ {
+			bc.sideBlocks[fmt.Sprint(i)] = bc.Height
+		}
+		side := certifiedBlock(t, blocks[len(blocks)-2], 1, coinbaseOnly)
+		if err := bc.ImportBlock(side); !errors.Is(err, ErrTooManySideBlocks) {
+			t.Fatalf("err = %v, want %v", err, ErrTooManySideBlocks)
+		}
+		// 확정 높이 아래로 내려간 곁가지 블록은 상한에서 빠짐
+		bc.sideBlocks["old"] = bc.Committed
+		bc.sideBlocks = bc.stageSideBlocks(db.NewBatch(), nil, nil, bc.Committed)
+		if _, ok := bc.sideBlocks["old"]; ok || len(bc.sideBlocks) != maxSideBlocks {
+			t.Fatalf("%d side blocks after pruning", len(bc.sideBlocks))
+		}
+	})
+}
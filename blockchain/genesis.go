@@ -1,68 +1,86 @@
 package blockchain
 
 import (
-	"github.com/abcfe-op/abcfe-node/common/utils"
+	log "github.com/abcfe-op/abcfe-node/common/logger"
 )
 
-// 제네시스 블록 구성 함수
-func createGenesisBlock() *Block {
-	genesisPort := utils.StakingNodePort
-	genesisAddr := utils.StakingAddress
-	roleInfo := &RoleInfo{
-		ProposerAddress:         genesisAddr,
-		ProposerPort:            genesisPort,
-		ProposerSelectedHeight:  1,
-		ValidatorAddress:        []string{genesisAddr, genesisAddr, genesisAddr},
-		ValidatorPort:           []string{genesisPort, genesisPort, genesisPort},
-		ValidatorSelectedHeight: 1,
+// 제네시스 검증자: 스테이커가 선출에 필요한 수보다 적은 동안 같은 가중치로 제안자와 검증자를 뽑는 고정된 후보 (run-nodes/wallets의 4000~4009 지갑)
+var genesisValidators = []*Validator{
+	{Address: "3e48e79c1a74c9ec3b580910ac89a0ba22b5f1e79c04276a42bd12e1a6ab3706c073667d7b51ff1db2997e94d09abd743c610a035f848cc548fda2302d295f13", Port: "4000", Stake: 1},
+	{Address: "d6184f756d61a2867f1f7f05eef3c246012d43c63c96083c22cc95f9fd39b0164b6aff66efb3fb0b8cc712b07b545839dfc49fd9adfab84605871f58143bf500", Port: "4001", Stake: 1},
+	{Address: "5810c9e8c9ccd8f2b4891e91b2f392cb3ebcd0899b504134fc43fa71bbbb324243eb287d311272d734d0645b1bbfb64ce5dbec4f1bd0651c4fb6fb01a7d03425", Port: "4002", Stake: 1},
+	{Address: "5f764492c405c4ed9abc394199cd4c562ba2b50190387a4d953e3af8669c76f359f0d5d680e232a781e4f0ea6eecbb5a866ef8f33091ea52fb6930692f1e9049", Port: "4003", Stake: 1},
+	{Address: "419dd2daf552f3b19a84f38c66873fd8510345d1f2836667ee6e7554bad958b544cc391d903c92fb510b8dd6901052764daee0cebb2321fd8ab0f1255f7960d7", Port: "4004", Stake: 1},
+	{Address: "174c5ea7b6f107cd5a054146f3dcaf05080e3a0a19ee4a472368e9c63f8a63ce1399ed1af3aaad4dbfd60078138a1908713403d56400c5b6f46dfafc8fe90d1c", Port: "4005", Stake: 1},
+	{Address: "ee2fe4bac2596c66f8ee7df93f47c38864c83fac7a69294ac587ad80589d68ab0a77ea975ae60461cb01cf56b8907515eb27cc8575cc384a23398c6a0226ac0d", Port: "4006", Stake: 1},
+	{Address: "4ed362ff5306810e9b8c5d60f9d33dcf7a54d9d970b8185b7ca08d62c00d8ba7e529abce272670dcb56bf67c8d6fe6f09bf47db67a893f2e2298a495a5d6a1a6", Port: "4007", Stake: 1},
+	{Address: "023c6638a63da084fde3a130dbcb758b3f27c5ffb62b0e55022dbd51f1b25784759e5c4ba190845b35e20cdfe40fba1ec637ec73b83b79cf04f411b3d21be309", Port: "4008", Stake: 1},
+	{Address: "03986e3ddb1bb5d36cb4ae232925baa8dbd4cb46567a840554428d866d897f7e7fdeb684423cf487f2906310446e7a94dd7e8b2beb384e540de0a051736078ee", Port: "4009", Stake: 1},
+}
+
+// 제네시스 블록의 역할 정보: 제네시스 검증자의 앞에서부터 committeeSize 명이 검증자, 그 다음 노드가 제안자
+func genesisRoleInfo() *RoleInfo {
+	r := &RoleInfo{
+		ProposerAddress:         genesisValidators[committeeSize].Address,
+		ProposerPort:            genesisValidators[committeeSize].Port,
+		ProposerSelectedHeight:  genesisHeight,
+		ValidatorSelectedHeight: genesisHeight,
 	}
+	for _, v := range genesisValidators[:committeeSize] {
+		r.ValidatorAddress = append(r.ValidatorAddress, v.Address)
+		r.ValidatorPort = append(r.ValidatorPort, v.Port)
+	}
+	return r
+}
+
+// 제네시스 블록 구성 함수 (모든 노드가 동일한 제네시스 블록을 만들 수 있도록 고정된 값만 사용)
+func createGenesisBlock() *Block {
+	roleInfo := genesisRoleInfo()
 	block := &Block{
-		Hash:     "",
-		PrevHash: "",
-		Height:   1,
+		Hash: "",
+		BlockHeader: BlockHeader{
+			PrevHash:  "",
+			Height:    genesisHeight,
+			RandaoMix: genesisRandaoMix,
+		},
 	}
-	block.Transaction = Mempool().GenesisTxToConfirm()
+	block.Transaction = []*Tx{makeGenesisTx()}
 	block.Timestamp = 1231006505 // 비트코인 제네시스 블록의 실제 타임스탬프
 	block.RoleInfo = roleInfo
-	block.Hash = utils.Hash(b)
-	PersistBlock(block)
+	if err := block.seal(); err != nil { // 고정된 제네시스 트랜잭션이므로 일어나지 않음
+		log.Error(err)
+	}
 	return block
 }
 
 // 최초 상태의 블록체인에 제네시스 블록 추가 (위 AddBlock과 구분한 이유는 비트코인의 타임스탬프 등 여러가지 조건을 넣고 싶어서)
 func (b *blockchain) AddGenesisBlock() *Block {
 	block := createGenesisBlock()
-	b.UpdateBlockchain(block)
+	if err := b.connectBlock(block); err != nil {
+		log.Error(err)
+	}
 	return block
 }
 
 // 제네시스 트랜잭션 생성
 func makeGenesisTx() *Tx {
 	txIns := []*TxIn{
-		{"", -1, "COINBASE"}, // 소유주는 채굴자
+		{TxID: "", Index: -1, Signature: "COINBASE"}, // 소유주는 채굴자
 	}
 	txOuts := []*TxOut{
-		{"", proposalReward},
+		{Address: "", Amount: proposalReward},
 	}
+	payload := newPayload()
+	payload.Coinbase = &CoinbasePayload{Height: genesisHeight}
 	tx := Tx{
 		ID:        "",
 		Timestamp: 1231006505,
 		TxIns:     txIns,
 		TxOuts:    txOuts,
 		InputData: "Genesis Block",
+		Kind:      KindCoinbase,
+		Payload:   payload,
 	}
 	tx.getId()
 	return &tx
 }
-
-// 제네시스 블록 확인
-func (m *mempool) GenesisTxToConfirm() []*Tx {
-	coinbase := makeGenesisTx()
-	var txs []*Tx
-	for _, tx := range m.Txs {
-		txs = append(txs, tx)
-	}
-	txs = append(txs, coinbase)
-	m.Txs = make(map[string]*Tx)
-	return txs
-}
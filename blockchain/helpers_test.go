@@ -0,0 +1,264 @@
+package blockchain
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/abcfe-op/abcfe-node/common/utils"
+	"github.com/abcfe-op/abcfe-node/config"
+	"github.com/abcfe-op/abcfe-node/db"
+	"github.com/abcfe-op/abcfe-node/wallet"
+
+	log "github.com/abcfe-op/abcfe-node/common/logger"
+)
+
+// 제네시스 검증자의 키 (run-nodes/wallets의 지갑)
+type testKey struct {
+	port    string
+	address string
+	sign    func(payload string) string
+	prove   func(alpha string) string
+}
+
+var (
+	walletDir string
+	testKeys  = make(map[string]*testKey) // 주소 -> 키
+	nodeKey   *testKey                    // 멤풀이 트랜잭션에 서명하는 노드 지갑 (4000)
+)
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	dir, err := filepath.Abs("../run-nodes/wallets")
+	if err != nil {
+		panic(err)
+	}
+	walletDir = dir
+	for _, v := range genesisValidators {
+		w := wallet.Load(filepath.Join(dir, v.Port+".wallet"))
+		if w.Address != v.Address {
+			panic(fmt.Sprintf("wallet %s does not match the genesis validator", v.Port))
+		}
+		testKeys[v.Address] = &testKey{
+			port:    v.Port,
+			address: v.Address,
+			sign:    func(payload string) string { return wallet.Sign(payload, w) },
+			prove:   func(alpha string) string { return wallet.Prove(alpha, w) },
+		}
+	}
+	nodeKey = testKeys[genesisValidators[0].Address]
+
+	logDir, err := os.MkdirTemp("", "blockchain")
+	if err != nil {
+		panic(err)
+	}
+	cfg := &config.Config{}
+	cfg.Common.Mode = "alpha"
+	cfg.LogInfo.Fpath = filepath.Join(logDir, "log")
+	log.InitLogger(cfg)
+	os.Args = []string{os.Args[0], "-mode=rest", "-port=" + nodeKey.port} // DB 파일 이름은 실행 인자의 포트로 정해짐
+
+	code := m.Run()
+	os.RemoveAll(logDir)
+	os.Exit(code)
+}
+
+// 제네시스 블록만 있는 새 체인으로 시작. 테스트 디렉터리에 DB와 노드 지갑을 두고 패키지 전역 상태를 초기화한다
+func newTestChain(t *testing.T) *blockchain {
+	t.Helper()
+	dir := t.TempDir()
+	for _, sub := range []string{"node_dbs", "wallets"} {
+		if err := os.Mkdir(filepath.Join(dir, sub), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	key, err := os.ReadFile(filepath.Join(walletDir, nodeKey.port+".wallet"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "wallets", nodeKey.port+".wallet"), key, 0644); err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	db.InitDB()
+	t.Cleanup(db.Close)
+	b, once = nil, sync.Once{}
+	m, memOnce = nil, sync.Once{}
+	size := committeeSize
+	t.Cleanup(func() { committeeSize = size })
+	return Blockchain()
+}
+
+// 테스트 동안 에포크마다 선출하는 검증자 수를 바꿈 (제네시스 블록의 역할 정보에도 반영되므로 newTestChain보다 먼저 호출)
+func withCommitteeSize(t *testing.T, size int) {
+	t.Helper()
+	old := committeeSize
+	committeeSize = size
+	t.Cleanup(func() { committeeSize = old })
+}
+
+// 블록 높이의 트랜잭션 구성 (코인베이스 포함)
+type txBuilder func(t *testing.T, roles *RoleInfo, height int) []*Tx
+
+// 코인베이스만 담은 블록
+func coinbaseOnly(t *testing.T, roles *RoleInfo, height int) []*Tx {
+	t.Helper()
+	coinbase, err := makeCoinbaseTx(roles, height, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return []*Tx{coinbase}
+}
+
+// 멤풀의 트랜잭션을 담은 블록
+func fromMempool(t *testing.T, roles *RoleInfo, height int) []*Tx {
+	return Mempool().TxToConfirm(nodeKey.port, roles, height)
+}
+
+// prev의 다음 높이에서 선출된 역할 정보 (최신 블록이라면 체인의 스테이커를 반영하고, 곁가지라면 제네시스 검증자로 선출)
+func electedRoles(t *testing.T, prev *Block) *RoleInfo {
+	t.Helper()
+	bc := Blockchain()
+	var roles *RoleInfo
+	var err error
+	if prev.Hash == bc.NewestHash {
+		roles, err = bc.roles(prev.Height + 1)
+	} else {
+		roles, err = elect(prev, nil)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return roles
+}
+
+// prev에 이어지는 블록: round 라운드의 역할 정보, 제안자의 VRF 증명, 검증자 정족수의 커밋 서명을 갖춤
+func certifiedBlock(t *testing.T, prev *Block, round int, txs txBuilder) *Block {
+	t.Helper()
+	roles := electedRoles(t, prev).ForRound(round)
+	block := &Block{BlockHeader: BlockHeader{PrevHash: prev.Hash, Height: prev.Height + 1, Timestamp: prev.Timestamp + 10 + round}}
+	block.RoleInfo = roles
+	revealBy(block, prev, testKeys[roles.ProposerAddress])
+	block.Transaction = txs(t, roles, block.Height)
+	if err := block.seal(); err != nil {
+		t.Fatal(err)
+	}
+	signBlock(block, round, Quorum(len(roles.ValidatorAddress)))
+	return block
+}
+
+// key의 VRF 증명으로 블록의 무작위 값 기록
+func revealBy(block, prev *Block, key *testKey) {
+	block.RandaoReveal = key.prove(randaoInput(prev.RandaoMix, block.Height))
+	block.RandaoMix = mixRandao(prev.RandaoMix, wallet.ProofOutput(block.RandaoReveal))
+}
+
+// 블록의 검증자 중 앞에서부터 count명이 round 라운드의 커밋 서명을 붙임
+func signBlock(block *Block, round, count int) {
+	block.Signature = nil
+	for _, address := range block.RoleInfo.ValidatorAddress[:count] {
+		block.Signature = append(block.Signature, commitSignature(block, round, testKeys[address]))
+	}
+}
+
+func commitSignature(block *Block, round int, key *testKey) *ValidateSignature {
+	return &ValidateSignature{Port: key.port, Address: key.address, Signature: key.sign(CommitPayload(block.Height, round, block.Hash)), Round: round}
+}
+
+func tipBlock(t *testing.T, bc *blockchain) *Block {
+	t.Helper()
+	block, err := FindBlock(bc.NewestHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return block
+}
+
+// 최신 블록에 라운드 0 블록을 만들어 임포트
+func mine(t *testing.T, bc *blockchain, txs txBuilder) *Block {
+	t.Helper()
+	block := certifiedBlock(t, tipBlock(t, bc), 0, txs)
+	if err := bc.ImportBlock(block); err != nil {
+		t.Fatalf("importing block %d: %v", block.Height, err)
+	}
+	return block
+}
+
+func balance(t *testing.T, bc *blockchain, address string) Amount {
+	t.Helper()
+	amount, err := BalanceByAddress(address, bc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return amount
+}
+
+// 노드 지갑(4000)이 제안하는 라운드 1 블록을 두 개 쌓아, 노드 지갑에 제안자와 검증자 보상을 모음
+func fund(t *testing.T, bc *blockchain) {
+	t.Helper()
+	for i := 0; i < 2; i++ {
+		block := certifiedBlock(t, tipBlock(t, bc), 1, coinbaseOnly)
+		if block.RoleInfo.ProposerAddress != nodeKey.address {
+			t.Fatal("node wallet does not propose round 1")
+		}
+		if err := bc.ImportBlock(block); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// key의 UTXO들을 입력으로 주어진 출력을 만드는 트랜잭션 (서명까지 마침)
+func spendTx(key *testKey, inputs []*utxoEntry, outputs ...*TxOut) *Tx {
+	tx := &Tx{Timestamp: 1700000000, TxOuts: outputs, Kind: KindTransfer}
+	for _, e := range inputs {
+		tx.TxIns = append(tx.TxIns, &TxIn{TxID: e.TxID, Index: e.Index})
+	}
+	tx.getId()
+	for _, txIn := range tx.TxIns {
+		txIn.Signature = key.sign(tx.ID)
+	}
+	return tx
+}
+
+// 노드 지갑이 key에게 스테이킹 수량을 보내고, key가 직접 서명한 스테이킹 트랜잭션을 블록에 담음 (노드 밖의 스테이커)
+func stakeFrom(t *testing.T, bc *blockchain, key *testKey) *Tx {
+	t.Helper()
+	if _, err := Mempool().AddTx(key.address, utils.StakingQuantity+5, MinTxFee, "", nodeKey.port); err != nil {
+		t.Fatal(err)
+	}
+	mine(t, bc, fromMempool)
+	inputs := utxosByAddress(key.address)
+	var total Amount
+	for _, e := range inputs {
+		total += e.Output.Amount
+	}
+	payload := newPayload()
+	payload.Stake = &StakePayload{Staker: key.address, Port: key.port}
+	stake := spendTx(key, nil,
+		&TxOut{Address: key.address, Amount: total - utils.StakingQuantity - MinTxFee},
+		&TxOut{Address: utils.StakingAddress, Amount: utils.StakingQuantity, Lock: stakingLock()})
+	stake.Kind, stake.Payload = KindStake, payload
+	for _, e := range inputs {
+		stake.TxIns = append(stake.TxIns, &TxIn{TxID: e.TxID, Index: e.Index})
+	}
+	stake.getId()
+	for _, txIn := range stake.TxIns {
+		txIn.Signature = key.sign(stake.ID)
+	}
+	if err := Mempool().SubmitTx(stake); err != nil {
+		t.Fatal(err)
+	}
+	mine(t, bc, fromMempool)
+	return stake
+}
@@ -0,0 +1,279 @@
+package blockchain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/abcfe-op/abcfe-node/common/utils"
+	"github.com/abcfe-op/abcfe-node/wallet"
+)
+
+// HTLC 출력의 상태
+const (
+	HTLCPending  = "pending"  // 만드는 트랜잭션이 아직 멤풀에 있음
+	HTLCOpen     = "open"     // 블록에 담겼고 아직 사용되지 않음
+	HTLCClaimed  = "claimed"  // 받는 쪽이 비밀 값으로 가져감
+	HTLCRefunded = "refunded" // 기한이 지나 보낸 쪽이 돌려받음
+)
+
+var (
+	ErrNotHTLC       = errors.New("output is not an HTLC")
+	ErrNotHTLCParty  = errors.New("wallet is not a party of the HTLC")
+	ErrInvalidHTLC   = errors.New("invalid HTLC parameters")
+	ErrWrongPreimage = errors.New("preimage does not match the HTLC hash")
+	ErrHTLCNotFound  = errors.New("HTLC output not found")
+	ErrBadPreimage   = errors.New("preimage must be 32 bytes of hex")
+)
+
+const htlcPreimageLen = 32 // 비밀 값의 바이트 수 (비트코인 등 다른 체인의 HTLC와 같은 32바이트)
+
+// 해시 시간 잠금 계약 (Hash Time-Locked Contract)
+// 기한(Timeout, 블록 높이 또는 유닉스 시간) 전에는 Hash의 원상(비밀 값)을 아는 받는 쪽이, 기한 후에는 보낸 쪽이 사용할 수 있다
+type HTLC struct {
+	Recipient string `json:"recipient"`
+	Sender    string `json:"sender"`
+	Hash      string `json:"hash"` // 비밀 값의 SHA-256 해시 (16진수 문자열)
+	Timeout   int    `json:"timeout"`
+}
+
+// HTLC 출력의 조회 결과
+type HTLCStatus struct {
+	*HTLC
+	TxID     string `json:"txId"`
+	Index    int    `json:"index"`
+	Address  string `json:"address"`
+	Amount   Amount `json:"amount"`
+	Height   int    `json:"height,omitempty"` // 만드는 트랜잭션이 담긴 블록 높이
+	State    string `json:"state"`
+	SpentBy  string `json:"spentBy,omitempty"`  // 사용한 트랜잭션 (멤풀에 있을 수 있음)
+	Preimage string `json:"preimage,omitempty"` // 받는 쪽이 가져가면서 공개한 비밀 값
+}
+
+// HTLC 잠금 스크립트: 증인 값 [서명, 비밀 값, 1]이면 받는 쪽, [서명, 0]이면 기한 후 보낸 쪽의 사용을 허용
+// 비밀 값은 32바이트의 16진수여야 하며, 다른 체인과 같은 해시가 되도록 디코딩한 바이트를 해시한다
+func (h *HTLC) Script() string {
+	return "OP_IF OP_SIZE " + strconv.Itoa(2*htlcPreimageLen) + " OP_EQUALVERIFY OP_SHA256HEX " + h.Hash + " OP_EQUALVERIFY " + h.Recipient + " OP_CHECKSIG " +
+		"OP_ELSE " + strconv.Itoa(h.Timeout) + " OP_CHECKLOCKTIMEVERIFY OP_DROP " + h.Sender + " OP_CHECKSIG OP_ENDIF"
+}
+
+func (h *HTLC) validate() error {
+	if h.Recipient == "" || h.Sender == "" || h.Timeout <= 0 {
+		return ErrInvalidHTLC
+	}
+	if raw, err := hex.DecodeString(h.Hash); err != nil || len(raw) != sha256.Size || hex.EncodeToString(raw) != h.Hash { // 소문자 16진수 SHA-256 해시
+		return ErrInvalidHTLC
+	}
+	if parsed, err := parseHTLC(h.Script()); err != nil || *parsed != *h { // 공백이 섞인 값 등으로 스크립트 형태가 달라지는 경우
+		return ErrInvalidHTLC
+	}
+	return validateScript(h.Script())
+}
+
+// 잠금 스크립트가 HTLC 형태라면 계약 내용을 복원
+func parseHTLC(script string) (*HTLC, error) {
+	t := scriptTokens(script)
+	if len(t) != 16 || t[0] != "OP_IF" || t[1] != "OP_SIZE" || t[2] != strconv.Itoa(2*htlcPreimageLen) || t[3] != "OP_EQUALVERIFY" ||
+		t[4] != "OP_SHA256HEX" || t[6] != "OP_EQUALVERIFY" || t[8] != "OP_CHECKSIG" || t[9] != "OP_ELSE" ||
+		t[11] != "OP_CHECKLOCKTIMEVERIFY" || t[12] != "OP_DROP" || t[14] != "OP_CHECKSIG" || t[15] != "OP_ENDIF" {
+		return nil, ErrNotHTLC
+	}
+	timeout, err := strconv.Atoi(t[10])
+	if err != nil {
+		return nil, ErrNotHTLC
+	}
+	return &HTLC{Recipient: t[7], Sender: t[13], Hash: t[5], Timeout: timeout}, nil
+}
+
+// 노드의 지갑에서 HTLC 출력으로 보내는 트랜잭션을 mempool에 추가 (보낸 쪽은 노드의 지갑). 만든 출력은 트랜잭션의 마지막 출력
+func (m *mempool) AddHTLC(recipient, hash string, timeout int, amount, fee Amount, port string) (*Tx, *HTLC, error) {
+	h := &HTLC{Recipient: recipient, Sender: wallet.Wallet(port).Address, Hash: hash, Timeout: timeout}
+	if err := h.validate(); err != nil {
+		return nil, nil, reject(RejectInvalid, err)
+	}
+	script := h.Script()
+	tx, err := m.AddTxOut(&TxOut{Address: ScriptAddress(script), Amount: amount, Script: script}, fee, "htlc", 0, port)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, h, nil
+}
+
+// 비밀 값을 공개하며 HTLC 출력을 노드의 지갑으로 가져가는 트랜잭션을 mempool에 추가
+func (m *mempool) ClaimHTLC(txID string, index int, preimage string, fee Amount, port string) (*Tx, error) {
+	return m.spendHTLC(txID, index, fee, port, func(h *HTLC, w string) ([]string, error) {
+		if w != h.Recipient {
+			return nil, ErrNotHTLCParty
+		}
+		hash, err := hashPreimage(preimage)
+		if err != nil {
+			return nil, err
+		}
+		if hash != h.Hash {
+			return nil, ErrWrongPreimage
+		}
+		return []string{preimage, scriptTrue}, nil
+	})
+}
+
+// 기한이 지난 HTLC 출력을 노드의 지갑으로 돌려받는 트랜잭션을 mempool에 추가
+func (m *mempool) RefundHTLC(txID string, index int, fee Amount, port string) (*Tx, error) {
+	return m.spendHTLC(txID, index, fee, port, func(h *HTLC, w string) ([]string, error) {
+		if w != h.Sender {
+			return nil, ErrNotHTLCParty
+		}
+		return []string{scriptFalse}, nil
+	})
+}
+
+// HTLC 출력 하나를 사용하는 트랜잭션 구성. branch는 서명 뒤에 쌓을 증인 값을 정한다
+func (m *mempool) spendHTLC(txID string, index int, fee Amount, port string, branch func(h *HTLC, w string) ([]string, error)) (*Tx, error) {
+	if fee < MinTxFee {
+		return nil, reject(RejectFeeTooLow, fmt.Errorf("%w: %d < %d", ErrFeeTooLow, fee, MinTxFee))
+	}
+	e := findUTXO(txID, index)
+	if e == nil {
+		return nil, reject(RejectInvalid, fmt.Errorf("%w: %s", ErrHTLCNotFound, outpoint(txID, index)))
+	}
+	h, err := parseHTLC(e.Output.Script)
+	if err != nil {
+		return nil, reject(RejectInvalid, err)
+	}
+	if e.Output.Asset != NativeAsset { // 수수료를 출력에서 차감하므로 기본 코인만 지원
+		return nil, reject(RejectInvalid, fmt.Errorf("%w: asset %s", ErrNotHTLC, e.Output.Asset))
+	}
+	w := wallet.Wallet(port)
+	rest, err := branch(h, w.Address)
+	if err != nil {
+		return nil, reject(RejectInvalid, err)
+	}
+	if e.Output.Amount <= fee {
+		return nil, reject(RejectInsufficientFunds, ErrorNoMoney)
+	}
+	tx := &Tx{
+		Timestamp: int(time.Now().Unix()),
+		TxIns:     []*TxIn{{TxID: txID, Index: index}},
+		TxOuts:    []*TxOut{{Address: w.Address, Amount: e.Output.Amount - fee}},
+		InputData: "htlc",
+		Kind:      KindTransfer,
+	}
+	tx.getId()
+	tx.TxIns[0].Witness = append([]string{wallet.Sign(tx.ID, w)}, rest...)
+	if err := m.add(tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// HTLC 출력의 상태 조회. 사용된 출력이라면 사용한 트랜잭션을 멤풀과 체인(최신 블록부터)에서 찾아 공개된 비밀 값을 알려준다
+func FindHTLC(txID string, index int) (*HTLCStatus, error) {
+	status := &HTLCStatus{TxID: txID, Index: index}
+	var output *TxOut
+	if e := findUTXO(txID, index); e != nil {
+		output, status.Height, status.State = e.Output, e.Height, HTLCOpen
+	} else if tx, ok := Mempool().Transactions()[txID]; ok {
+		if index < 0 || index >= len(tx.TxOuts) {
+			return nil, ErrHTLCNotFound
+		}
+		output, status.State = tx.TxOuts[index], HTLCPending
+	} else {
+		tx := FindTx(Blockchain(), txID)
+		if tx == nil || index < 0 || index >= len(tx.TxOuts) {
+			return nil, ErrHTLCNotFound
+		}
+		output = tx.TxOuts[index]
+	}
+	h, err := parseHTLC(output.Script)
+	if err != nil {
+		return nil, err
+	}
+	status.HTLC, status.Address, status.Amount = h, output.Address, output.Amount
+
+	spender, confirmed := findSpender(txID, index)
+	if spender == nil {
+		if status.State == "" {
+			return nil, fmt.Errorf("%w: spender of %s", ErrHTLCNotFound, outpoint(txID, index))
+		}
+		return status, nil
+	}
+	status.SpentBy = spender.ID
+	for _, txIn := range spender.TxIns {
+		if txIn.TxID != txID || txIn.Index != index {
+			continue
+		}
+		if len(txIn.Witness) != 3 {
+			continue
+		}
+		if hash, err := hashPreimage(txIn.Witness[1]); err == nil && hash == h.Hash {
+			status.Preimage = txIn.Witness[1]
+		}
+	}
+	if confirmed {
+		status.State = HTLCRefunded
+		if status.Preimage != "" {
+			status.State = HTLCClaimed
+		}
+	}
+	return status, nil
+}
+
+// 출력을 사용한 트랜잭션 찾기 (멤풀 먼저, 그 다음 최신 블록부터). 블록에 담긴 트랜잭션이면 confirmed는 true
+func findSpender(txID string, index int) (*Tx, bool) {
+	pool := Mempool()
+	pool.m.Lock()
+	id, ok := pool.spends[outpoint(txID, index)]
+	var pending *Tx
+	if ok {
+		pending = pool.txs[id].tx
+	}
+	pool.m.Unlock()
+	if pending != nil {
+		return pending, false
+	}
+	chain := Blockchain()
+	chain.m.Lock()
+	hash := chain.NewestHash
+	chain.m.Unlock()
+	for hash != "" {
+		block, err := FindBlock(hash)
+		if err != nil {
+			break
+		}
+		hash = block.PrevHash
+		for _, tx := range block.Transaction {
+			if tx.isCoinbase() {
+				continue
+			}
+			for _, txIn := range tx.TxIns {
+				if txIn.TxID == txID && txIn.Index == index {
+					return tx, true
+				}
+			}
+		}
+		if containsTx(block, txID) { // 출력을 만든 블록보다 이전 블록에서는 사용될 수 없음
+			break
+		}
+	}
+	return nil, false
+}
+
+func containsTx(block *Block, txID string) bool {
+	for _, tx := range block.Transaction {
+		if tx.ID == txID {
+			return true
+		}
+	}
+	return false
+}
+
+// HTLC 비밀 값(32바이트의 16진수)을 디코딩한 바이트의 SHA-256 해시
+func hashPreimage(preimage string) (string, error) {
+	raw, err := hex.DecodeString(preimage)
+	if err != nil || len(raw) != htlcPreimageLen {
+		return "", fmt.Errorf("%w: %q", ErrBadPreimage, preimage)
+	}
+	return utils.HashBytes(raw), nil
+}
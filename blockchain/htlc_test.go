@@ -0,0 +1,159 @@
+package blockchain
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/abcfe-op/abcfe-node/common/utils"
+)
+
+// 테스트용 비밀 값 (32바이트의 16진수)과, 디코딩한 바이트의 해시
+var (
+	testPreimage     = strings.Repeat("ab", htlcPreimageLen)
+	testPreimageHash = utils.HashBytes(bytes.Repeat([]byte{0xab}, htlcPreimageLen))
+)
+
+func TestHTLCValidate(t *testing.T) {
+	valid := func() *HTLC {
+		return &HTLC{Recipient: "aa", Sender: "bb", Hash: testPreimageHash, Timeout: 10}
+	}
+	tests := []struct {
+		name   string
+		mutate func(h *HTLC)
+		ok     bool
+	}{
+		{"valid", func(h *HTLC) {}, true},
+		{"no recipient", func(h *HTLC) { h.Recipient = "" }, false},
+		{"no sender", func(h *HTLC) { h.Sender = "" }, false},
+		{"no hash", func(h *HTLC) { h.Hash = "" }, false},
+		{"no timeout", func(h *HTLC) { h.Timeout = 0 }, false},
+		{"key with a space shifts the script", func(h *HTLC) { h.Recipient = "aa OP_DROP" }, false},
+		{"unknown opcode in a field", func(h *HTLC) { h.Recipient = "OP_NOP" }, false},
+		{"hash is not a sha-256 hash", func(h *HTLC) { h.Hash = "abcd" }, false},
+		{"uppercase hash", func(h *HTLC) { h.Hash = strings.ToUpper(h.Hash) }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := valid()
+			tt.mutate(h)
+			if err := h.validate(); (err == nil) != tt.ok {
+				t.Fatalf("err = %v, want ok = %v", err, tt.ok)
+			}
+			if !tt.ok {
+				return
+			}
+			parsed, err := parseHTLC(h.Script())
+			if err != nil || *parsed != *h {
+				t.Fatalf("parsed = %+v, %v", parsed, err)
+			}
+		})
+	}
+	if _, err := parseHTLC("aa OP_CHECKSIG"); !errors.Is(err, ErrNotHTLC) {
+		t.Fatalf("err = %v, want %v", err, ErrNotHTLC)
+	}
+}
+
+func htlcState(t *testing.T, txID string, index int) *HTLCStatus {
+	t.Helper()
+	status, err := FindHTLC(txID, index)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return status
+}
+
+func TestHTLCClaimRevealsPreimage(t *testing.T) {
+	bc := newTestChain(t)
+	fund(t, bc)
+	// 다른 체인의 상대방(보낸 쪽)이 노드 지갑을 받는 쪽으로 HTLC를 만듦
+	sender := testKeys[genesisValidators[1].Address]
+	h := &HTLC{Recipient: nodeKey.address, Sender: sender.address, Hash: testPreimageHash, Timeout: 1000}
+	script := h.Script()
+	inputs := utxosByAddress(sender.address)
+	tx := spendTx(sender, inputs, &TxOut{Address: ScriptAddress(script), Amount: inputs[0].Output.Amount - 1, Script: script})
+	if err := Mempool().SubmitTx(tx); err != nil {
+		t.Fatal(err)
+	}
+	if state := htlcState(t, tx.ID, 0).State; state != HTLCPending {
+		t.Fatalf("state = %s, want %s", state, HTLCPending)
+	}
+	mine(t, bc, fromMempool)
+	if state := htlcState(t, tx.ID, 0).State; state != HTLCOpen {
+		t.Fatalf("state = %s, want %s", state, HTLCOpen)
+	}
+
+	tests := []struct {
+		name string
+		try  func() error
+		want error
+	}{
+		{"wrong preimage", func() error {
+			_, err := Mempool().ClaimHTLC(tx.ID, 0, strings.Repeat("cd", htlcPreimageLen), 1, nodeKey.port)
+			return err
+		}, ErrWrongPreimage},
+		{"preimage is not hex", func() error { _, err := Mempool().ClaimHTLC(tx.ID, 0, "secret", 1, nodeKey.port); return err }, ErrBadPreimage},
+		{"preimage is not 32 bytes", func() error {
+			_, err := Mempool().ClaimHTLC(tx.ID, 0, testPreimage[2:], 1, nodeKey.port)
+			return err
+		}, ErrBadPreimage},
+		{"refund by the recipient", func() error { _, err := Mempool().RefundHTLC(tx.ID, 0, 1, nodeKey.port); return err }, ErrNotHTLCParty},
+		{"fee above the amount", func() error {
+			_, err := Mempool().ClaimHTLC(tx.ID, 0, testPreimage, inputs[0].Output.Amount, nodeKey.port)
+			return err
+		}, ErrorNoMoney},
+		{"not an htlc output", func() error {
+			e := freeUTXO(t)
+			_, err := Mempool().ClaimHTLC(e.TxID, e.Index, testPreimage, 1, nodeKey.port)
+			return err
+		}, ErrNotHTLC},
+		{"unknown output", func() error { _, err := Mempool().ClaimHTLC("ff", 0, testPreimage, 1, nodeKey.port); return err }, ErrHTLCNotFound},
+	}
+	for _, tt := range tests {
+		if err := tt.try(); !errors.Is(err, tt.want) {
+			t.Fatalf("%s: err = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+
+	claim, err := Mempool().ClaimHTLC(tx.ID, 0, testPreimage, 1, nodeKey.port)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mine(t, bc, fromMempool)
+	status := htlcState(t, tx.ID, 0)
+	if status.State != HTLCClaimed || status.Preimage != testPreimage || status.SpentBy != claim.ID {
+		t.Fatalf("status = %+v", status)
+	}
+}
+
+func TestHTLCRefundAfterTimeout(t *testing.T) {
+	bc := newTestChain(t)
+	fund(t, bc)
+	recipient := testKeys[genesisValidators[1].Address]
+	timeout := bc.Height + 3
+	tx, _, err := Mempool().AddHTLC(recipient.address, testPreimageHash, timeout, 5, 1, nodeKey.port)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mine(t, bc, fromMempool)
+	index := len(tx.TxOuts) - 1
+	if _, err := Mempool().ClaimHTLC(tx.ID, index, testPreimage, 1, nodeKey.port); !errors.Is(err, ErrNotHTLCParty) {
+		t.Fatalf("claim by the sender: err = %v, want %v", err, ErrNotHTLCParty)
+	}
+	if _, err := Mempool().RefundHTLC(tx.ID, index, 1, nodeKey.port); !errors.Is(err, ErrOutputLocked) {
+		t.Fatalf("refund before the timeout: err = %v, want %v", err, ErrOutputLocked)
+	}
+	for bc.Height+1 < timeout {
+		mine(t, bc, coinbaseOnly)
+	}
+	refund, err := Mempool().RefundHTLC(tx.ID, index, 1, nodeKey.port)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mine(t, bc, fromMempool)
+	status := htlcState(t, tx.ID, index)
+	if status.State != HTLCRefunded || status.Preimage != "" || status.SpentBy != refund.ID {
+		t.Fatalf("status = %+v", status)
+	}
+}
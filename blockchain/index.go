@@ -0,0 +1,302 @@
+package blockchain
+
+import (
+	"errors"
+
+	"github.com/abcfe-op/abcfe-node/common/utils"
+	"github.com/abcfe-op/abcfe-node/db"
+
+	log "github.com/abcfe-op/abcfe-node/common/logger"
+)
+
+// 색인 이름 (디비에 색인이 반영하고 있는 최신 블록 해시를 기록할 때 사용, 키 형식이 바뀐 색인은 이름을 바꿔 다시 만든다)
+const (
+	utxoTipName           = "utxo/v2"
+	undoTipName           = "undo"
+	heightTipName         = "height"
+	txIndexTipName        = "txIndex"
+	addressHistoryTipName = "addressHistory/v2"
+	assetTipName          = "asset"
+)
+
+const (
+	defaultHistoryLimit = 20  // 주소별 기록 조회 시 기본 개수
+	maxHistoryLimit     = 100 // 주소별 기록 조회 시 최대 개수
+)
+
+var (
+	ErrTxIndexDisabled      = errors.New("transaction index is disabled")
+	ErrAddressIndexDisabled = errors.New("address index is disabled")
+	ErrTxNotFound           = errors.New("transaction not found")
+)
+
+var (
+	txIndexEnabled      bool // 트랜잭션 해시 -> 위치 색인 사용 여부
+	addressIndexEnabled bool // 주소 -> 잔액 변화 기록 색인 사용 여부
+)
+
+// 선택적인 색인 사용 여부 설정 (노드 시작 시, 블록체인을 불러오기 전에 호출)
+func EnableIndexes(txIndex, addressIndex bool) {
+	txIndexEnabled = txIndex
+	addressIndexEnabled = addressIndex
+}
+
+// 블록 내 트랜잭션의 위치
+type TxLocation struct {
+	BlockHash string `json:"blockHash"` // 트랜잭션이 포함된 블록의 해시 값
+	Height    int    `json:"height"`    // 트랜잭션이 포함된 블록의 높이
+	Position  int    `json:"position"`  // 블록 내 트랜잭션의 순서
+}
+
+// 주소의 잔액 변화 기록
+type HistoryEntry struct {
+	Height    int    `json:"height"`    // 트랜잭션이 포함된 블록의 높이
+	TxID      string `json:"txId"`      // 트랜잭션의 해시 값
+	Delta     int64  `json:"delta"`     // 트랜잭션으로 인한 잔액 변화량 (받은 금액 - 보낸 금액, int64 범위를 넘으면 범위의 끝 값)
+	Timestamp int    `json:"timestamp"` // 트랜잭션이 포함된 블록의 타임스탬프
+	Kind      TxKind `json:"kind"`      // 트랜잭션 종류
+	Received  Amount `json:"received"`  // 트랜잭션에서 받은 금액
+	Sent      Amount `json:"sent"`      // 트랜잭션에서 보낸 금액
+}
+
+// 주소별 기록 조회 결과 (최신 순)
+type HistoryPage struct {
+	Address    string          `json:"address"`
+	History    []*HistoryEntry `json:"history"`
+	NextCursor string          `json:"nextCursor,omitempty"` // 다음 페이지 조회 시 사용하는 값
+}
+
+// 블록 데이터와, 블록을 반영한 UTXO 셋, undo 기록 및 색인 변경 작업을 배치에 추가
+// spent에는 트랜잭션마다 입력으로 사용된 출력 목록이 담겨 있음
+func stageBlock(batch *db.Batch, block *Block, spent [][]*utxoEntry) error {
+	blockBytes, err := utils.ToBytes(block)
+	if err != nil {
+		return err
+	}
+	batch.SaveBlock(block.Hash, blockBytes)
+	stageUTXOs(batch, block)
+	batch.SaveIndexTip(utxoTipName, block.Hash)
+	if err := stageUndo(batch, block.Hash, spent); err != nil {
+		return err
+	}
+	batch.SaveIndexTip(undoTipName, block.Hash)
+	batch.SaveHeight(block.Height, block.Hash)
+	batch.SaveIndexTip(heightTipName, block.Hash)
+	if err := stageIssuances(batch, block); err != nil {
+		return err
+	}
+	batch.SaveIndexTip(assetTipName, block.Hash)
+	if err := stageValidators(batch, block, spent); err != nil {
+		return err
+	}
+	batch.SaveIndexTip(validatorTipName, block.Hash)
+	if txIndexEnabled {
+		if err := stageTxIndex(batch, block); err != nil {
+			return err
+		}
+		batch.SaveIndexTip(txIndexTipName, block.Hash)
+	}
+	if addressIndexEnabled {
+		if err := stageAddressHistory(batch, block, spent); err != nil {
+			return err
+		}
+		batch.SaveIndexTip(addressHistoryTipName, block.Hash)
+	}
+	return nil
+}
+
+// 트랜잭션 위치 색인 추가
+func stageTxIndex(batch *db.Batch, block *Block) error {
+	for i, tx := range block.Transaction {
+		data, err := utils.ToBytes(&TxLocation{block.Hash, block.Height, i})
+		if err != nil {
+			return err
+		}
+		batch.IndexTx(tx.ID, data)
+	}
+	return nil
+}
+
+// 트랜잭션마다 관련된 주소의 기본 코인 잔액 변화량을 계산하여 주소별 기록 추가 (다른 자산만 주고받은 주소도 변화량 0으로 기록)
+// 색인은 이미 검증된 블록을 반영하므로 블록을 거부하지 않는다. 합계가 범위를 넘으면 범위의 끝 값으로 기록
+func stageAddressHistory(batch *db.Batch, block *Block, spent [][]*utxoEntry) error {
+	for i, tx := range block.Transaction {
+		received, sent := make(amounts), make(amounts)
+		var addresses []string // 기록 순서를 고정하기 위해 등장 순서대로 보관
+		add := func(sums amounts, address string, amount Amount) {
+			_, seenReceived := received[address]
+			_, seenSent := sent[address]
+			if !seenReceived && !seenSent {
+				addresses = append(addresses, address)
+			}
+			sums[address] = sums[address].saturatingAdd(amount)
+		}
+		if i < len(spent) {
+			for _, e := range spent[i] {
+				add(sent, e.Output.Address, nativeAmount(e.Output))
+			}
+		}
+		for _, txOut := range tx.TxOuts {
+			add(received, txOut.Address, nativeAmount(txOut))
+		}
+		for _, address := range addresses {
+			entry := &HistoryEntry{
+				Height:    block.Height,
+				TxID:      tx.ID,
+				Delta:     clampedDelta(received[address], sent[address]),
+				Timestamp: block.Timestamp,
+				Kind:      tx.Kind,
+				Received:  received[address],
+				Sent:      sent[address],
+			}
+			data, err := utils.ToBytes(entry)
+			if err != nil {
+				return err
+			}
+			batch.AddHistory(address, block.Height, tx.ID, data)
+		}
+	}
+	return nil
+}
+
+// 체인과 어긋난 UTXO 셋 또는 색인이 있다면, 제네시스 블록부터 다시 반영하여 재구성 (호출하는 쪽에서 b.m을 잠가야 함)
+func (b *blockchain) reindex() error {
+	utxo := dbStorage.LoadIndexTip(utxoTipName) != b.NewestHash
+	undo := dbStorage.LoadIndexTip(undoTipName) != b.NewestHash
+	height := dbStorage.LoadIndexTip(heightTipName) != b.NewestHash
+	asset := dbStorage.LoadIndexTip(assetTipName) != b.NewestHash
+	validator := dbStorage.LoadIndexTip(validatorTipName) != b.NewestHash
+	txIndex := txIndexEnabled && dbStorage.LoadIndexTip(txIndexTipName) != b.NewestHash
+	addressIndex := addressIndexEnabled && dbStorage.LoadIndexTip(addressHistoryTipName) != b.NewestHash
+	if !utxo && !undo && !height && !asset && !validator && !txIndex && !addressIndex {
+		return nil
+	}
+	log.Info("Rebuilding the UTXO set and indexes")
+
+	batch := db.NewBatch()
+	if utxo {
+		batch.ClearUTXOs()
+	}
+	if undo {
+		batch.ClearUndo()
+	}
+	if height {
+		batch.ClearHeights()
+	}
+	if asset {
+		batch.ClearIssuances()
+	}
+	if validator {
+		batch.ClearValidators()
+	}
+	if txIndex {
+		batch.ClearTxIndex()
+	}
+	if addressIndex {
+		batch.ClearAddressHistory()
+	}
+	s := newChainState()
+	blocks := b.blocks()
+	for i := len(blocks) - 1; i >= 0; i-- {
+		block := blocks[i]
+		spent := s.connect(block)
+		if utxo {
+			stageUTXOs(batch, block)
+		}
+		if undo {
+			if err := stageUndo(batch, block.Hash, spent); err != nil {
+				return err
+			}
+		}
+		if height {
+			batch.SaveHeight(block.Height, block.Hash)
+		}
+		if asset {
+			if err := stageIssuances(batch, block); err != nil {
+				return err
+			}
+		}
+		if validator {
+			if err := stageValidators(batch, block, spent); err != nil {
+				return err
+			}
+		}
+		if txIndex {
+			if err := stageTxIndex(batch, block); err != nil {
+				return err
+			}
+		}
+		if addressIndex {
+			if err := stageAddressHistory(batch, block, spent); err != nil {
+				return err
+			}
+		}
+	}
+	batch.SaveIndexTip(utxoTipName, b.NewestHash)
+	batch.SaveIndexTip(undoTipName, b.NewestHash)
+	batch.SaveIndexTip(heightTipName, b.NewestHash)
+	batch.SaveIndexTip(assetTipName, b.NewestHash)
+	batch.SaveIndexTip(validatorTipName, b.NewestHash)
+	if txIndex {
+		batch.SaveIndexTip(txIndexTipName, b.NewestHash)
+	}
+	if addressIndex {
+		batch.SaveIndexTip(addressHistoryTipName, b.NewestHash)
+	}
+	return dbStorage.Write(batch)
+}
+
+// 트랜잭션 위치 색인으로 트랜잭션의 위치 조회
+func FindTxLocation(txID string) (*TxLocation, error) {
+	if !txIndexEnabled {
+		return nil, ErrTxIndexDisabled
+	}
+	data := dbStorage.FindTxIndex(txID)
+	if data == nil {
+		return nil, ErrTxNotFound
+	}
+	location := &TxLocation{}
+	if err := utils.FromBytes(location, data); err != nil {
+		return nil, err
+	}
+	return location, nil
+}
+
+// 트랜잭션 위치 색인으로 트랜잭션과 위치 조회
+func FindTransaction(txID string) (*Tx, *TxLocation, error) {
+	location, err := FindTxLocation(txID)
+	if err != nil {
+		return nil, nil, err
+	}
+	block, err := FindBlock(location.BlockHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	if location.Position >= len(block.Transaction) || block.Transaction[location.Position].ID != txID {
+		return nil, nil, ErrTxNotFound
+	}
+	return block.Transaction[location.Position], location, nil
+}
+
+// 주소의 잔액 변화 기록을 최신 순으로 조회 (limit가 0 이하이면 기본 개수)
+func AddressHistory(address, cursor string, limit int) (*HistoryPage, error) {
+	if !addressIndexEnabled {
+		return nil, ErrAddressIndexDisabled
+	}
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+	if limit > maxHistoryLimit {
+		limit = maxHistoryLimit
+	}
+	list, next := dbStorage.AddressHistory(address, cursor, limit)
+	page := &HistoryPage{Address: address, History: []*HistoryEntry{}, NextCursor: next}
+	for _, data := range list {
+		entry := &HistoryEntry{}
+		if err := utils.FromBytes(entry, data); err != nil {
+			return nil, err
+		}
+		page.History = append(page.History, entry)
+	}
+	return page, nil
+}
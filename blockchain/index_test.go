@@ -0,0 +1,129 @@
+package blockchain
+
+import (
+	"errors"
+	"testing"
+)
+
+// 테스트 동안 선택적인 색인을 켬
+func enableIndexes(t *testing.T) {
+	t.Helper()
+	EnableIndexes(true, true)
+	t.Cleanup(func() { EnableIndexes(false, false) })
+}
+
+// 최신 순 기록을 모든 페이지에 걸쳐 조회
+func fullHistory(t *testing.T, address string, limit int) []*HistoryEntry {
+	t.Helper()
+	var history []*HistoryEntry
+	cursor := ""
+	for {
+		page, err := AddressHistory(address, cursor, limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		history = append(history, page.History...)
+		if page.NextCursor == "" {
+			return history
+		}
+		cursor = page.NextCursor
+	}
+}
+
+// 블록 3개: 코인베이스, 노드 지갑에서 "ab"로 전송, 다시 "ab"로 전송
+func mineHistory(t *testing.T, bc *blockchain) []*Block {
+	t.Helper()
+	blocks := []*Block{mine(t, bc, coinbaseOnly)}
+	for _, amount := range []Amount{3, 4} {
+		if _, err := Mempool().AddTx("ab", amount, MinTxFee, "", nodeKey.port); err != nil {
+			t.Fatal(err)
+		}
+		blocks = append(blocks, mine(t, bc, fromMempool))
+	}
+	return blocks
+}
+
+func assertIndexesMatchBlocks(t *testing.T, bc *blockchain, blocks []*Block) {
+	t.Helper()
+	for _, block := range blocks {
+		for i, want := range block.Transaction {
+			tx, location, err := FindTransaction(want.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tx.ID != want.ID || *location != (TxLocation{block.Hash, block.Height, i}) {
+				t.Fatalf("location of %s = %+v", want.ID, location)
+			}
+		}
+	}
+	for _, address := range []string{"ab", nodeKey.address} {
+		var sum int64
+		height := bc.Height + 1
+		for _, entry := range fullHistory(t, address, 1) {
+			if entry.Height > height {
+				t.Fatalf("history of %s is not newest first", address)
+			}
+			height = entry.Height
+			sum += entry.Delta
+			if entry.Delta != clampedDelta(entry.Received, entry.Sent) {
+				t.Fatalf("entry %s of %s: delta %d, received %d, sent %d", entry.TxID, address, entry.Delta, entry.Received, entry.Sent)
+			}
+		}
+		if Amount(sum) != balance(t, bc, address) {
+			t.Fatalf("history of %s sums to %d, balance is %d", address, sum, balance(t, bc, address))
+		}
+	}
+}
+
+func TestIndexesFollowConnectedBlocks(t *testing.T) {
+	enableIndexes(t)
+	bc := newTestChain(t)
+	blocks := mineHistory(t, bc)
+	assertIndexesMatchBlocks(t, bc, blocks)
+
+	history := fullHistory(t, "ab", 10)
+	if len(history) != 2 || history[0].Delta != 4 || history[0].Received != 4 || history[1].Delta != 3 || history[0].Kind != KindTransfer {
+		t.Fatalf("history of ab = %+v", history)
+	}
+	if _, _, err := FindTransaction("ff"); !errors.Is(err, ErrTxNotFound) {
+		t.Fatalf("err = %v, want %v", err, ErrTxNotFound)
+	}
+}
+
+func TestReindexBuildsIndexesEnabledLater(t *testing.T) {
+	bc := newTestChain(t)
+	blocks := mineHistory(t, bc)
+	enableIndexes(t)
+	bc.m.Lock()
+	err := bc.reindex()
+	bc.m.Unlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertIndexesMatchBlocks(t, bc, blocks)
+}
+
+func TestIndexLookupsWhenDisabled(t *testing.T) {
+	newTestChain(t)
+	if _, err := FindTxLocation("ff"); !errors.Is(err, ErrTxIndexDisabled) {
+		t.Fatalf("err = %v, want %v", err, ErrTxIndexDisabled)
+	}
+	if _, err := AddressHistory("ab", "", 0); !errors.Is(err, ErrAddressIndexDisabled) {
+		t.Fatalf("err = %v, want %v", err, ErrAddressIndexDisabled)
+	}
+}
+
+func TestAddressHistoryClampsLimit(t *testing.T) {
+	enableIndexes(t)
+	bc := newTestChain(t)
+	mineHistory(t, bc)
+	for _, limit := range []int{0, -1, maxHistoryLimit + 1} {
+		page, err := AddressHistory("ab", "", limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page.History) != 2 || page.NextCursor != "" {
+			t.Fatalf("limit %d: %d entries, next %q", limit, len(page.History), page.NextCursor)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/abcfe-op/abcfe-node/common/utils"
+	"github.com/abcfe-op/abcfe-node/wallet"
+)
+
+// 트랜잭션 종류. 종류마다 정해진 페이로드를 가지며 합의 규칙이 종류별 조건을 검증한다 (InputData는 해석하지 않는 메모)
+type TxKind string
+
+const (
+	KindTransfer TxKind = "transfer" // 일반 전송 (페이로드 없음)
+	KindStake    TxKind = "stake"    // 스테이킹 풀로 스테이킹 수량을 보냄
+	KindUnstake  TxKind = "unstake"  // 스테이킹 출력을 스테이커에게 돌려줌
+	KindCoinbase TxKind = "coinbase" // 블록 보상 (제네시스 트랜잭션 포함)
+	KindSlash    TxKind = "slash"    // 이중 서명한 검증자의 스테이킹 출력 몰수
+	KindIssue    TxKind = "issue"    // 자산 발행
+)
+
+const (
+	txPayloadVersion = 1                         // 현재 페이로드 형식의 버전
+	slashReward      = utils.StakingQuantity / 2 // 이중 서명을 신고한 지갑이 받는 몫 (나머지는 수수료)
+)
+
+var (
+	ErrUnknownTxKind   = errors.New("unknown transaction kind")
+	ErrInvalidPayload  = errors.New("payload does not match the transaction kind")
+	ErrStakeSpend      = errors.New("staking outputs can only be spent by unstake or slash transactions")
+	ErrStakeOutput     = errors.New("only stake transactions can pay the staking pool")
+	ErrInvalidStake    = errors.New("invalid stake transaction")
+	ErrInvalidUnstake  = errors.New("invalid unstake transaction")
+	ErrInvalidEvidence = errors.New("invalid double-sign evidence")
+	ErrStakeNotFound   = errors.New("staking output not found")
+)
+
+// 종류별 페이로드. 종류에 맞는 항목 하나만 채워진다
+type TxPayload struct {
+	Version  int              `json:"version"`
+	Stake    *StakePayload    `json:"stake,omitempty"`
+	Unstake  *UnstakePayload  `json:"unstake,omitempty"`
+	Coinbase *CoinbasePayload `json:"coinbase,omitempty"`
+	Slash    *SlashPayload    `json:"slash,omitempty"`
+	Issue    *Issuance        `json:"issue,omitempty"`
+}
+
+// 스테이킹: 스테이커(첫 번째 입력의 소유자)와 스테이커 노드 포트
+type StakePayload struct {
+	Staker string `json:"staker"`
+	Port   string `json:"port"`
+}
+
+// 언스테이킹: 돌려받을 스테이킹 출력
+type UnstakePayload struct {
+	StakeTxID string `json:"stakeTxId"`
+	Index     int    `json:"index"`
+}
+
+// 코인베이스: 보상을 지급하는 블록 높이
+type CoinbasePayload struct {
+	Height int `json:"height"`
+}
+
+// 이중 서명 증거: 같은 높이, 라운드, 종류에서 서로 다른 블록에 대한 검증자(Offender)의 서명된 투표 두 개
+type SlashPayload struct {
+	Offender string        `json:"offender"`
+	Votes    []*SignedVote `json:"votes"`
+}
+
+// 서명된 합의 투표 (서명 대상은 VotePayload)
+type SignedVote struct {
+	Type      string `json:"type"`      // 투표 종류 (PrevoteKind 또는 PrecommitKind)
+	Height    int    `json:"height"`    // 블록 높이
+	Round     int    `json:"round"`     // 합의 라운드
+	Hash      string `json:"hash"`      // 투표한 블록 해시 (빈 값이면 어느 블록에도 찬성하지 않는 nil 투표)
+	Signature string `json:"signature"` // 검증자의 서명
+}
+
+// 현재 버전의 페이로드 생성
+func newPayload() *TxPayload {
+	return &TxPayload{Version: txPayloadVersion}
+}
+
+// 종류와 페이로드의 형태 검증: 전송은 페이로드가 없고, 그 외 종류는 현재 버전의 페이로드에 종류에 맞는 항목 하나만 있어야 함
+func (t *Tx) checkPayload() error {
+	if t.Kind == KindTransfer {
+		if t.Payload != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidPayload, t.ID)
+		}
+		return nil
+	}
+	p := t.Payload
+	var ok bool
+	switch t.Kind {
+	case KindStake:
+		ok = p != nil && p.Stake != nil
+	case KindUnstake:
+		ok = p != nil && p.Unstake != nil
+	case KindCoinbase:
+		ok = p != nil && p.Coinbase != nil
+	case KindSlash:
+		ok = p != nil && p.Slash != nil
+	case KindIssue:
+		ok = p != nil && p.Issue != nil
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownTxKind, t.Kind)
+	}
+	if !ok || p.Version != txPayloadVersion {
+		return fmt.Errorf("%w: %s %s", ErrInvalidPayload, t.Kind, t.ID)
+	}
+	set := 0
+	for _, filled := range []bool{p.Stake != nil, p.Unstake != nil, p.Coinbase != nil, p.Slash != nil, p.Issue != nil} {
+		if filled {
+			set++
+		}
+	}
+	if set != 1 {
+		return fmt.Errorf("%w: %s %s", ErrInvalidPayload, t.Kind, t.ID)
+	}
+	return nil
+}
+
+// 종류별 합의 규칙 검증 (페이로드 형태는 확인된 상태, 코인베이스 제외). prevs는 입력이 가리키는 출력 (입력 순서)
+// 스테이킹 풀의 출력은 스테이킹 트랜잭션만 만들 수 있고, 언스테이킹과 몰수 트랜잭션만 사용할 수 있다
+// (스테이킹 풀 주소는 표시일 뿐이며, 출력을 사용하는 권한은 스테이커의 서명 또는 이중 서명 증거에 있음)
+func validateKind(tx *Tx, prevs []*utxoEntry) error {
+	for _, prev := range prevs {
+		if prev.Output.Address == utils.StakingAddress && tx.Kind != KindUnstake && tx.Kind != KindSlash {
+			return fmt.Errorf("%w: %s", ErrStakeSpend, tx.ID)
+		}
+	}
+	for _, txOut := range tx.TxOuts {
+		if txOut.Address == utils.StakingAddress && tx.Kind != KindStake {
+			return fmt.Errorf("%w: %s", ErrStakeOutput, tx.ID)
+		}
+	}
+	switch tx.Kind {
+	case KindStake:
+		return validateStake(tx, prevs)
+	case KindUnstake:
+		return validateUnstake(tx, prevs)
+	case KindSlash:
+		return validateSlash(tx, prevs)
+	case KindIssue:
+		return tx.Payload.Issue.verify(tx.ID)
+	}
+	return nil
+}
+
+// 스테이킹: 스테이커가 첫 번째 입력의 소유자이고, 스테이킹 풀로 스테이킹 수량을 한 번만 보내야 함
+// 언스테이킹과 블록 서명은 스테이커 키의 서명으로 검증하므로, 다중서명이나 스크립트 주소는 스테이커가 될 수 없다
+func validateStake(tx *Tx, prevs []*utxoEntry) error {
+	p := tx.Payload.Stake
+	if p.Port == "" || p.Staker != prevs[0].Output.Address {
+		return fmt.Errorf("%w: staker %s", ErrInvalidStake, p.Staker)
+	}
+	if prevs[0].Output.Script != "" || prevs[0].Output.Multisig != nil {
+		return fmt.Errorf("%w: staker %s is not a key address", ErrInvalidStake, p.Staker)
+	}
+	count := 0
+	for _, txOut := range tx.TxOuts {
+		if txOut.Address != utils.StakingAddress {
+			continue
+		}
+		if txOut.Amount != utils.StakingQuantity {
+			return fmt.Errorf("%w: amount %d", ErrInvalidStake, txOut.Amount)
+		}
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("%w: %d staking outputs", ErrInvalidStake, count)
+	}
+	return nil
+}
+
+// 언스테이킹: 페이로드가 가리키는 스테이킹 출력 하나만 사용하고, 모든 출력이 스테이커에게 가야 함
+func validateUnstake(tx *Tx, prevs []*utxoEntry) error {
+	p := tx.Payload.Unstake
+	if len(prevs) != 1 || tx.TxIns[0].TxID != p.StakeTxID || tx.TxIns[0].Index != p.Index {
+		return fmt.Errorf("%w: %s", ErrInvalidUnstake, outpoint(p.StakeTxID, p.Index))
+	}
+	stake := prevs[0].Stake
+	if !isStakeEntry(prevs[0]) {
+		return fmt.Errorf("%w: %s is not a staking output", ErrInvalidUnstake, outpoint(p.StakeTxID, p.Index))
+	}
+	for _, txOut := range tx.TxOuts {
+		if txOut.Address != stake.Staker {
+			return fmt.Errorf("%w: pays %s instead of the staker", ErrInvalidUnstake, txOut.Address)
+		}
+	}
+	return nil
+}
+
+// 몰수: 이중 서명 증거가 유효하고, 사용하는 출력이 서명한 검증자의 스테이킹 출력이며, 검증자에게 돌려주는 출력이 없어야 함
+func validateSlash(tx *Tx, prevs []*utxoEntry) error {
+	p := tx.Payload.Slash
+	if err := p.verify(); err != nil {
+		return err
+	}
+	if len(prevs) != 1 || !isStakeEntry(prevs[0]) || prevs[0].Stake.Staker != p.Offender {
+		return fmt.Errorf("%w: input is not a staking output of %s", ErrInvalidEvidence, p.Offender)
+	}
+	for _, txOut := range tx.TxOuts {
+		if txOut.Address == p.Offender {
+			return fmt.Errorf("%w: pays the offender", ErrInvalidEvidence)
+		}
+	}
+	return nil
+}
+
+// 이중 서명 증거 검증: 같은 높이, 라운드, 종류의 서로 다른 블록에 대한 두 투표에 모두 Offender의 유효한 서명이 있어야 함
+func (p *SlashPayload) verify() error {
+	if len(p.Votes) != 2 || p.Votes[0] == nil || p.Votes[1] == nil {
+		return fmt.Errorf("%w: two votes are required", ErrInvalidEvidence)
+	}
+	v1, v2 := p.Votes[0], p.Votes[1]
+	if v1.Type != PrevoteKind && v1.Type != PrecommitKind {
+		return fmt.Errorf("%w: unknown vote type %q", ErrInvalidEvidence, v1.Type)
+	}
+	if v1.Type != v2.Type || v1.Height != v2.Height || v1.Round != v2.Round || v1.Hash == v2.Hash {
+		return fmt.Errorf("%w: votes must differ in the same height, round and type", ErrInvalidEvidence)
+	}
+	for _, v := range p.Votes {
+		if !wallet.Verify(v.Signature, VotePayload(v.Type, v.Height, v.Round, v.Hash), p.Offender) {
+			return fmt.Errorf("%w: signature of %s", ErrInvalidEvidence, p.Offender)
+		}
+	}
+	return nil
+}
+
+// 이중 서명한 검증자의 스테이킹 출력을 몰수하는 트랜잭션을 mempool에 추가 (몰수한 수량 중 slashReward는 노드의 지갑이 받음)
+// 몰수는 입력 서명 대신 이중 서명 증거로 검증하므로 입력에 서명하지 않는다
+func (m *mempool) AddSlashTx(evidence *SlashPayload, port string) (*Tx, error) {
+	if err := evidence.verify(); err != nil {
+		return nil, reject(RejectInvalid, err)
+	}
+	stake, err := activeStakeOf(evidence.Offender)
+	if err != nil {
+		return nil, reject(RejectInvalid, err)
+	}
+	if stake == nil {
+		return nil, reject(RejectInvalid, fmt.Errorf("%w: %s", ErrStakeNotFound, evidence.Offender))
+	}
+	payload := newPayload()
+	payload.Slash = evidence
+	tx := &Tx{
+		Timestamp: int(time.Now().Unix()),
+		TxIns:     []*TxIn{{TxID: stake.TxID, Index: stake.Index}},
+		TxOuts:    []*TxOut{{Address: wallet.Wallet(port).Address, Amount: slashReward}},
+		InputData: "slashed for double signing",
+		Kind:      KindSlash,
+		Payload:   payload,
+	}
+	tx.getId()
+	if err := m.add(tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
@@ -0,0 +1,186 @@
+package blockchain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/abcfe-op/abcfe-node/common/utils"
+)
+
+func TestCheckPayload(t *testing.T) {
+	with := func(fill func(p *TxPayload)) *TxPayload {
+		p := newPayload()
+		fill(p)
+		return p
+	}
+	stake := with(func(p *TxPayload) { p.Stake = &StakePayload{Staker: "aa", Port: "4000"} })
+	tests := []struct {
+		name string
+		tx   *Tx
+		want error
+	}{
+		{"transfer without payload", &Tx{Kind: KindTransfer}, nil},
+		{"transfer with payload", &Tx{Kind: KindTransfer, Payload: stake}, ErrInvalidPayload},
+		{"stake", &Tx{Kind: KindStake, Payload: stake}, nil},
+		{"stake without payload", &Tx{Kind: KindStake}, ErrInvalidPayload},
+		{"payload of another kind", &Tx{Kind: KindUnstake, Payload: stake}, ErrInvalidPayload},
+		{"old payload version", &Tx{Kind: KindStake, Payload: &TxPayload{Version: txPayloadVersion - 1, Stake: stake.Stake}}, ErrInvalidPayload},
+		{"two payload items", &Tx{Kind: KindStake, Payload: with(func(p *TxPayload) {
+			p.Stake = stake.Stake
+			p.Coinbase = &CoinbasePayload{Height: 2}
+		})}, ErrInvalidPayload},
+		{"coinbase", &Tx{Kind: KindCoinbase, Payload: with(func(p *TxPayload) { p.Coinbase = &CoinbasePayload{Height: 2} })}, nil},
+		{"unknown kind", &Tx{Kind: "mint", Payload: stake}, ErrUnknownTxKind},
+		{"empty kind", &Tx{}, ErrUnknownTxKind},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.tx.checkPayload(); !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// offender가 같은 높이, 라운드에서 서로 다른 두 블록에 서명한 투표
+func doubleVote(offender *testKey) *SlashPayload {
+	vote := func(hash string) *SignedVote {
+		return &SignedVote{Type: PrecommitKind, Height: 5, Round: 1, Hash: hash, Signature: offender.sign(VotePayload(PrecommitKind, 5, 1, hash))}
+	}
+	return &SlashPayload{Offender: offender.address, Votes: []*SignedVote{vote("aa"), vote("bb")}}
+}
+
+func TestSlashPayloadVerify(t *testing.T) {
+	offender := testKeys[genesisValidators[1].Address]
+	other := testKeys[genesisValidators[2].Address]
+	resign := func(v *SignedVote, key *testKey) {
+		v.Signature = key.sign(VotePayload(v.Type, v.Height, v.Round, v.Hash))
+	}
+	tests := []struct {
+		name   string
+		mutate func(p *SlashPayload)
+		ok     bool
+	}{
+		{"double precommit", func(p *SlashPayload) {}, true},
+		{"double prevote", func(p *SlashPayload) {
+			for _, v := range p.Votes {
+				v.Type = PrevoteKind
+				resign(v, offender)
+			}
+		}, true},
+		{"nil vote against a block", func(p *SlashPayload) { p.Votes[1].Hash = ""; resign(p.Votes[1], offender) }, true},
+		{"one vote", func(p *SlashPayload) { p.Votes = p.Votes[:1] }, false},
+		{"missing vote", func(p *SlashPayload) { p.Votes[1] = nil }, false},
+		{"same block", func(p *SlashPayload) { p.Votes[1].Hash = "aa"; resign(p.Votes[1], offender) }, false},
+		{"different rounds", func(p *SlashPayload) { p.Votes[1].Round = 2; resign(p.Votes[1], offender) }, false},
+		{"different heights", func(p *SlashPayload) { p.Votes[1].Height = 6; resign(p.Votes[1], offender) }, false},
+		{"different types", func(p *SlashPayload) { p.Votes[1].Type = PrevoteKind; resign(p.Votes[1], offender) }, false},
+		{"unknown type", func(p *SlashPayload) {
+			for _, v := range p.Votes {
+				v.Type = "commit"
+				resign(v, offender)
+			}
+		}, false},
+		{"vote signed by another validator", func(p *SlashPayload) { resign(p.Votes[1], other) }, false},
+		{"signature over another vote", func(p *SlashPayload) { p.Votes[1].Round = 2; p.Votes[0].Round = 2 }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := doubleVote(offender)
+			tt.mutate(p)
+			err := p.verify()
+			if (err == nil) != tt.ok || (err != nil && !errors.Is(err, ErrInvalidEvidence)) {
+				t.Fatalf("err = %v, want ok = %v", err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestValidateStake(t *testing.T) {
+	staker := "aa"
+	key := &utxoEntry{Output: &TxOut{Address: staker, Amount: 200}}
+	stakeTx := func(port string, outs ...*TxOut) *Tx {
+		p := newPayload()
+		p.Stake = &StakePayload{Staker: staker, Port: port}
+		return &Tx{Kind: KindStake, Payload: p, TxOuts: outs}
+	}
+	pool := func(amount Amount) *TxOut {
+		return &TxOut{Address: utils.StakingAddress, Amount: amount, Lock: stakingLock()}
+	}
+	tests := []struct {
+		name string
+		tx   *Tx
+		prev *utxoEntry
+		ok   bool
+	}{
+		{"stake", stakeTx("4000", pool(utils.StakingQuantity)), key, true},
+		{"no port", stakeTx("", pool(utils.StakingQuantity)), key, false},
+		{"staker is not the spender", stakeTx("4000", pool(utils.StakingQuantity)), &utxoEntry{Output: &TxOut{Address: "bb"}}, false},
+		{"multisig staker", stakeTx("4000", pool(utils.StakingQuantity)), &utxoEntry{Output: &TxOut{Address: staker, Multisig: &MultisigLock{M: 1, PubKeys: []string{"cc"}}}}, false},
+		{"script staker", stakeTx("4000", pool(utils.StakingQuantity)), &utxoEntry{Output: &TxOut{Address: staker, Script: "OP_1"}}, false},
+		{"wrong quantity", stakeTx("4000", pool(utils.StakingQuantity-1)), key, false},
+		{"two staking outputs", stakeTx("4000", pool(utils.StakingQuantity), pool(utils.StakingQuantity)), key, false},
+		{"no staking output", stakeTx("4000", &TxOut{Address: staker, Amount: 1}), key, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateStake(tt.tx, []*utxoEntry{tt.prev})
+			if (err == nil) != tt.ok || (err != nil && !errors.Is(err, ErrInvalidStake)) {
+				t.Fatalf("err = %v, want ok = %v", err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestStakingPoolIsGuardedByKind(t *testing.T) {
+	stakeEntry := &utxoEntry{Output: &TxOut{Address: utils.StakingAddress, Amount: utils.StakingQuantity}, Kind: KindStake, Stake: &StakePayload{Staker: "aa"}}
+	plain := &utxoEntry{Output: &TxOut{Address: "aa", Amount: 10}}
+	tests := []struct {
+		name string
+		tx   *Tx
+		prev *utxoEntry
+		want error
+	}{
+		{"transfer spending a stake", &Tx{Kind: KindTransfer, TxOuts: []*TxOut{{Address: "aa", Amount: 1}}}, stakeEntry, ErrStakeSpend},
+		{"transfer paying the pool", &Tx{Kind: KindTransfer, TxOuts: []*TxOut{{Address: utils.StakingAddress, Amount: utils.StakingQuantity}}}, plain, ErrStakeOutput},
+		{"transfer between keys", &Tx{Kind: KindTransfer, TxOuts: []*TxOut{{Address: "bb", Amount: 1}}}, plain, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateKind(tt.tx, []*utxoEntry{tt.prev}); !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestStakeAndSlashOnChain(t *testing.T) {
+	bc := newTestChain(t)
+	fund(t, bc)
+	offender := testKeys[genesisValidators[1].Address]
+	stakeFrom(t, bc, offender)
+	if v, err := activeStakeOf(offender.address); err != nil || v == nil {
+		t.Fatalf("stake is not active: %v", err)
+	}
+
+	if _, err := Mempool().AddSlashTx(doubleVote(nodeKey), nodeKey.port); !errors.Is(err, ErrStakeNotFound) {
+		t.Fatalf("slashing a validator without a stake: err = %v, want %v", err, ErrStakeNotFound)
+	}
+	if _, err := Mempool().AddSlashTx(doubleVote(offender), nodeKey.port); err != nil {
+		t.Fatal(err)
+	}
+	before := balance(t, bc, nodeKey.address)
+	block := mine(t, bc, fromMempool)
+	if v, err := activeStakeOf(offender.address); err != nil || v != nil {
+		t.Fatalf("slashed stake is still active: %v", err)
+	}
+	var earned Amount
+	for _, txOut := range block.Transaction[len(block.Transaction)-1].TxOuts {
+		if txOut.Address == nodeKey.address {
+			earned += txOut.Amount
+		}
+	}
+	if got, want := balance(t, bc, nodeKey.address), before+slashReward+earned; got != want {
+		t.Fatalf("reporter balance = %d, want %d", got, want)
+	}
+}
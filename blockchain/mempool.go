@@ -0,0 +1,498 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/abcfe-op/abcfe-node/common/utils"
+	"github.com/abcfe-op/abcfe-node/wallet"
+
+	log "github.com/abcfe-op/abcfe-node/common/logger"
+)
+
+// 멤풀 정책 (0 이하의 값은 제한 없음)
+type MempoolPolicy struct {
+	MaxCount     int // 보관할 수 있는 최대 트랜잭션 수
+	MaxBytes     int // 보관할 수 있는 트랜잭션 크기의 최대 합
+	MaxPerSender int // 한 주소가 보관할 수 있는 최대 트랜잭션 수
+	ExpirySec    int // 블록에 담기지 못한 트랜잭션을 보관하는 최대 시간
+}
+
+var mempoolPolicy = MempoolPolicy{
+	MaxCount:     5000,
+	MaxBytes:     8 << 20,
+	MaxPerSender: 25,
+	ExpirySec:    3 * DayToSec,
+}
+
+// 멤풀 정책 설정 (노드 시작 시 호출). 0인 값은 기본값을 유지
+func SetMempoolPolicy(policy MempoolPolicy) {
+	if policy.MaxCount != 0 {
+		mempoolPolicy.MaxCount = policy.MaxCount
+	}
+	if policy.MaxBytes != 0 {
+		mempoolPolicy.MaxBytes = policy.MaxBytes
+	}
+	if policy.MaxPerSender != 0 {
+		mempoolPolicy.MaxPerSender = policy.MaxPerSender
+	}
+	if policy.ExpirySec != 0 {
+		mempoolPolicy.ExpirySec = policy.ExpirySec
+	}
+}
+
+// 멤풀이 트랜잭션을 거절한 이유
+type RejectCode string
+
+const (
+	RejectInvalid           RejectCode = "invalid"            // 검증에 실패한 트랜잭션
+	RejectInsufficientFunds RejectCode = "insufficient-funds" // 잔액 부족
+	RejectFeeTooLow         RejectCode = "fee-too-low"        // 최소 수수료 미달
+	RejectDuplicate         RejectCode = "duplicate"          // 이미 멤풀에 있는 트랜잭션
+	RejectConflict          RejectCode = "conflict"           // 멤풀의 다른 트랜잭션과 같은 UTXO를 사용
+	RejectSenderLimit       RejectCode = "sender-limit"       // 주소별 트랜잭션 수 초과
+	RejectMempoolFull       RejectCode = "mempool-full"       // 멤풀이 가득 찼고, 수수료율이 기존 트랜잭션보다 높지 않음
+	RejectLocked            RejectCode = "locked"             // 시간 잠금이 아직 풀리지 않은 출력 또는 LockTime
+	RejectBusy              RejectCode = "busy"               // 멤풀이 붐벼서 UTXO 통합을 미룸
+)
+
+var (
+	ErrTxInMempool   = errors.New("transaction is already in the mempool")
+	ErrConflictingTx = errors.New("transaction spends an output already spent in the mempool")
+	ErrSenderLimit   = errors.New("too many transactions from the sender")
+	ErrMempoolFull   = errors.New("mempool is full")
+	ErrTxTooLarge    = errors.New("transaction is larger than the mempool")
+)
+
+// 멤풀의 거절 에러 (거절 사유 코드 포함)
+type RejectError struct {
+	Code RejectCode
+	Err  error
+}
+
+func (e *RejectError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Code, e.Err)
+}
+
+func (e *RejectError) Unwrap() error {
+	return e.Err
+}
+
+func reject(code RejectCode, err error) *RejectError {
+	return &RejectError{code, err}
+}
+
+// 에러에 담긴 거절 사유 코드 (멤풀의 거절 에러가 아니라면 빈 문자열)
+func RejectReason(err error) RejectCode {
+	var rejectErr *RejectError
+	if errors.As(err, &rejectErr) {
+		return rejectErr.Code
+	}
+	return ""
+}
+
+// 체인 상태와 상관없이 트랜잭션만으로 증명할 수 있는 잘못: 잘못된 서명, 형식이 잘못된 내용, 정규 인코딩과 맞지 않는 ID
+var relayFaults = []error{
+	ErrEmptyTx, ErrMalformedTx, ErrInvalidTxID, ErrInvalidTxSignature, ErrInvalidIssuerSignature,
+	ErrInvalidOutputAmount, ErrInvalidAddress, ErrDoubleSpend, ErrOutputsExceedInputs,
+	ErrUnknownTxKind, ErrInvalidPayload, ErrInvalidTimeLock, ErrInvalidScript, ErrScriptAddress,
+	ErrInvalidMultisig, ErrMultisigAddress, ErrNotEnoughMultisigSigs, ErrTooManyMultisigSigs,
+}
+
+// 트랜잭션을 전달한 피어의 잘못으로 볼 수 있는 거절인가
+// (relayFaults만 해당하며, 노드마다 체인 상태가 달라 생길 수 있는 거절과 정책에 따른 거절은 제외)
+func IsInvalidRelay(err error) bool {
+	if RejectReason(err) != RejectInvalid {
+		return false
+	}
+	for _, fault := range relayFaults {
+		if errors.Is(err, fault) {
+			return true
+		}
+	}
+	return false
+}
+
+// 멤풀에 보관 중인 트랜잭션 정보
+type mempoolEntry struct {
+	tx     *Tx
+	fee    Amount // 입력 합 - 출력 합
+	size   int    // 트랜잭션 크기
+	sender string // 트랜잭션을 보낸 주소 (txSender 참고)
+	added  int    // 멤풀에 들어온 시각
+}
+
+// 주소별 트랜잭션 수를 셀 때의 보낸 주소. 보통은 첫 번째 입력이 가리키는 출력의 주소이지만,
+// 스테이킹 풀의 출력을 사용하는 언스테이킹은 스테이커, 몰수는 보상을 받는 신고자를 보낸 주소로 본다
+func txSender(tx *Tx, prev *utxoEntry) string {
+	switch {
+	case tx.Kind == KindUnstake && prev.Stake != nil:
+		return prev.Stake.Staker
+	case tx.Kind == KindSlash && len(tx.TxOuts) > 0:
+		return tx.TxOuts[0].Address
+	}
+	return prev.Output.Address
+}
+
+// 수수료율 비교: a의 수수료율이 b보다 높으면 양수, 같으면 0, 낮으면 음수 (곱셈이 넘치지 않도록 128비트로 계산)
+func (a *mempoolEntry) compareFeeRate(b *mempoolEntry) int {
+	aHi, aLo := bits.Mul64(uint64(a.fee), uint64(b.size))
+	bHi, bLo := bits.Mul64(uint64(b.fee), uint64(a.size))
+	switch {
+	case aHi > bHi || (aHi == bHi && aLo > bLo):
+		return 1
+	case aHi == bHi && aLo == bLo:
+		return 0
+	default:
+		return -1
+	}
+}
+
+// 수수료율 비교 (a의 수수료율이 b보다 높은가)
+func (a *mempoolEntry) higherFeeRate(b *mempoolEntry) bool {
+	return a.compareFeeRate(b) > 0
+}
+
+type mempool struct {
+	txs     map[string]*mempoolEntry // 트랜잭션 해시 -> 트랜잭션 정보
+	spends  map[string]string        // 멤풀의 트랜잭션이 사용하는 UTXO -> 트랜잭션 해시
+	senders map[string]int           // 주소별 트랜잭션 수
+	bytes   int                      // 트랜잭션 크기의 합
+	m       sync.Mutex
+}
+
+var m *mempool
+var memOnce sync.Once
+
+// 대기 중인 트랜잭션들을 저장
+func Mempool() *mempool {
+	memOnce.Do(func() {
+		m = &mempool{
+			txs:     make(map[string]*mempoolEntry),
+			spends:  make(map[string]string),
+			senders: make(map[string]int),
+		}
+	})
+	return m
+}
+
+// 멤풀에 보관 중인 트랜잭션 (트랜잭션 해시 -> 트랜잭션)
+func (m *mempool) Transactions() map[string]*Tx {
+	m.m.Lock()
+	defer m.m.Unlock()
+	txs := make(map[string]*Tx, len(m.txs))
+	for id, e := range m.txs {
+		txs[id] = e.tx
+	}
+	return txs
+}
+
+// UTXO가 mempool에 있는지 확인
+func isOnMempool(uTxOut *UTxOut) bool {
+	pool := Mempool()
+	pool.m.Lock()
+	defer pool.m.Unlock()
+	_, exists := pool.spends[outpoint(uTxOut.TxID, uTxOut.Index)]
+	return exists
+}
+
+// mempool에 트랜잭션을 추가
+func (m *mempool) AddTx(to string, amount, fee Amount, inputData string, port string) (*Tx, error) {
+	return m.AddTxOut(&TxOut{Address: to, Amount: amount}, fee, inputData, 0, port)
+}
+
+// 다중서명 주소로 보내는 트랜잭션을 mempool에 추가
+func (m *mempool) AddMultisigTx(lock *MultisigLock, amount, fee Amount, inputData string, port string) (*Tx, error) {
+	if err := lock.validate(); err != nil {
+		return nil, reject(RejectInvalid, err)
+	}
+	return m.AddTxOut(&TxOut{Address: lock.Address(), Amount: amount, Multisig: lock}, fee, inputData, 0, port)
+}
+
+// 스테이킹 풀로 스테이킹 수량을 보내는 트랜잭션을 mempool에 추가 (출력에는 락업 기간만큼 시간 잠금이 걸림)
+func (m *mempool) AddStakeTx(fee Amount, port string) (*Tx, error) {
+	v, err := activeStakeOf(wallet.Wallet(port).Address)
+	if err != nil {
+		return nil, reject(RejectInvalid, err)
+	}
+	if v != nil {
+		return nil, reject(RejectInvalid, ErrAlreadyStaked)
+	}
+	payload := newPayload()
+	payload.Stake = &StakePayload{Staker: wallet.Wallet(port).Address, Port: port}
+	return m.addTxOut(KindStake, payload, &TxOut{Address: utils.StakingAddress, Amount: utils.StakingQuantity, Lock: stakingLock()}, fee, "staking", 0, port)
+}
+
+// 노드의 지갑으로 주어진 출력을 만드는 일반 전송 트랜잭션을 구성하여 mempool에 추가
+func (m *mempool) AddTxOut(txOut *TxOut, fee Amount, inputData string, lockTime int, port string) (*Tx, error) {
+	return m.AddTxOuts([]*TxOut{txOut}, fee, SelectInOrder, inputData, lockTime, port)
+}
+
+// 여러 받는 쪽에게 한 번에 보내는 일반 전송 트랜잭션을 구성하여 mempool에 추가 (입력 선택은 전체 출력에 대해 한 번, 거스름돈 출력은 하나)
+func (m *mempool) AddTxOuts(txOuts []*TxOut, fee Amount, selection CoinSelection, inputData string, lockTime int, port string) (*Tx, error) {
+	return m.addTxOuts(KindTransfer, nil, txOuts, fee, selection, inputData, lockTime, port)
+}
+
+func (m *mempool) addTxOut(kind TxKind, payload *TxPayload, txOut *TxOut, fee Amount, inputData string, lockTime int, port string) (*Tx, error) {
+	return m.addTxOuts(kind, payload, []*TxOut{txOut}, fee, SelectInOrder, inputData, lockTime, port)
+}
+
+func (m *mempool) addTxOuts(kind TxKind, payload *TxPayload, txOuts []*TxOut, fee Amount, selection CoinSelection, inputData string, lockTime int, port string) (*Tx, error) {
+	for _, txOut := range txOuts {
+		if txOut.Lock != nil {
+			if err := txOut.Lock.validate(); err != nil {
+				return nil, reject(RejectInvalid, err)
+			}
+		}
+	}
+	tx, err := makeTx(kind, payload, wallet.Wallet(port).Address, txOuts, fee, selection, inputData, lockTime, port)
+	if err != nil {
+		return nil, makeTxReject(err)
+	}
+	if err := m.add(tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// 노드 밖에서 구성하고 서명까지 마친 트랜잭션 추가 (노드의 지갑을 사용하지 않음)
+func (m *mempool) SubmitTx(tx *Tx) error {
+	return m.add(tx)
+}
+
+// 노드간 전파된 peer의 트랜잭션 추가
+func (m *mempool) AddPeerTx(tx *Tx) error {
+	return m.add(tx)
+}
+
+// 트랜잭션 생성 에러를 거절 에러로 변환
+func makeTxReject(err error) error {
+	switch {
+	case errors.Is(err, ErrorNoMoney):
+		return reject(RejectInsufficientFunds, err)
+	case errors.Is(err, ErrFeeTooLow):
+		return reject(RejectFeeTooLow, err)
+	case errors.Is(err, ErrOutputLocked), errors.Is(err, ErrTxNotFinal):
+		return reject(RejectLocked, err)
+	default:
+		return reject(RejectInvalid, err)
+	}
+}
+
+// 멤풀 정책에 따라 트랜잭션을 받아들임
+func (m *mempool) add(tx *Tx) error {
+	state := Blockchain().snapshot() // 블록 연결은 체인 락을 잡은 채 멤풀 락을 잡으므로, 체인 락을 먼저 잡고 놓음
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.admit(tx, state, int(time.Now().Unix()))
+}
+
+// 트랜잭션 검증, 충돌 확인, 주소별 제한, 용량 제한(수수료율이 가장 낮은 트랜잭션부터 밀어냄)을 차례로 적용 (호출하는 쪽에서 m.m을 잠가야 함)
+func (m *mempool) admit(tx *Tx, state *chainState, now int) error {
+	if tx == nil {
+		return reject(RejectInvalid, ErrEmptyTx)
+	}
+	m.expire(now)
+	if _, ok := m.txs[tx.ID]; ok {
+		return reject(RejectDuplicate, fmt.Errorf("%w: %s", ErrTxInMempool, tx.ID))
+	}
+	lookup := state.lookup
+	fee, err := validateTx(tx, lookup, make(map[string]bool), state.nextLockContext())
+	if errors.Is(err, ErrOutputLocked) || errors.Is(err, ErrTxNotFinal) {
+		return reject(RejectLocked, err)
+	}
+	if err != nil {
+		return reject(RejectInvalid, err)
+	}
+	if fee < MinTxFee {
+		return reject(RejectFeeTooLow, fmt.Errorf("%w: %d < %d", ErrFeeTooLow, fee, MinTxFee))
+	}
+	for _, txIn := range tx.TxIns {
+		if id, ok := m.spends[outpoint(txIn.TxID, txIn.Index)]; ok {
+			return reject(RejectConflict, fmt.Errorf("%w: %s", ErrConflictingTx, id))
+		}
+	}
+	prev := lookup(tx.TxIns[0].TxID, tx.TxIns[0].Index)
+	entry := &mempoolEntry{tx, fee, tx.size(), txSender(tx, prev), now}
+	if limit := mempoolPolicy.MaxPerSender; limit > 0 && m.senders[entry.sender] >= limit {
+		return reject(RejectSenderLimit, fmt.Errorf("%w: %s", ErrSenderLimit, entry.sender))
+	}
+	if limit := mempoolPolicy.MaxBytes; limit > 0 && entry.size > limit {
+		return reject(RejectMempoolFull, fmt.Errorf("%w: %d bytes", ErrTxTooLarge, entry.size))
+	}
+
+	evict, err := m.evictionsFor(entry)
+	if err != nil {
+		return err
+	}
+	for _, e := range evict {
+		m.remove(e.tx.ID)
+	}
+	m.insert(entry)
+	return nil
+}
+
+// 새 트랜잭션이 들어갈 자리를 만들기 위해 밀어낼 트랜잭션 목록 (수수료율이 낮은 순).
+// 새 트랜잭션보다 수수료율이 낮은 트랜잭션만 밀어낼 수 있다
+func (m *mempool) evictionsFor(entry *mempoolEntry) ([]*mempoolEntry, error) {
+	count, bytes := len(m.txs)+1, m.bytes+entry.size
+	full := func() bool {
+		return (mempoolPolicy.MaxCount > 0 && count > mempoolPolicy.MaxCount) ||
+			(mempoolPolicy.MaxBytes > 0 && bytes > mempoolPolicy.MaxBytes)
+	}
+	if !full() {
+		return nil, nil
+	}
+	var evict []*mempoolEntry
+	for _, e := range m.sorted() {
+		if !entry.higherFeeRate(e) {
+			break
+		}
+		evict = append(evict, e)
+		count, bytes = count-1, bytes-e.size
+		if !full() {
+			return evict, nil
+		}
+	}
+	return nil, reject(RejectMempoolFull, ErrMempoolFull)
+}
+
+// 수수료율이 낮은 순으로 정렬한 트랜잭션 목록 (수수료율이 같다면 나중에 들어온 트랜잭션이 먼저)
+func (m *mempool) sorted() []*mempoolEntry {
+	entries := make([]*mempoolEntry, 0, len(m.txs))
+	for _, e := range m.txs {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.compareFeeRate(b) != 0 {
+			return b.higherFeeRate(a)
+		}
+		if a.added != b.added {
+			return a.added > b.added
+		}
+		return a.tx.ID > b.tx.ID
+	})
+	return entries
+}
+
+// 트랜잭션 추가 (호출하는 쪽에서 m.m을 잠가야 함)
+func (m *mempool) insert(e *mempoolEntry) {
+	m.txs[e.tx.ID] = e
+	for _, txIn := range e.tx.TxIns {
+		m.spends[outpoint(txIn.TxID, txIn.Index)] = e.tx.ID
+	}
+	m.senders[e.sender]++
+	m.bytes += e.size
+}
+
+// 트랜잭션 제거 (호출하는 쪽에서 m.m을 잠가야 함)
+func (m *mempool) remove(id string) {
+	e, ok := m.txs[id]
+	if !ok {
+		return
+	}
+	delete(m.txs, id)
+	for _, txIn := range e.tx.TxIns {
+		delete(m.spends, outpoint(txIn.TxID, txIn.Index))
+	}
+	if m.senders[e.sender]--; m.senders[e.sender] <= 0 {
+		delete(m.senders, e.sender)
+	}
+	m.bytes -= e.size
+}
+
+// 보관 기한이 지난 트랜잭션 제거 (호출하는 쪽에서 m.m을 잠가야 함)
+func (m *mempool) expire(now int) {
+	if mempoolPolicy.ExpirySec <= 0 {
+		return
+	}
+	for id, e := range m.txs {
+		if now-e.added > mempoolPolicy.ExpirySec {
+			m.remove(id)
+		}
+	}
+}
+
+// 블록에 담을 트랜잭션들을 수수료율(수수료 / 크기)이 높은 순으로 골라 반환. 마지막에는 수수료 합을 포함한 코인베이스 트랜잭션이 붙는다
+// 같은 멤풀이라면 어느 노드가 구성해도 같은 순서가 되도록, 수수료율이 같으면 트랜잭션 해시 순으로 정렬
+func (m *mempool) TxToConfirm(port string, roleInfo *RoleInfo, height int) []*Tx {
+	state := Blockchain().snapshot()
+	m.m.Lock()
+	defer m.m.Unlock()
+	m.expire(int(time.Now().Unix()))
+	at := state.nextLockContext()
+	var candidates []*mempoolEntry
+	for _, e := range m.txs {
+		if _, err := validateTx(e.tx, state.lookup, make(map[string]bool), at); err != nil { // 체인이 바뀌어 더 이상 유효하지 않은 트랜잭션
+			continue
+		}
+		candidates = append(candidates, e)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.compareFeeRate(b) != 0 {
+			return a.higherFeeRate(b)
+		}
+		return a.tx.ID < b.tx.ID
+	})
+
+	var txs []*Tx
+	size, fees := 0, Amount(0)
+	for _, e := range candidates {
+		if size+e.size > maxBlockTxBytes {
+			continue
+		}
+		total, err := fees.Add(e.fee)
+		if err != nil {
+			continue
+		}
+		if _, err := proposalReward.Add(total); err != nil { // 제안자 보상이 넘치는 수수료는 담지 않음
+			continue
+		}
+		txs = append(txs, e.tx)
+		size += e.size
+		fees = total
+	}
+	coinbase, err := makeCoinbaseTx(roleInfo, height, fees)
+	if err != nil { // 위에서 보상이 넘치지 않는 만큼만 수수료를 모았으므로 일어나지 않음
+		log.Error(err)
+		return txs
+	}
+	return append(txs, coinbase)
+}
+
+// 블록에 포함된 트랜잭션과, 블록이 사용한 UTXO를 함께 사용하려던 트랜잭션을 멤풀에서 제거
+func (m *mempool) removeConfirmed(block *Block) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	for _, tx := range block.Transaction {
+		m.remove(tx.ID)
+		if tx.isCoinbase() {
+			continue
+		}
+		for _, txIn := range tx.TxIns {
+			if id, ok := m.spends[outpoint(txIn.TxID, txIn.Index)]; ok {
+				m.remove(id)
+			}
+		}
+	}
+}
+
+// 체인 재구성으로 되돌려진 블록의 트랜잭션 중, 새로운 체인(state)에서도 유효한 트랜잭션을 멤풀로 복구
+func (m *mempool) restoreDisconnected(blocks []*Block, state *chainState) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	now := int(time.Now().Unix())
+	for _, block := range blocks {
+		for _, tx := range block.Transaction {
+			if !tx.isCoinbase() {
+				m.admit(tx, state, now)
+			}
+		}
+	}
+}
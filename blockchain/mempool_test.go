@@ -0,0 +1,315 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+)
+
+// 멤풀 트랜잭션의 수수료
+func mempoolFee(t *testing.T, tx *Tx) Amount {
+	t.Helper()
+	e, ok := Mempool().txs[tx.ID]
+	if !ok {
+		t.Fatalf("tx %s is not in the mempool", tx.ID)
+	}
+	return e.fee
+}
+
+func TestTxToConfirmOrdersByFeeRate(t *testing.T) {
+	bc := newTestChain(t)
+	fund(t, bc)
+	for _, fee := range []Amount{1, 4, 2} {
+		if _, err := Mempool().AddTx("ab", 1, fee, "", nodeKey.port); err != nil {
+			t.Fatal(err)
+		}
+	}
+	roles := electedRoles(t, tipBlock(t, bc))
+	txs := Mempool().TxToConfirm(nodeKey.port, roles, bc.Height+1)
+	if len(txs) != 4 || !txs[3].isCoinbase() {
+		t.Fatalf("%d transactions, want 3 and a trailing coinbase", len(txs))
+	}
+	var fees []Amount
+	for _, tx := range txs[:3] {
+		fees = append(fees, mempoolFee(t, tx))
+	}
+	if fees[0] != 4 || fees[1] != 2 || fees[2] != 1 {
+		t.Fatalf("fees in block order = %v, want [4 2 1]", fees)
+	}
+	if txs[3].TxOuts[0].Amount != proposalReward+7 {
+		t.Fatalf("proposer reward = %d, want %d", txs[3].TxOuts[0].Amount, proposalReward+7)
+	}
+}
+
+// 테스트 동안 멤풀 정책을 바꿈
+func withPolicy(t *testing.T, policy MempoolPolicy) {
+	t.Helper()
+	old := mempoolPolicy
+	mempoolPolicy = policy
+	t.Cleanup(func() { mempoolPolicy = old })
+}
+
+// 노드 지갑의 UTXO 중 멤풀이 사용하지 않는 첫 번째 출력
+func freeUTXO(t *testing.T) *utxoEntry {
+	t.Helper()
+	for _, e := range utxosByAddress(nodeKey.address) {
+		if !isOnMempool(e.uTxOut()) {
+			return e
+		}
+	}
+	t.Fatal("node wallet has no free utxo")
+	return nil
+}
+
+func TestMempoolRejections(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy MempoolPolicy
+		submit func(t *testing.T) error
+		code   RejectCode
+		want   error
+	}{
+		{"duplicate", mempoolPolicy, func(t *testing.T) error {
+			tx, err := Mempool().AddTx("ab", 1, 1, "", nodeKey.port)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return Mempool().SubmitTx(tx)
+		}, RejectDuplicate, ErrTxInMempool},
+		{"conflicting spend", mempoolPolicy, func(t *testing.T) error {
+			e := freeUTXO(t)
+			if err := Mempool().SubmitTx(spendTx(nodeKey, []*utxoEntry{e}, &TxOut{Address: "ab", Amount: e.Output.Amount - 1})); err != nil {
+				t.Fatal(err)
+			}
+			return Mempool().SubmitTx(spendTx(nodeKey, []*utxoEntry{e}, &TxOut{Address: "cd", Amount: e.Output.Amount - 2}))
+		}, RejectConflict, ErrConflictingTx},
+		{"fee below the minimum", mempoolPolicy, func(t *testing.T) error {
+			e := freeUTXO(t)
+			return Mempool().SubmitTx(spendTx(nodeKey, []*utxoEntry{e}, &TxOut{Address: "ab", Amount: e.Output.Amount}))
+		}, RejectFeeTooLow, ErrFeeTooLow},
+		{"insufficient funds", mempoolPolicy, func(t *testing.T) error {
+			_, err := Mempool().AddTx("ab", 1000, 1, "", nodeKey.port)
+			return err
+		}, RejectInsufficientFunds, ErrorNoMoney},
+		{"too many from the sender", MempoolPolicy{MaxPerSender: 2}, func(t *testing.T) error {
+			for i := 0; i < 2; i++ {
+				if _, err := Mempool().AddTx("ab", 1, 1, "", nodeKey.port); err != nil {
+					t.Fatal(err)
+				}
+			}
+			_, err := Mempool().AddTx("ab", 1, 1, "", nodeKey.port)
+			return err
+		}, RejectSenderLimit, ErrSenderLimit},
+		{"larger than the mempool", MempoolPolicy{MaxBytes: 10}, func(t *testing.T) error {
+			_, err := Mempool().AddTx("ab", 1, 1, "", nodeKey.port)
+			return err
+		}, RejectMempoolFull, ErrTxTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withPolicy(t, tt.policy)
+			bc := newTestChain(t)
+			fund(t, bc)
+			err := tt.submit(t)
+			if !errors.Is(err, tt.want) || RejectReason(err) != tt.code {
+				t.Fatalf("err = %v (%q), want %v (%q)", err, RejectReason(err), tt.want, tt.code)
+			}
+		})
+	}
+}
+
+func TestMempoolEvictsLowestFeeRate(t *testing.T) {
+	withPolicy(t, MempoolPolicy{MaxCount: 2})
+	bc := newTestChain(t)
+	fund(t, bc)
+	add := func(fee Amount) (*Tx, error) {
+		e := freeUTXO(t)
+		tx := spendTx(nodeKey, []*utxoEntry{e}, &TxOut{Address: "ab", Amount: e.Output.Amount - fee})
+		return tx, Mempool().SubmitTx(tx)
+	}
+	low, err := add(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := add(3); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := add(2); err != nil {
+		t.Fatalf("higher fee rate was not admitted: %v", err)
+	}
+	pool := Mempool()
+	if _, ok := pool.txs[low.ID]; ok || len(pool.txs) != 2 {
+		t.Fatal("lowest fee rate transaction was not evicted")
+	}
+	if _, ok := pool.spends[outpoint(low.TxIns[0].TxID, low.TxIns[0].Index)]; ok || pool.senders[nodeKey.address] != 2 {
+		t.Fatal("eviction left the spend or sender count behind")
+	}
+	if _, err := add(1); RejectReason(err) != RejectMempoolFull {
+		t.Fatalf("err = %v, want %q", err, RejectMempoolFull)
+	}
+}
+
+func TestMempoolExpiresStaleTransactions(t *testing.T) {
+	bc := newTestChain(t)
+	fund(t, bc)
+	stale, err := Mempool().AddTx("ab", 1, 1, "", nodeKey.port)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Mempool().txs[stale.ID].added -= mempoolPolicy.ExpirySec + 1
+	if _, err := Mempool().AddTx("ab", 1, 1, "", nodeKey.port); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := Mempool().Transactions()[stale.ID]; ok {
+		t.Fatal("stale transaction was not expired")
+	}
+}
+
+func TestConfirmedBlockRemovesConflictingTransactions(t *testing.T) {
+	bc := newTestChain(t)
+	fund(t, bc)
+	e := freeUTXO(t)
+	pending := spendTx(nodeKey, []*utxoEntry{e}, &TxOut{Address: "ab", Amount: e.Output.Amount - 1})
+	if err := Mempool().SubmitTx(pending); err != nil {
+		t.Fatal(err)
+	}
+	// 같은 출력을 사용하는 다른 트랜잭션이 다른 노드의 블록으로 확정됨
+	confirmed := spendTx(nodeKey, []*utxoEntry{e}, &TxOut{Address: "cd", Amount: e.Output.Amount - 2})
+	mine(t, bc, func(t *testing.T, roles *RoleInfo, height int) []*Tx {
+		coinbase, err := makeCoinbaseTx(roles, height, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return []*Tx{confirmed, coinbase}
+	})
+	if len(Mempool().Transactions()) != 0 {
+		t.Fatal("transaction spending a confirmed input stayed in the mempool")
+	}
+}
+
+func TestPeerTransactionsPassAdmission(t *testing.T) {
+	// 피어 책임 여부: 트랜잭션 자체가 잘못되었다면 전달한 피어의 책임이지만, 체인 상태나 멤풀 정책에 따른 거절은 아님
+	tests := []struct {
+		name    string
+		tx      func(e *utxoEntry) *Tx
+		want    error
+		invalid bool
+	}{
+		{"nil transaction", func(e *utxoEntry) *Tx { return nil }, ErrEmptyTx, true},
+		{"id does not match the content", func(e *utxoEntry) *Tx {
+			tx := spendTx(nodeKey, []*utxoEntry{e}, &TxOut{Address: "ab", Amount: 1})
+			tx.TxOuts[0].Amount = 2
+			return tx
+		}, ErrInvalidTxID, true},
+		{"signed by another key", func(e *utxoEntry) *Tx {
+			return spendTx(testKeys[genesisValidators[1].Address], []*utxoEntry{e}, &TxOut{Address: "ab", Amount: 1})
+		}, ErrInvalidTxSignature, true},
+		{"outputs exceed inputs", func(e *utxoEntry) *Tx {
+			return spendTx(nodeKey, []*utxoEntry{e}, &TxOut{Address: "ab", Amount: e.Output.Amount + 1})
+		}, ErrOutputsExceedInputs, true},
+		{"zero amount output", func(e *utxoEntry) *Tx {
+			return spendTx(nodeKey, []*utxoEntry{e}, &TxOut{Address: "ab", Amount: 0})
+		}, ErrInvalidOutputAmount, true},
+		{"same output spent twice", func(e *utxoEntry) *Tx {
+			return spendTx(nodeKey, []*utxoEntry{e, e}, &TxOut{Address: "ab", Amount: 1})
+		}, ErrDoubleSpend, true},
+		{"unknown transaction kind", func(e *utxoEntry) *Tx {
+			tx := &Tx{Timestamp: 1700000000, TxIns: []*TxIn{{TxID: e.TxID, Index: e.Index}}, TxOuts: []*TxOut{{Address: "ab", Amount: 1}}, Kind: "mint"}
+			tx.getId()
+			tx.TxIns[0].Signature = nodeKey.sign(tx.ID)
+			return tx
+		}, ErrUnknownTxKind, true},
+		{"unknown input", func(e *utxoEntry) *Tx {
+			missing := *e
+			missing.TxID = "ff"
+			return spendTx(nodeKey, []*utxoEntry{&missing}, &TxOut{Address: "ab", Amount: 1})
+		}, ErrMissingInput, false},
+		{"fee below the minimum", func(e *utxoEntry) *Tx {
+			return spendTx(nodeKey, []*utxoEntry{e}, &TxOut{Address: "ab", Amount: e.Output.Amount})
+		}, ErrFeeTooLow, false},
+		{"not final yet", func(e *utxoEntry) *Tx {
+			tx := &Tx{Timestamp: 1700000000, TxIns: []*TxIn{{TxID: e.TxID, Index: e.Index}}, TxOuts: []*TxOut{{Address: "ab", Amount: 1}}, Kind: KindTransfer, LockTime: 1000}
+			tx.getId()
+			tx.TxIns[0].Signature = nodeKey.sign(tx.ID)
+			return tx
+		}, ErrTxNotFinal, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bc := newTestChain(t)
+			fund(t, bc)
+			err := Mempool().AddPeerTx(tt.tx(freeUTXO(t)))
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			if IsInvalidRelay(err) != tt.invalid {
+				t.Fatalf("IsInvalidRelay = %v, want %v", IsInvalidRelay(err), tt.invalid)
+			}
+			if len(Mempool().Transactions()) != 0 {
+				t.Fatal("rejected transaction entered the mempool")
+			}
+		})
+	}
+}
+
+func TestSubmitTxAcceptsClientSignedTransactions(t *testing.T) {
+	bc := newTestChain(t)
+	fund(t, bc)
+	// 노드 지갑이 아닌 키로 서명하여 JSON으로 전달된 트랜잭션
+	client := testKeys[genesisValidators[1].Address]
+	inputs := utxosByAddress(client.address)
+	var total Amount
+	for _, e := range inputs {
+		total += e.Output.Amount
+	}
+	data, err := json.Marshal(spendTx(client, inputs, &TxOut{Address: "ab", Amount: 5}, &TxOut{Address: client.address, Amount: total - 6}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tx Tx
+	if err := json.Unmarshal(data, &tx); err != nil {
+		t.Fatal(err)
+	}
+	unsigned := tx
+	unsigned.TxIns = nil
+	for _, txIn := range tx.TxIns {
+		unsigned.TxIns = append(unsigned.TxIns, &TxIn{TxID: txIn.TxID, Index: txIn.Index})
+	}
+	if err := Mempool().SubmitTx(&unsigned); !errors.Is(err, ErrInvalidTxSignature) {
+		t.Fatalf("unsigned: err = %v, want %v", err, ErrInvalidTxSignature)
+	}
+	if err := Mempool().SubmitTx(&tx); err != nil {
+		t.Fatal(err)
+	}
+	mine(t, bc, fromMempool)
+	if balance(t, bc, "ab") != 5 || balance(t, bc, client.address) != total-6 {
+		t.Fatal("client-signed transaction was not confirmed")
+	}
+}
+
+func TestAdmissionRunsConcurrentlyWithImport(t *testing.T) {
+	bc := newTestChain(t)
+	fund(t, bc)
+	// 블록을 연결하는 동안 다른 고루틴에서 멤풀이 트랜잭션을 받아들이고 블록에 담을 트랜잭션을 고름 (go test -race로 확인)
+	roles := electedRoles(t, tipBlock(t, bc))
+	height := bc.Height + 1
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 5; i++ {
+			if _, err := Mempool().AddTx("ab", 1, MinTxFee, "", nodeKey.port); err != nil {
+				t.Error(err)
+			}
+			Mempool().TxToConfirm(nodeKey.port, roles, height)
+		}
+	}()
+	for i := 0; i < 5; i++ {
+		mine(t, bc, coinbaseOnly)
+	}
+	wg.Wait()
+	if len(Mempool().Transactions()) != 5 {
+		t.Fatalf("%d transactions in the mempool, want 5", len(Mempool().Transactions()))
+	}
+}
@@ -0,0 +1,175 @@
+package blockchain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// 머클 트리 노드 해시 계산 시, 리프와 내부 노드를 구분하기 위한 접두 바이트 (second preimage 공격 방지)
+const (
+	merkleLeafPrefix byte = 0x00
+	merkleNodePrefix byte = 0x01
+)
+
+// 블록에 트랜잭션이 포함되지 않았을 경우의 에러
+var ErrTxNotInBlock = errors.New("transaction not in block")
+
+// 머클 증명의 한 단계 (형제 노드의 해시와 위치)
+type MerkleStep struct {
+	Hash string `json:"hash"` // 형제 노드의 해시 값 (짝이 없어 그대로 올라가는 단계라면 빈 문자열)
+	Left bool   `json:"left"` // 형제 노드가 왼쪽에 위치하는지 여부
+}
+
+// 트랜잭션 포함 증명에 대한 구조체
+type MerkleProof struct {
+	TxID       string        `json:"txId"`       // 증명 대상 트랜잭션의 해시 값
+	BlockHash  string        `json:"blockHash"`  // 트랜잭션이 포함된 블록의 해시 값
+	MerkleRoot string        `json:"merkleRoot"` // 블록 헤더의 머클 루트
+	Index      int           `json:"index"`      // 블록 내 트랜잭션의 위치
+	Path       []*MerkleStep `json:"path"`       // 리프에서 루트까지 트리 높이만큼의 형제 노드 목록
+}
+
+// 트랜잭션 ID로 머클 트리의 리프 해시 계산. ID는 SHA-256 해시의 16진수 문자열이어야 함
+func merkleLeaf(txID string) ([]byte, error) {
+	id, err := hex.DecodeString(txID)
+	if err != nil || len(id) != sha256.Size {
+		return nil, fmt.Errorf("%w: malformed id %q", ErrInvalidTxID, txID)
+	}
+	return merkleLeafOf(id), nil
+}
+
+// 리프 값의 해시 계산
+func merkleLeafOf(data []byte) []byte {
+	h := sha256.Sum256(append([]byte{merkleLeafPrefix}, data...))
+	return h[:]
+}
+
+// 두 자식 노드로 부모 노드 해시 계산
+func merkleNode(left, right []byte) []byte {
+	data := make([]byte, 0, 1+len(left)+len(right))
+	data = append(data, merkleNodePrefix)
+	data = append(data, left...)
+	data = append(data, right...)
+	h := sha256.Sum256(data)
+	return h[:]
+}
+
+// 한 단계 위의 머클 트리 레벨 계산. 짝이 없는 마지막 노드는 그대로 올려보낸다
+func merkleLevelUp(level [][]byte) [][]byte {
+	var next [][]byte
+	for i := 0; i < len(level); i += 2 {
+		if i+1 == len(level) {
+			next = append(next, level[i])
+		} else {
+			next = append(next, merkleNode(level[i], level[i+1]))
+		}
+	}
+	return next
+}
+
+// 트랜잭션 목록의 머클 루트 계산
+func merkleRoot(txs []*Tx) (string, error) {
+	level := make([][]byte, 0, len(txs))
+	for _, tx := range txs {
+		leaf, err := merkleLeaf(tx.ID)
+		if err != nil {
+			return "", err
+		}
+		level = append(level, leaf)
+	}
+	return merkleRootOf(level), nil
+}
+
+// 트랜잭션 목록의 증인 루트 계산: 서명과 증인 값까지 포함한 트랜잭션 전체 직렬화의 해시를 리프로 사용
+func witnessRoot(txs []*Tx) string {
+	level := make([][]byte, 0, len(txs))
+	for _, tx := range txs {
+		h := sha256.Sum256(tx.serializeWitness())
+		level = append(level, merkleLeafOf(h[:]))
+	}
+	return merkleRootOf(level)
+}
+
+// 리프 해시 목록의 머클 루트 계산 (리프가 없다면 빈 문자열)
+func merkleRootOf(level [][]byte) string {
+	if len(level) == 0 {
+		return ""
+	}
+	for len(level) > 1 {
+		level = merkleLevelUp(level)
+	}
+	return fmt.Sprintf("%x", level[0])
+}
+
+// 블록 내 특정 트랜잭션의 머클 포함 증명 생성
+func (b *Block) MerkleProof(txID string) (*MerkleProof, error) {
+	index := -1
+	level := make([][]byte, 0, len(b.Transaction))
+	for i, tx := range b.Transaction {
+		if tx.ID == txID {
+			index = i
+		}
+		leaf, err := merkleLeaf(tx.ID)
+		if err != nil {
+			return nil, err
+		}
+		level = append(level, leaf)
+	}
+	if index < 0 {
+		return nil, ErrTxNotInBlock
+	}
+	proof := &MerkleProof{
+		TxID:       txID,
+		BlockHash:  b.Hash,
+		MerkleRoot: b.MerkleRoot,
+		Index:      index,
+	}
+	for pos := index; len(level) > 1; pos /= 2 {
+		if pos%2 == 1 {
+			proof.Path = append(proof.Path, &MerkleStep{fmt.Sprintf("%x", level[pos-1]), true})
+		} else if pos+1 < len(level) {
+			proof.Path = append(proof.Path, &MerkleStep{fmt.Sprintf("%x", level[pos+1]), false})
+		} else {
+			proof.Path = append(proof.Path, &MerkleStep{"", false})
+		}
+		level = merkleLevelUp(level)
+	}
+	return proof, nil
+}
+
+// 머클 포함 증명을 신뢰할 수 있는 블록 헤더의 머클 루트와 비교하여 검증
+// 각 단계의 형제 위치가 Index의 비트와 일치해야 하므로 증명은 주장한 위치에도 묶인다
+func VerifyMerkleProof(proof *MerkleProof, merkleRoot string) bool {
+	if proof == nil || proof.Index < 0 {
+		return false
+	}
+	h, err := merkleLeaf(proof.TxID)
+	if err != nil {
+		return false
+	}
+	pos := proof.Index
+	for _, step := range proof.Path {
+		if step == nil || step.Left != (pos%2 == 1) {
+			return false
+		}
+		pos /= 2
+		if step.Hash == "" { // 짝이 없어 그대로 올라가는 노드
+			if step.Left {
+				return false
+			}
+			continue
+		}
+		sibling, err := hex.DecodeString(step.Hash)
+		if err != nil {
+			return false
+		}
+		if step.Left {
+			h = merkleNode(sibling, h)
+		} else {
+			h = merkleNode(h, sibling)
+		}
+	}
+	return pos == 0 && fmt.Sprintf("%x", h) == merkleRoot
+}
@@ -0,0 +1,111 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/abcfe-op/abcfe-node/common/utils"
+)
+
+// 트랜잭션 n개를 담은 블록 (트랜잭션 ID만 채움)
+func merkleBlock(n int) *Block {
+	block := &Block{}
+	for i := 0; i < n; i++ {
+		block.Transaction = append(block.Transaction, &Tx{ID: utils.HashBytes([]byte(fmt.Sprint(i)))})
+	}
+	block.MerkleRoot, _ = merkleRoot(block.Transaction)
+	return block
+}
+
+func TestMerkleProofVerifiesEveryIndex(t *testing.T) {
+	for n := 1; n <= 9; n++ {
+		block := merkleBlock(n)
+		for i, tx := range block.Transaction {
+			proof, err := block.MerkleProof(tx.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if proof.Index != i || !VerifyMerkleProof(proof, block.MerkleRoot) {
+				t.Fatalf("proof for tx %d of %d does not verify", i, n)
+			}
+		}
+	}
+}
+
+func TestMerkleProofBindsIndex(t *testing.T) {
+	tests := []struct {
+		name   string
+		n, i   int
+		mutate func(p *MerkleProof)
+	}{
+		{"sibling index", 4, 1, func(p *MerkleProof) { p.Index = 0 }},
+		{"index beyond the tree", 4, 1, func(p *MerkleProof) { p.Index = 5 }},
+		{"index of a promoted node", 3, 2, func(p *MerkleProof) { p.Index = 6 }},
+		{"negative index", 2, 0, func(p *MerkleProof) { p.Index = -2 }},
+		{"flipped sibling side", 4, 2, func(p *MerkleProof) { p.Path[0].Left = !p.Path[0].Left }},
+		{"promoted step on the left", 3, 2, func(p *MerkleProof) { p.Path[0].Left = true }},
+		{"tampered sibling", 4, 3, func(p *MerkleProof) { p.Path[1].Hash = utils.HashBytes([]byte("x")) }},
+		{"truncated path", 4, 3, func(p *MerkleProof) { p.Path = p.Path[:1] }},
+		{"missing step", 4, 0, func(p *MerkleProof) { p.Path[0] = nil }},
+		{"other transaction", 4, 0, func(p *MerkleProof) { p.TxID = utils.HashBytes([]byte("x")) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			block := merkleBlock(tt.n)
+			proof, err := block.MerkleProof(block.Transaction[tt.i].ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.mutate(proof)
+			if VerifyMerkleProof(proof, block.MerkleRoot) {
+				t.Fatal("tampered proof verified")
+			}
+		})
+	}
+}
+
+func TestMerkleProofOfMissingTx(t *testing.T) {
+	block := merkleBlock(3)
+	if _, err := block.MerkleProof(utils.HashBytes([]byte("x"))); !errors.Is(err, ErrTxNotInBlock) {
+		t.Fatalf("err = %v, want %v", err, ErrTxNotInBlock)
+	}
+	if VerifyMerkleProof(nil, block.MerkleRoot) {
+		t.Fatal("nil proof verified")
+	}
+}
+
+func TestMerkleRejectsMalformedIDs(t *testing.T) {
+	for _, id := range []string{"", "zz", "abcd", utils.HashBytes([]byte("x")) + "00"} {
+		block := merkleBlock(2)
+		block.Transaction[1].ID = id
+		if _, err := merkleRoot(block.Transaction); !errors.Is(err, ErrInvalidTxID) {
+			t.Fatalf("root over id %q: err = %v, want %v", id, err, ErrInvalidTxID)
+		}
+		if _, err := block.MerkleProof(block.Transaction[0].ID); !errors.Is(err, ErrInvalidTxID) {
+			t.Fatalf("proof in a block with id %q: err = %v, want %v", id, err, ErrInvalidTxID)
+		}
+		// 잘못된 ID를 빈 리프로 취급한다면 빈 리프 하나로 만든 루트에 대해 증명이 통과함
+		if VerifyMerkleProof(&MerkleProof{TxID: id}, fmt.Sprintf("%x", merkleLeafOf(nil))) {
+			t.Fatalf("proof for id %q verified", id)
+		}
+	}
+}
+
+func TestMerkleRootDependsOnOrder(t *testing.T) {
+	block := merkleBlock(2)
+	root := block.MerkleRoot
+	block.Transaction[0], block.Transaction[1] = block.Transaction[1], block.Transaction[0]
+	if reordered, _ := merkleRoot(block.Transaction); reordered == root {
+		t.Fatal("reordering transactions kept the merkle root")
+	}
+	// 짝이 없는 노드를 복제하지 않으므로 마지막 트랜잭션을 반복해도 루트가 같아지지 않음
+	three := merkleBlock(3)
+	four := merkleBlock(3)
+	four.Transaction = append(four.Transaction, four.Transaction[2])
+	threeRoot, _ := merkleRoot(three.Transaction)
+	fourRoot, _ := merkleRoot(four.Transaction)
+	if threeRoot == fourRoot {
+		t.Fatal("duplicating the last transaction kept the merkle root")
+	}
+}
@@ -0,0 +1,177 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/abcfe-op/abcfe-node/common/utils"
+	"github.com/abcfe-op/abcfe-node/wallet"
+)
+
+const maxMultisigKeys = 15 // 다중서명 잠금 조건에 넣을 수 있는 최대 공개키 수
+
+var (
+	ErrInvalidMultisig        = errors.New("invalid multisig lock")
+	ErrMultisigAddress        = errors.New("address does not match the multisig lock")
+	ErrNotEnoughMultisigSigs  = errors.New("not enough multisig signatures")
+	ErrTooManyMultisigSigs    = errors.New("more signatures than multisig keys")
+	ErrNotMultisigCosigner    = errors.New("wallet is not a cosigner of any input")
+	ErrNotMultisigTransaction = errors.New("transaction does not spend a multisig output")
+)
+
+// M-of-N 다중서명 잠금 조건: 나열된 N개의 공개키(지갑 주소) 중 M개의 서명이 있어야 출력을 사용할 수 있다
+// 다중서명 주소는 스테이커가 될 수 없다 (언스테이킹과 블록 서명을 스테이커 키 하나로 검증하므로, validateStake 참고)
+type MultisigLock struct {
+	M       int      `json:"m"`
+	PubKeys []string `json:"pubKeys"`
+}
+
+// 잠금 조건으로 만든 다중서명 주소
+func (l *MultisigLock) Address() string {
+	return utils.HashBytes(l.serialize())
+}
+
+// 잠금 조건 검증: 1 <= M <= N <= 최대 공개키 수, 공개키는 비어있지 않고 중복되지 않아야 함
+func (l *MultisigLock) validate() error {
+	n := len(l.PubKeys)
+	if l.M < 1 || l.M > n || n > maxMultisigKeys {
+		return fmt.Errorf("%w: %d of %d", ErrInvalidMultisig, l.M, n)
+	}
+	seen := make(map[string]bool)
+	for _, key := range l.PubKeys {
+		if key == "" || seen[key] {
+			return fmt.Errorf("%w: empty or duplicate key", ErrInvalidMultisig)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// 다중서명 검증: 서명마다 아직 사용되지 않은 공개키 중 서명이 맞는 키를 찾아, 서로 다른 키 M개 이상이 서명했는지 확인
+func verifyMultisig(signatures []string, payload string, lock *MultisigLock) error {
+	if len(signatures) > len(lock.PubKeys) {
+		return fmt.Errorf("%w: %d > %d", ErrTooManyMultisigSigs, len(signatures), len(lock.PubKeys))
+	}
+	used := make(map[string]bool)
+	for _, sig := range signatures {
+		for _, key := range lock.PubKeys {
+			if !used[key] && wallet.Verify(sig, payload, key) {
+				used[key] = true
+				break
+			}
+		}
+	}
+	if len(used) < lock.M {
+		return fmt.Errorf("%w: %d of %d", ErrNotEnoughMultisigSigs, len(used), lock.M)
+	}
+	return nil
+}
+
+// 다중서명 주소에서 txOut의 자산(기본 코인 또는 발행된 자산)을 보내는 서명 전의 트랜잭션 생성. 수수료는 기본 코인으로 지불하고,
+// 거스름돈은 자산별로 같은 잠금 조건으로 돌려받는다. 공동 서명자들이 SignMultisig(또는 외부 서명)로 서명을 모은 뒤 원시 트랜잭션으로 제출한다
+func MakeMultisigTx(from string, txOut *TxOut, fee Amount, inputData string) (*Tx, error) {
+	if fee < MinTxFee {
+		return nil, fmt.Errorf("%w: %d < %d", ErrFeeTooLow, fee, MinTxFee)
+	}
+	need := amounts{NativeAsset: fee}
+	if err := need.add(txOut.Asset, txOut.Amount); err != nil {
+		return nil, err
+	}
+	var txIns []*TxIn
+	var lock *MultisigLock
+	total := make(amounts)
+	at := Blockchain().snapshot().nextLockContext()
+	for _, e := range utxosByAddress(from) {
+		asset := e.Output.Asset
+		if total[asset] >= need[asset] { // 필요 없는 자산이거나 이미 충분히 모은 자산
+			continue
+		}
+		if e.Output.Multisig == nil || isOnMempool(e.uTxOut()) || (e.Output.Lock != nil && e.Output.Lock.check(e, at) != nil) {
+			continue
+		}
+		if err := total.add(asset, e.Output.Amount); err != nil {
+			return nil, err
+		}
+		lock = e.Output.Multisig
+		txIns = append(txIns, &TxIn{TxID: e.TxID, Index: e.Index})
+	}
+	assets := make([]string, 0, len(need))
+	for asset := range need {
+		assets = append(assets, asset)
+	}
+	sort.Strings(assets) // 거스름돈 출력 순서 고정 (기본 코인이 먼저)
+	var txOuts []*TxOut
+	for _, asset := range assets {
+		if total[asset] < need[asset] {
+			if asset != NativeAsset {
+				return nil, fmt.Errorf("%w: asset %s", ErrorNoMoney, asset)
+			}
+			return nil, ErrorNoMoney
+		}
+		if change := total[asset] - need[asset]; change != 0 {
+			txOuts = append(txOuts, &TxOut{Address: from, Amount: change, Asset: asset, Multisig: lock})
+		}
+	}
+	txOuts = append(txOuts, txOut)
+	tx := &Tx{
+		Timestamp: int(time.Now().Unix()),
+		TxIns:     txIns,
+		TxOuts:    txOuts,
+		InputData: inputData,
+		Kind:      KindTransfer,
+	}
+	tx.getId()
+	return tx, nil
+}
+
+// 노드의 지갑이 공동 서명자로 들어있는 다중서명 입력에 서명을 추가하고, 추가한 서명 수를 반환
+func SignMultisig(tx *Tx, port string) (int, error) {
+	if tx == nil || tx.ID != tx.calculateID() {
+		return 0, ErrInvalidTxID
+	}
+	w := wallet.Wallet(port)
+	lookup := Blockchain().snapshot().lookup
+	multisig, signed := false, 0
+	for _, txIn := range tx.TxIns {
+		prev := lookup(txIn.TxID, txIn.Index)
+		if prev == nil {
+			return 0, fmt.Errorf("%w: %s", ErrMissingInput, outpoint(txIn.TxID, txIn.Index))
+		}
+		if prev.Output.Multisig == nil {
+			continue
+		}
+		multisig = true
+		if !contains(prev.Output.Multisig.PubKeys, w.Address) || hasSignatureBy(txIn.Signatures, tx.ID, w.Address) {
+			continue
+		}
+		txIn.Signatures = append(txIn.Signatures, wallet.Sign(tx.ID, w))
+		signed++
+	}
+	if !multisig {
+		return 0, ErrNotMultisigTransaction
+	}
+	if signed == 0 {
+		return 0, ErrNotMultisigCosigner
+	}
+	return signed, nil
+}
+
+// 서명 목록에 특정 공개키의 서명이 이미 있는지 확인
+func hasSignatureBy(signatures []string, payload, key string) bool {
+	for _, sig := range signatures {
+		if wallet.Verify(sig, payload, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// 잠금 조건을 검증한 뒤 다중서명 주소 반환 (외부 패키지에서 주소를 계산할 때 사용)
+func (l *MultisigLock) CheckedAddress() (string, error) {
+	if err := l.validate(); err != nil {
+		return "", err
+	}
+	return l.Address(), nil
+}
@@ -0,0 +1,183 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// 제네시스 검증자 앞에서부터 n명의 키
+func cosigners(n int) []*testKey {
+	var keys []*testKey
+	for _, v := range genesisValidators[:n] {
+		keys = append(keys, testKeys[v.Address])
+	}
+	return keys
+}
+
+func lockOf(m int, keys []*testKey) *MultisigLock {
+	lock := &MultisigLock{M: m}
+	for _, key := range keys {
+		lock.PubKeys = append(lock.PubKeys, key.address)
+	}
+	return lock
+}
+
+func TestMultisigLockValidate(t *testing.T) {
+	many := make([]string, maxMultisigKeys+1)
+	for i := range many {
+		many[i] = fmt.Sprintf("%02x", i)
+	}
+	tests := []struct {
+		name string
+		lock *MultisigLock
+		ok   bool
+	}{
+		{"2 of 3", &MultisigLock{M: 2, PubKeys: []string{"aa", "bb", "cc"}}, true},
+		{"n of n", &MultisigLock{M: 3, PubKeys: []string{"aa", "bb", "cc"}}, true},
+		{"zero threshold", &MultisigLock{M: 0, PubKeys: []string{"aa"}}, false},
+		{"threshold above the keys", &MultisigLock{M: 3, PubKeys: []string{"aa", "bb"}}, false},
+		{"too many keys", &MultisigLock{M: 1, PubKeys: many}, false},
+		{"empty key", &MultisigLock{M: 1, PubKeys: []string{"aa", ""}}, false},
+		{"duplicate key", &MultisigLock{M: 2, PubKeys: []string{"aa", "aa"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.lock.validate()
+			if (err == nil) != tt.ok || (err != nil && !errors.Is(err, ErrInvalidMultisig)) {
+				t.Fatalf("err = %v, want ok = %v", err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestVerifyMultisig(t *testing.T) {
+	keys := cosigners(4)
+	lock := lockOf(2, keys[:3])
+	const payload = "aabbcc"
+	sign := func(signers ...int) []string {
+		var sigs []string
+		for _, i := range signers {
+			sigs = append(sigs, keys[i].sign(payload))
+		}
+		return sigs
+	}
+	tests := []struct {
+		name string
+		sigs []string
+		want error
+	}{
+		{"threshold met", sign(0, 1), nil},
+		{"all cosigners in any order", sign(2, 0, 1), nil},
+		{"one signature", sign(1), ErrNotEnoughMultisigSigs},
+		{"same key twice", sign(0, 0), ErrNotEnoughMultisigSigs},
+		{"signer outside the lock", sign(0, 3), ErrNotEnoughMultisigSigs},
+		{"signature over another payload", append(sign(0), keys[1].sign("ddeeff")), ErrNotEnoughMultisigSigs},
+		{"more signatures than keys", sign(0, 1, 2, 3), ErrTooManyMultisigSigs},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verifyMultisig(tt.sigs, payload, lock); !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMultisigSpendEndToEnd(t *testing.T) {
+	bc := newTestChain(t)
+	fund(t, bc)
+	keys := cosigners(3)
+	lock := lockOf(2, keys)
+	if _, err := Mempool().AddMultisigTx(lock, 20, 1, "", nodeKey.port); err != nil {
+		t.Fatal(err)
+	}
+	mine(t, bc, fromMempool)
+	if balance(t, bc, lock.Address()) != 20 {
+		t.Fatal("multisig output was not created")
+	}
+
+	tx, err := MakeMultisigTx(lock.Address(), &TxOut{Address: "ab", Amount: 5}, 1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, err := SignMultisig(tx, nodeKey.port); err != nil || n != 1 {
+		t.Fatalf("node signed %d inputs: %v", n, err)
+	}
+	if _, err := SignMultisig(tx, nodeKey.port); !errors.Is(err, ErrNotMultisigCosigner) {
+		t.Fatalf("signing twice: err = %v, want %v", err, ErrNotMultisigCosigner)
+	}
+	if err := Mempool().SubmitTx(tx); !errors.Is(err, ErrNotEnoughMultisigSigs) {
+		t.Fatalf("one of two signatures: err = %v, want %v", err, ErrNotEnoughMultisigSigs)
+	}
+	// 다른 공동 서명자가 노드 밖에서 서명을 더함
+	tx.TxIns[0].Signatures = append(tx.TxIns[0].Signatures, keys[2].sign(tx.ID))
+	if err := Mempool().SubmitTx(tx); err != nil {
+		t.Fatal(err)
+	}
+	mine(t, bc, fromMempool)
+	if balance(t, bc, "ab") != 5 || balance(t, bc, lock.Address()) != 14 {
+		t.Fatal("multisig spend was not confirmed")
+	}
+	for _, e := range utxosByAddress(lock.Address()) {
+		if !sameMultisig(e.Output.Multisig, lock) {
+			t.Fatal("change lost the multisig lock")
+		}
+	}
+}
+
+func TestMultisigSpendsIssuedAsset(t *testing.T) {
+	bc := newTestChain(t)
+	fund(t, bc)
+	keys := cosigners(3)
+	lock := lockOf(2, keys)
+	issue, err := Mempool().IssueAsset("TOK", 0, 300, "", 1, nodeKey.port)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mine(t, bc, fromMempool)
+	id := issue.Payload.Issue.Asset()
+	// 다중서명 주소로 자산과 수수료로 쓸 기본 코인을 보냄
+	if _, err := Mempool().AddTxOuts([]*TxOut{{Address: lock.Address(), Amount: 300, Asset: id, Multisig: lock}, {Address: lock.Address(), Amount: 5, Multisig: lock}}, 1, SelectInOrder, "", 0, nodeKey.port); err != nil {
+		t.Fatal(err)
+	}
+	mine(t, bc, fromMempool)
+
+	tx, err := MakeMultisigTx(lock.Address(), &TxOut{Address: "ab", Amount: 100, Asset: id}, 1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := SignMultisig(tx, nodeKey.port); err != nil {
+		t.Fatal(err)
+	}
+	for _, txIn := range tx.TxIns {
+		txIn.Signatures = append(txIn.Signatures, keys[2].sign(tx.ID))
+	}
+	if err := Mempool().SubmitTx(tx); err != nil {
+		t.Fatal(err)
+	}
+	mine(t, bc, fromMempool)
+	if assetBalance(t, "ab", id) != 100 || assetBalance(t, lock.Address(), id) != 200 || balance(t, bc, lock.Address()) != 4 {
+		t.Fatal("multisig asset spend was not confirmed")
+	}
+	for _, e := range utxosByAddress(lock.Address()) {
+		if !sameMultisig(e.Output.Multisig, lock) {
+			t.Fatal("change lost the multisig lock")
+		}
+	}
+
+	if _, err := MakeMultisigTx(lock.Address(), &TxOut{Address: "ab", Amount: 201, Asset: id}, 1, ""); !errors.Is(err, ErrorNoMoney) {
+		t.Fatalf("err = %v, want %v", err, ErrorNoMoney)
+	}
+}
+
+func TestMultisigOutputAddressMustMatchLock(t *testing.T) {
+	bc := newTestChain(t)
+	fund(t, bc)
+	e := freeUTXO(t)
+	lock := lockOf(1, cosigners(2))
+	tx := spendTx(nodeKey, []*utxoEntry{e}, &TxOut{Address: "ab", Amount: 1, Multisig: lock})
+	if err := Mempool().SubmitTx(tx); !errors.Is(err, ErrMultisigAddress) {
+		t.Fatalf("err = %v, want %v", err, ErrMultisigAddress)
+	}
+}
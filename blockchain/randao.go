@@ -0,0 +1,54 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/abcfe-op/abcfe-node/common/utils"
+	"github.com/abcfe-op/abcfe-node/wallet"
+)
+
+// 선출 무작위 값 (RANDAO): 블록마다 제안자가 이전 무작위 값과 높이에 대한 VRF 증명(RandaoReveal)을 공개하고,
+// 그 출력을 이전 무작위 값에 섞어 새 무작위 값(RandaoMix)을 만든다. 다음 높이의 선출은 이 값으로 시드를 정한다.
+// VRF 출력은 입력마다 하나로 정해지므로, 제안자는 블록 내용을 바꾸어 가며 다음 선출 결과를 고를 수 없다
+var ErrInvalidRandao = errors.New("invalid randao reveal")
+
+// 제네시스 블록의 무작위 값
+var genesisRandaoMix = utils.HashBytes([]byte("abcfe genesis randao"))
+
+// 높이의 제안자가 VRF로 증명하는 입력 (제안자가 바꿀 수 없는 이전 무작위 값과 높이만 사용)
+func randaoInput(prevMix string, height int) string {
+	e := &canonicalEncoder{}
+	e.writeString("randao")
+	e.writeString(prevMix)
+	e.writeInt(height)
+	return utils.HashBytes(e.bytes())
+}
+
+// 이전 무작위 값에 VRF 출력을 섞은 새 무작위 값
+func mixRandao(prevMix, output string) string {
+	e := &canonicalEncoder{}
+	e.writeString("randao-mix")
+	e.writeString(prevMix)
+	e.writeString(output)
+	return utils.HashBytes(e.bytes())
+}
+
+// 제안자의 지갑으로 블록의 VRF 증명과 새 무작위 값 기록
+func (b *Block) reveal(prev *Block, port string) {
+	b.RandaoReveal = wallet.Prove(randaoInput(prev.RandaoMix, b.Height), wallet.Wallet(port))
+	b.RandaoMix = mixRandao(prev.RandaoMix, wallet.ProofOutput(b.RandaoReveal))
+}
+
+// 블록의 VRF 증명이 제안자의 것이고, 무작위 값이 이전 블록의 무작위 값에 그 출력을 섞은 값인지 확인
+func validateRandao(block *Block) error {
+	prev, err := FindBlock(block.PrevHash)
+	if err != nil {
+		return err
+	}
+	output, ok := wallet.VerifyProof(block.RandaoReveal, randaoInput(prev.RandaoMix, block.Height), block.RoleInfo.ProposerAddress)
+	if !ok || block.RandaoMix != mixRandao(prev.RandaoMix, output) {
+		return fmt.Errorf("%w: %s", ErrInvalidRandao, block.Hash)
+	}
+	return nil
+}
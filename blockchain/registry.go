@@ -0,0 +1,243 @@
+package blockchain
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/abcfe-op/abcfe-node/common/utils"
+	"github.com/abcfe-op/abcfe-node/db"
+	"github.com/abcfe-op/abcfe-node/wallet"
+)
+
+// 검증자 등록 상태
+type ValidatorStatus string
+
+const (
+	ValidatorActive  ValidatorStatus = "active"  // 스테이킹 중 (제안자와 검증자 선출 대상)
+	ValidatorExited  ValidatorStatus = "exited"  // 언스테이킹으로 스테이킹 수량을 돌려받음
+	ValidatorSlashed ValidatorStatus = "slashed" // 이중 서명으로 스테이킹 수량을 몰수당함
+)
+
+const validatorTipName = "validators" // 검증자 등록부 색인 이름
+
+var (
+	ErrNotStaked     = errors.New("wallet has no active stake")
+	ErrAlreadyStaked = errors.New("wallet is already an active validator")
+)
+
+// 검증자 등록부의 기록. 스테이킹 트랜잭션이 만들고, 언스테이킹과 몰수 트랜잭션이 상태를 바꾼다 (스테이킹 출력마다 하나)
+type Validator struct {
+	Address    string          `json:"address"`              // 스테이커 주소
+	Stake      Amount          `json:"stake"`                // 스테이킹 수량
+	Port       string          `json:"port"`                 // 스테이커 노드 포트
+	Status     ValidatorStatus `json:"status"`               // 등록 상태
+	TxID       string          `json:"txId"`                 // 스테이킹 트랜잭션의 해시 값
+	Index      int             `json:"index"`                // 스테이킹 출력 인덱스
+	Height     int             `json:"height"`               // 등록된 블록 높이
+	Timestamp  int             `json:"timestamp"`            // 등록된 블록의 타임스탬프
+	ExitHeight int             `json:"exitHeight,omitempty"` // 언스테이킹 또는 몰수된 블록 높이
+}
+
+// 스테이킹 출력으로 만든 활성 상태의 등록 기록
+func newValidator(e *utxoEntry) *Validator {
+	return &Validator{
+		Address:   e.Stake.Staker,
+		Stake:     e.Output.Amount,
+		Port:      e.Stake.Port,
+		Status:    ValidatorActive,
+		TxID:      e.TxID,
+		Index:     e.Index,
+		Height:    e.Height,
+		Timestamp: e.Timestamp,
+	}
+}
+
+// 스테이킹 트랜잭션이 만든 출력인가
+func isStakeEntry(e *utxoEntry) bool {
+	return e.Kind == KindStake && e.Stake != nil && e.Output.Address == utils.StakingAddress
+}
+
+func saveValidator(batch *db.Batch, v *Validator) error {
+	data, err := utils.ToBytes(v)
+	if err != nil {
+		return err
+	}
+	batch.SaveValidator(outpoint(v.TxID, v.Index), data)
+	return nil
+}
+
+// 블록의 스테이킹, 언스테이킹, 몰수 트랜잭션을 검증자 등록부에 반영
+func stageValidators(batch *db.Batch, block *Block, spent [][]*utxoEntry) error {
+	for i, tx := range block.Transaction {
+		switch tx.Kind {
+		case KindStake:
+			for _, e := range blockUTXOs(block, tx) {
+				if isStakeEntry(e) {
+					if err := saveValidator(batch, newValidator(e)); err != nil {
+						return err
+					}
+				}
+			}
+		case KindUnstake, KindSlash:
+			if i >= len(spent) {
+				continue
+			}
+			for _, e := range spent[i] {
+				if !isStakeEntry(e) {
+					continue
+				}
+				v := newValidator(e)
+				v.Status, v.ExitHeight = ValidatorExited, block.Height
+				if tx.Kind == KindSlash {
+					v.Status = ValidatorSlashed
+				}
+				if err := saveValidator(batch, v); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// 블록을 되돌릴 때 트랜잭션 하나가 바꾼 등록 기록을 되돌림 (스테이킹은 기록 제거, 언스테이킹과 몰수는 활성 상태로 복구)
+func unstageValidators(batch *db.Batch, block *Block, tx *Tx, spent []*utxoEntry) error {
+	switch tx.Kind {
+	case KindStake:
+		for _, e := range blockUTXOs(block, tx) {
+			if isStakeEntry(e) {
+				batch.DeleteValidator(outpoint(e.TxID, e.Index))
+			}
+		}
+	case KindUnstake, KindSlash:
+		for _, e := range spent {
+			if isStakeEntry(e) {
+				if err := saveValidator(batch, newValidator(e)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// 검증자 등록부 전체 (등록 순)
+func Validators() ([]*Validator, error) {
+	var list []*Validator
+	for _, data := range dbStorage.Validators() {
+		v := &Validator{}
+		if err := utils.FromBytes(v, data); err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+	}
+	sortValidators(list)
+	return list, nil
+}
+
+// 등록 순 정렬 (등록된 블록 높이, 같은 높이라면 스테이킹 출력 순)
+func sortValidators(list []*Validator) {
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Height != list[j].Height {
+			return list[i].Height < list[j].Height
+		}
+		return outpoint(list[i].TxID, list[i].Index) < outpoint(list[j].TxID, list[j].Index)
+	})
+}
+
+// 활성 상태의 검증자 목록 (주소마다 하나, 처음 등록한 순서). 한 주소의 스테이킹이 여럿이라면 수량은 합치고 노드 포트는 마지막 등록을 따름
+func GetStakingList() ([]*Validator, error) {
+	list, err := Validators()
+	if err != nil {
+		return nil, err
+	}
+	return mergeStakes(list)
+}
+
+// 등록 순으로 정렬된 기록 중 활성 상태의 기록을 주소별로 합침
+func mergeStakes(list []*Validator) ([]*Validator, error) {
+	var active []*Validator
+	byAddress := make(map[string]*Validator)
+	for _, v := range list {
+		if v.Status != ValidatorActive {
+			continue
+		}
+		if merged, ok := byAddress[v.Address]; ok {
+			var err error
+			if merged.Stake, err = merged.Stake.Add(v.Stake); err != nil {
+				return nil, err
+			}
+			merged.Port = v.Port
+			continue
+		}
+		byAddress[v.Address] = v
+		active = append(active, v)
+	}
+	return active, nil
+}
+
+// 체인 상태 시점의 활성 검증자 목록. 사용되지 않은 스테이킹 출력이 곧 활성 등록 기록이므로, 디비의 등록부 없이 과거나 곁가지 상태에서도 계산할 수 있다
+func (s *chainState) stakers() ([]*Validator, error) {
+	var list []*Validator
+	for _, e := range s.utxos {
+		if e != nil && isStakeEntry(e) {
+			list = append(list, newValidator(e))
+		}
+	}
+	if s.base != nil { // 변경분에 없는 출력은 디비의 UTXO 셋에서 조회
+		for _, e := range utxosByAddress(utils.StakingAddress) {
+			if _, changed := s.utxos[outpoint(e.TxID, e.Index)]; !changed && isStakeEntry(e) {
+				list = append(list, newValidator(e))
+			}
+		}
+	}
+	sortValidators(list)
+	return mergeStakes(list)
+}
+
+// 주소의 활성 스테이킹 기록 중 멤풀에서 사용하려는 중이 아닌 첫 기록 (없으면 nil)
+func activeStakeOf(address string) (*Validator, error) {
+	list, err := Validators()
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range list {
+		if v.Address == address && v.Status == ValidatorActive && !isOnMempool(&UTxOut{TxID: v.TxID, Index: v.Index}) {
+			return v, nil
+		}
+	}
+	return nil, nil
+}
+
+// 노드 지갑의 스테이킹 출력을 지갑으로 돌려받는 언스테이킹 트랜잭션을 mempool에 추가 (수수료는 돌려받는 수량에서 차감)
+// 스테이킹 출력은 스테이커의 서명으로만 사용할 수 있으므로, 스테이킹 풀 주소의 키는 필요 없다
+func (m *mempool) AddUnstakeTx(fee Amount, port string) (*Tx, error) {
+	w := wallet.Wallet(port)
+	v, err := activeStakeOf(w.Address)
+	if err != nil {
+		return nil, reject(RejectInvalid, err)
+	}
+	if v == nil {
+		return nil, reject(RejectInvalid, ErrNotStaked)
+	}
+	if v.Stake <= fee {
+		return nil, reject(RejectInsufficientFunds, ErrorNoMoney)
+	}
+	payload := newPayload()
+	payload.Unstake = &UnstakePayload{StakeTxID: v.TxID, Index: v.Index}
+	tx := &Tx{
+		Timestamp: int(time.Now().Unix()),
+		TxIns:     []*TxIn{{TxID: v.TxID, Index: v.Index}},
+		TxOuts:    []*TxOut{{Address: w.Address, Amount: v.Stake - fee}},
+		InputData: "unstaking ordered",
+		Kind:      KindUnstake,
+		Payload:   payload,
+	}
+	tx.getId()
+	tx.sign(port)
+	if err := m.add(tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
@@ -0,0 +1,183 @@
+package blockchain
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/abcfe-op/abcfe-node/common/utils"
+)
+
+func TestMergeStakes(t *testing.T) {
+	record := func(address string, stake Amount, port string, status ValidatorStatus) *Validator {
+		return &Validator{Address: address, Stake: stake, Port: port, Status: status}
+	}
+	tests := []struct {
+		name string
+		list []*Validator
+		want []*Validator
+		err  error
+	}{
+		{
+			"one record per address in registration order",
+			[]*Validator{record("bb", 100, "4001", ValidatorActive), record("aa", 100, "4000", ValidatorActive)},
+			[]*Validator{record("bb", 100, "4001", ValidatorActive), record("aa", 100, "4000", ValidatorActive)},
+			nil,
+		},
+		{
+			"stakes of an address add up and the last port wins",
+			[]*Validator{record("aa", 100, "4000", ValidatorActive), record("bb", 100, "4001", ValidatorActive), record("aa", 100, "4005", ValidatorActive)},
+			[]*Validator{record("aa", 200, "4005", ValidatorActive), record("bb", 100, "4001", ValidatorActive)},
+			nil,
+		},
+		{
+			"exited and slashed records are left out",
+			[]*Validator{record("aa", 100, "4000", ValidatorExited), record("bb", 100, "4001", ValidatorSlashed), record("aa", 100, "4002", ValidatorActive)},
+			[]*Validator{record("aa", 100, "4002", ValidatorActive)},
+			nil,
+		},
+		{"empty registry", nil, nil, nil},
+		{
+			"overflowing stake",
+			[]*Validator{record("aa", math.MaxUint64, "4000", ValidatorActive), record("aa", 1, "4000", ValidatorActive)},
+			nil,
+			ErrAmountOverflow,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mergeStakes(tt.list)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("merged = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTxSender(t *testing.T) {
+	plain := &utxoEntry{Output: &TxOut{Address: "aa"}}
+	stake := &utxoEntry{Output: &TxOut{Address: utils.StakingAddress}, Kind: KindStake, Stake: &StakePayload{Staker: "bb"}}
+	tests := []struct {
+		name string
+		tx   *Tx
+		prev *utxoEntry
+		want string
+	}{
+		{"transfer", &Tx{Kind: KindTransfer}, plain, "aa"},
+		{"unstake", &Tx{Kind: KindUnstake}, stake, "bb"},
+		{"slash", &Tx{Kind: KindSlash, TxOuts: []*TxOut{{Address: "cc"}}}, stake, "cc"},
+		{"slash without outputs", &Tx{Kind: KindSlash}, stake, utils.StakingAddress},
+		{"unstake of a plain output", &Tx{Kind: KindUnstake}, plain, "aa"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := txSender(tt.tx, tt.prev); got != tt.want {
+				t.Fatalf("sender = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func assertActiveStakers(t *testing.T, bc *blockchain, want ...string) {
+	t.Helper()
+	list, err := GetStakingList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromState, err := bc.tipState().stakers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(list, fromState) {
+		t.Fatalf("registry %+v does not match the chain state %+v", list, fromState)
+	}
+	var got []string
+	for _, v := range list {
+		got = append(got, v.Address)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("active stakers = %v, want %v", got, want)
+	}
+}
+
+func TestRegistryFollowsStakeTransactions(t *testing.T) {
+	bc := newTestChain(t)
+	fund(t, bc)
+	fork := tipBlock(t, bc)
+	assertActiveStakers(t, bc)
+	if _, err := Mempool().AddUnstakeTx(MinTxFee, nodeKey.port); !errors.Is(err, ErrNotStaked) {
+		t.Fatalf("unstaking without a stake: err = %v, want %v", err, ErrNotStaked)
+	}
+
+	stake, err := Mempool().AddStakeTx(MinTxFee, nodeKey.port)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := mine(t, bc, fromMempool)
+	assertActiveStakers(t, bc, nodeKey.address)
+	v, err := activeStakeOf(nodeKey.address)
+	if err != nil || v == nil {
+		t.Fatalf("no active stake: %v", err)
+	}
+	want := Validator{Address: nodeKey.address, Stake: utils.StakingQuantity, Port: nodeKey.port, Status: ValidatorActive, TxID: stake.ID, Index: v.Index, Height: block.Height, Timestamp: block.Timestamp}
+	if *v != want {
+		t.Fatalf("registry record = %+v, want %+v", *v, want)
+	}
+	if _, err := Mempool().AddStakeTx(MinTxFee, nodeKey.port); !errors.Is(err, ErrAlreadyStaked) {
+		t.Fatalf("staking twice: err = %v, want %v", err, ErrAlreadyStaked)
+	}
+	if _, err := Mempool().AddSlashTx(doubleVote(nodeKey), nodeKey.port); !errors.Is(err, ErrInvalidEvidence) {
+		t.Fatalf("reporting its own double vote: err = %v, want %v", err, ErrInvalidEvidence)
+	}
+	if _, err := Mempool().AddUnstakeTx(MinTxFee, nodeKey.port); RejectReason(err) != RejectLocked {
+		t.Fatalf("unstaking within the lockup: err = %v, want a %q rejection", err, RejectLocked)
+	}
+
+	// 스테이킹 블록이 되돌려지면 등록 기록도 사라지고, 다시 담기면 새 블록 높이로 등록됨
+	side := certifiedBlock(t, fork, 1, coinbaseOnly)
+	if err := bc.ImportBlock(side); err != nil {
+		t.Fatal(err)
+	}
+	if err := bc.ImportBlock(certifiedBlock(t, side, 0, coinbaseOnly)); err != nil {
+		t.Fatal(err)
+	}
+	if findUTXO(stake.ID, v.Index) != nil {
+		t.Fatal("stake of the disconnected block is still in the utxo set")
+	}
+	assertActiveStakers(t, bc)
+	if list, _ := Validators(); len(list) != 0 {
+		t.Fatalf("registry still holds %+v", list)
+	}
+	block = mine(t, bc, fromMempool)
+	assertActiveStakers(t, bc, nodeKey.address)
+	if v, _ := activeStakeOf(nodeKey.address); v == nil || v.Height != block.Height {
+		t.Fatalf("restored stake is not registered at height %d", block.Height)
+	}
+}
+
+func TestSlashedValidatorLeavesTheRegistry(t *testing.T) {
+	bc := newTestChain(t)
+	fund(t, bc)
+	offender := testKeys[genesisValidators[1].Address]
+	stake := stakeFrom(t, bc, offender)
+	assertActiveStakers(t, bc, offender.address)
+
+	if _, err := Mempool().AddSlashTx(doubleVote(offender), nodeKey.port); err != nil {
+		t.Fatal(err)
+	}
+	block := mine(t, bc, fromMempool)
+	assertActiveStakers(t, bc)
+	list, err := Validators()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range list {
+		if v.TxID == stake.ID && (v.Status != ValidatorSlashed || v.ExitHeight != block.Height) {
+			t.Fatalf("slashed record = %+v, want status %q at height %d", v, ValidatorSlashed, block.Height)
+		}
+	}
+}
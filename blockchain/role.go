@@ -1,9 +1,11 @@
 package blockchain
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"math/rand"
-	"time"
+	"strconv"
 
 	"github.com/abcfe-op/abcfe-node/common/utils"
 
@@ -11,110 +13,242 @@ import (
 )
 
 var (
-	ResLeastStaker = "PoS requires at least 4 Stakers to run" // 스테이킹 노드가 최소 4개여야 한다는 응답
+	ResLeastStaker = "PoS requires at least %d Stakers to run" // 스테이킹 노드가 검증자 수보다 많아야 한다는 응답 (최소 스테이커 수를 채워 사용)
+)
+
+var (
+	ErrNoStake          = errors.New("no stake left to select from")
+	ErrNotEnoughStakers = errors.New("not enough stakers to elect roles")
+	ErrRoleInfoMismatch = errors.New("role info does not match the election result")
+	ErrUnknownHeight    = errors.New("roles can only be computed up to the next height")
 )
 
 const (
-	slotTime      = 12
 	Epoch         = 3 // 이더리움은 32개의 슬롯
 	genesisHeight = 1
 )
 
-// 검증자 선출
-func (r *RoleInfo) selectValidator(b *blockchain, stakingList []*StakingInfo) {
-	selectedNumbers := make(map[int]bool)
-	var result []int
+var committeeSize = 3 // 에포크마다 선출하는 검증자 수
 
-	for len(result) < 3 {
-		randNum := rand.Intn(len(stakingList))
-		if !selectedNumbers[randNum] { // 선택한 숫자가 아직 선택되지 않았다면
-			selectedNumbers[randNum] = true  // 선택한 숫자를 맵에 추가
-			result = append(result, randNum) // 결과 슬라이스에 추가
-		}
+// 검증자 수 설정 (노드 시작 시 호출). 네트워크의 모든 노드가 같은 값을 써야 하며, 0 이하의 값과
+// 제네시스 검증자만으로 검증자와 제안자를 모두 뽑을 수 없는 값은 무시하고 기본값을 유지
+func SetCommitteeSize(size int) {
+	if size > 0 && size < len(genesisValidators) {
+		committeeSize = size
 	}
+}
 
-	for _, num := range result {
-		selected := stakingList[num]
-		r.ValidatorAddress = append(r.ValidatorAddress, selected.Address)
-		r.ValidatorPort = append(r.ValidatorPort, selected.Port)
+// 선출 후보: 스테이커가 검증자 수보다 많다면 스테이커, 그렇지 않다면 제네시스 검증자
+func electionCandidates(stakingList []*Validator) []*Validator {
+	if len(stakingList) > committeeSize {
+		return stakingList
 	}
-
-	r.ValidatorSelectedHeight = b.Height + 1 // b.Height는 현재 높이이고, 이제 추가할 블록의 높이는 +1로 해야함
+	return genesisValidators
 }
 
-// 제안자 선출
-func (r *RoleInfo) selectProposer(b *blockchain, stakingList []*StakingInfo) {
-	var selected *StakingInfo
-	for {
-		check := 0
-		randNum := rand.Intn(len(stakingList))
-		selected = stakingList[randNum]
-		for _, validatorAddress := range r.ValidatorAddress {
-			if selected.Address != validatorAddress {
-				check++
-			} else {
-				break
-			}
+// 스테이킹 수량에 비례하는 확률로 후보 하나를 뽑아 인덱스를 반환 (excluded에 있는 주소는 후보에서 제외)
+func pickByStake(rng *rand.Rand, stakingList []*Validator, excluded map[string]bool) (int, error) {
+	var total Amount
+	for _, v := range stakingList {
+		if excluded[v.Address] {
+			continue
 		}
-		if check == 3 {
-			break
+		sum, err := total.Add(v.Stake)
+		if err != nil {
+			return 0, err
 		}
+		total = sum
+	}
+	if total == 0 {
+		return 0, ErrNoStake
+	}
+	limit := math.MaxUint64 - math.MaxUint64%uint64(total) // 나머지 연산의 편향을 없애기 위해 범위를 total의 배수로 자름
+	n := rng.Uint64()
+	for n >= limit {
+		n = rng.Uint64()
 	}
-	r.ProposerAddress = selected.Address
-	r.ProposerPort = selected.Port
-	r.ProposerSelectedHeight = b.Height + 1 // b.Height는 현재 높이이고, 이제 추가할 블록의 높이는 +1로 해야함
+	n %= uint64(total)
+	for i, v := range stakingList {
+		if excluded[v.Address] {
+			continue
+		}
+		if n < uint64(v.Stake) {
+			return i, nil
+		}
+		n -= uint64(v.Stake)
+	}
+	return 0, ErrNoStake
 }
 
-// 지정된 슬롯과 에포크에 따른 검증자와 제안자 선출
-func (b *blockchain) Selector() (*RoleInfo, string) {
-	r := &RoleInfo{}
+// 검증자 선출: 스테이킹 수량에 비례하는 확률로 committeeSize 명을 중복 없이 뽑음
+func (r *RoleInfo) selectValidator(height int, stakingList []*Validator, rng *rand.Rand) error {
+	selected := make(map[string]bool)
+	for len(r.ValidatorAddress) < committeeSize {
+		i, err := pickByStake(rng, stakingList, selected)
+		if err != nil {
+			return err
+		}
+		selected[stakingList[i].Address] = true
+		r.ValidatorAddress = append(r.ValidatorAddress, stakingList[i].Address)
+		r.ValidatorPort = append(r.ValidatorPort, stakingList[i].Port)
+	}
+
+	r.ValidatorSelectedHeight = height
+	return nil
+}
 
-	_, stakingWalletTx, _ := UTxOutsByStakingAddress(utils.StakingAddress, b)
-	stakingInfoList := GetStakingList(stakingWalletTx, b)
+// 제안자 선출: 검증자가 아닌 스테이커 중에서 스테이킹 수량에 비례하는 확률로 뽑음
+func (r *RoleInfo) selectProposer(height int, stakingList []*Validator, rng *rand.Rand) error {
+	excluded := make(map[string]bool)
+	for _, address := range r.ValidatorAddress {
+		excluded[address] = true
+	}
+	i, err := pickByStake(rng, stakingList, excluded)
+	if err != nil {
+		return err
+	}
+	r.ProposerAddress = stakingList[i].Address
+	r.ProposerPort = stakingList[i].Port
+	r.ProposerSelectedHeight = height
+	return nil
+}
 
-	if len(stakingInfoList) <= 3 {
-		fmt.Println(ResLeastStaker)
-		time.Sleep(slotTime * time.Second)
-		return nil, ResLeastStaker
+// 합의 라운드의 역할 정보. 라운드 0은 선출된 제안자가 제안하고, 제안이 실패한 이후 라운드는 검증자가 돌아가며 제안한다
+func (r *RoleInfo) ForRound(round int) *RoleInfo {
+	if round == 0 || len(r.ValidatorAddress) == 0 {
+		return r
 	}
+	i := (round - 1) % len(r.ValidatorAddress)
+	roles := *r
+	roles.ProposerAddress, roles.ProposerPort = r.ValidatorAddress[i], r.ValidatorPort[i]
+	return &roles
+}
+
+// 선출 시드: 이전 블록의 무작위 값(RandaoMix)과 높이로 정해지므로, 이전 블록을 아는 노드라면 누구나 같은 값을 계산할 수 있다 (시드를 정한 math/rand 수열은 Go 버전과 무관하게 같음)
+func electionSeed(mix string, height int) int64 {
+	e := &canonicalEncoder{}
+	e.writeString("election")
+	e.writeString(mix)
+	e.writeInt(height)
+	seed, _ := strconv.ParseUint(utils.HashBytes(e.bytes())[:16], 16, 64)
+	return int64(seed)
+}
 
-	if b.Height%Epoch == 0 {
-		r.selectValidator(b, stakingInfoList)
-		r.selectProposer(b, stakingInfoList)
+// 높이가 속한 에포크의 첫 높이 (제네시스 블록부터 Epoch 개의 블록마다 새 에포크)
+func epochStart(height int) int {
+	return height - (height-genesisHeight)%Epoch
+}
+
+// prev부터 조상 방향으로 따라가 height가 속한 에포크의 첫 블록 조회 (곁가지라면 같은 가지의 블록)
+// 에포크의 첫 블록은 임포트할 때 역할 정보를 선출 결과와 비교했으므로, 그 검증자는 에포크 동안 그대로 쓸 수 있다
+func epochStartBlock(prev *Block, height int) (*Block, error) {
+	start := epochStart(height)
+	block := prev
+	for block.Height > start {
+		parent, err := FindBlock(block.PrevHash)
+		if err != nil {
+			return nil, err
+		}
+		block = parent
+	}
+	return block, nil
+}
+
+// 이전 블록과 그 시점의 스테이커 목록으로 다음 높이의 역할 정보를 결정적으로 계산 (후보는 electionCandidates)
+// 에포크가 시작되는 높이에서는 검증자를 새로 뽑고, 그 외에는 에포크 첫 블록의 검증자를 이어받은 뒤 제안자만 뽑는다
+func elect(prev *Block, stakingList []*Validator) (*RoleInfo, error) {
+	stakingList = electionCandidates(stakingList)
+	if len(stakingList) <= committeeSize {
+		return nil, fmt.Errorf("%w: %d of %d", ErrNotEnoughStakers, len(stakingList), committeeSize+1)
+	}
+	height := prev.Height + 1
+	rng := rand.New(rand.NewSource(electionSeed(prev.RandaoMix, height)))
+	r := &RoleInfo{}
+	if height == epochStart(height) {
+		if err := r.selectValidator(height, stakingList, rng); err != nil {
+			return nil, err
+		}
 	} else {
-		block, _ := FindBlock(b.NewestHash)
-		r.ValidatorSelectedHeight = block.RoleInfo.ValidatorSelectedHeight
-		r.ValidatorAddress = block.RoleInfo.ValidatorAddress
-		r.ValidatorPort = block.RoleInfo.ValidatorPort
-		r.selectProposer(b, stakingInfoList)
+		start, err := epochStartBlock(prev, height)
+		if err != nil {
+			return nil, err
+		}
+		r.ValidatorSelectedHeight = start.RoleInfo.ValidatorSelectedHeight
+		r.ValidatorAddress = start.RoleInfo.ValidatorAddress
+		r.ValidatorPort = start.RoleInfo.ValidatorPort
+	}
+	if err := r.selectProposer(height, stakingList, rng); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// 높이의 역할 정보 (라운드 0 기준). 메인 체인의 과거 높이부터 다음 블록의 높이까지 계산할 수 있다
+func (b *blockchain) Selector(height int) (*RoleInfo, string) {
+	b.m.Lock()
+	r, err := b.roles(height)
+	b.m.Unlock()
+	if errors.Is(err, ErrNotEnoughStakers) {
+		msg := fmt.Sprintf(ResLeastStaker, committeeSize+1)
+		log.Warn(msg)
+		return nil, msg
+	}
+	if err != nil {
+		log.Error(err)
+		return nil, err.Error()
 	}
 
 	str, err := utils.ToString(r)
 	if err != nil {
 		log.Error(err)
 	}
-	fmt.Printf("Seleted Roles for the next block:\n%s\n", str)
+	log.Debug(fmt.Sprintf("Selected Roles for height %d:\n%s", height, str))
 
 	return r, ""
 }
 
-// 검증자들의 검증 결과 과반수 계산
-func CalculateMajority(v []*ValidatedInfo) bool {
-	pass := 0
-	fail := 0
-	for _, r := range v {
-		if r.Result {
-			pass++
-		} else {
-			fail++
-		}
+// 이전 높이까지의 체인 상태로 높이의 역할 정보 계산 (호출하는 쪽에서 b.m을 잠가야 함)
+func (b *blockchain) roles(height int) (*RoleInfo, error) {
+	if height <= genesisHeight || height > b.Height+1 {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownHeight, height)
+	}
+	s, err := b.stateAt(height - 1)
+	if err != nil {
+		return nil, err
+	}
+	prev, err := FindBlock(s.hash)
+	if err != nil {
+		return nil, err
 	}
-	fmt.Printf("PASS: %d \nFAIL: %d\n", pass, fail)
-	return pass > fail
+	stakingList, err := s.stakers()
+	if err != nil {
+		return nil, err
+	}
+	return elect(prev, stakingList)
+}
+
+// 역할 정보 검증: 이전 블록과 체인 상태로 계산한 선출 결과 중 블록이 제안(커밋)된 라운드의 역할 정보와 같아야 함 (선출할 수 없다면 거절)
+func validateRoleInfo(block *Block, state *chainState, round int) error {
+	prev, err := FindBlock(block.PrevHash)
+	if err != nil {
+		return err
+	}
+	stakingList, err := state.stakers()
+	if err != nil {
+		return err
+	}
+	expected, err := elect(prev, stakingList)
+	if err != nil {
+		return err
+	}
+	if !CompareRoleInfo(block.RoleInfo, expected.ForRound(round)) {
+		return fmt.Errorf("%w: %s in round %d", ErrRoleInfoMismatch, block.Hash, round)
+	}
+	return nil
 }
 
 // 검증 중 RoleInfo 내용 비교
-func compareRoleInfo(r1, r2 *RoleInfo) bool {
+func CompareRoleInfo(r1, r2 *RoleInfo) bool {
 	return r1.ProposerAddress == r2.ProposerAddress &&
 		r1.ProposerPort == r2.ProposerPort &&
 		r1.ProposerSelectedHeight == r2.ProposerSelectedHeight &&
@@ -122,14 +256,3 @@ func compareRoleInfo(r1, r2 *RoleInfo) bool {
 		utils.CompareStringSlices(r1.ValidatorPort, r2.ValidatorPort) &&
 		r1.ValidatorSelectedHeight == r2.ValidatorSelectedHeight
 }
-
-// 블록 제안 성공 유무
-func (b *blockchain) CheckProposalSuccess(lastHeight int) {
-	if b.Height == lastHeight {
-		fmt.Println("Proposal Rejected.")
-	} else if b.Height-lastHeight == 1 {
-		fmt.Println("Added and broadcasted the block done.")
-	} else {
-		fmt.Println("Warning: Block Height was twisted.")
-	}
-}
@@ -0,0 +1,240 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/abcfe-op/abcfe-node/common/utils"
+)
+
+// 주어진 스테이킹 수량을 가진 스테이커 목록 (주소는 s0, s1, ...)
+func stakers(stakes ...Amount) []*Validator {
+	list := make([]*Validator, len(stakes))
+	for i, stake := range stakes {
+		list[i] = &Validator{Address: fmt.Sprintf("s%d", i), Port: fmt.Sprintf("%d", 5000+i), Stake: stake, Status: ValidatorActive}
+	}
+	return list
+}
+
+func TestSetCommitteeSize(t *testing.T) {
+	withCommitteeSize(t, 3)
+	tests := []struct {
+		size int
+		want int
+	}{
+		{5, 5},
+		{1, 1},
+		{0, 3},
+		{-1, 3},
+		{len(genesisValidators) - 1, len(genesisValidators) - 1},
+		{len(genesisValidators), 3}, // 제네시스 검증자만으로 제안자까지 뽑을 수 없음
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.size), func(t *testing.T) {
+			committeeSize = 3
+			SetCommitteeSize(tt.size)
+			if committeeSize != tt.want {
+				t.Fatalf("committee size = %d, want %d", committeeSize, tt.want)
+			}
+		})
+	}
+}
+
+func TestElectionCandidates(t *testing.T) {
+	withCommitteeSize(t, 3)
+	if got := electionCandidates(stakers(1, 1, 1)); len(got) != len(genesisValidators) || got[0] != genesisValidators[0] {
+		t.Fatal("too few stakers did not fall back to the genesis validators")
+	}
+	if got := electionCandidates(stakers(1, 1, 1, 1)); len(got) != 4 || got[0].Address != "s0" {
+		t.Fatal("enough stakers were not used as candidates")
+	}
+}
+
+func TestPickByStake(t *testing.T) {
+	tests := []struct {
+		name     string
+		list     []*Validator
+		excluded map[string]bool
+		want     []float64 // 후보별 기대 비율
+		err      error
+	}{
+		{"equal stakes", stakers(1, 1), nil, []float64{0.5, 0.5}, nil},
+		{"proportional to stake", stakers(1, 3), nil, []float64{0.25, 0.75}, nil},
+		{"excluded candidate", stakers(1, 3, 4), map[string]bool{"s2": true}, []float64{0.25, 0.75, 0}, nil},
+		{"zero stake is never picked", stakers(0, 2), nil, []float64{0, 1}, nil},
+		{"everyone excluded", stakers(1, 1), map[string]bool{"s0": true, "s1": true}, nil, ErrNoStake},
+		{"no stake", stakers(0, 0), nil, nil, ErrNoStake},
+		{"overflowing stakes", stakers(math.MaxUint64, 1), nil, nil, ErrAmountOverflow},
+	}
+	const draws = 20000
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1))
+			counts := make([]int, len(tt.list))
+			for n := 0; n < draws; n++ {
+				i, err := pickByStake(rng, tt.list, tt.excluded)
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("err = %v, want %v", err, tt.err)
+				}
+				if err != nil {
+					return
+				}
+				counts[i]++
+			}
+			for i, want := range tt.want {
+				if got := float64(counts[i]) / draws; math.Abs(got-want) > 0.02 {
+					t.Fatalf("candidate %d picked %.3f of the time, want %.2f", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestElectCommitteeSize(t *testing.T) {
+	list := stakers(1, 2, 3, 4, 5, 6, 7)
+	prev := &Block{BlockHeader: BlockHeader{Height: genesisHeight + Epoch - 1, RandaoMix: "aa"}} // 다음 높이에서 에포크 시작
+	for size := 1; size < len(list); size++ {
+		t.Run(fmt.Sprint(size), func(t *testing.T) {
+			withCommitteeSize(t, size)
+			roles, err := elect(prev, list)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(roles.ValidatorAddress) != size || len(roles.ValidatorPort) != size {
+				t.Fatalf("committee of %d, want %d", len(roles.ValidatorAddress), size)
+			}
+			seen := map[string]bool{roles.ProposerAddress: true}
+			for _, address := range roles.ValidatorAddress {
+				if seen[address] {
+					t.Fatalf("%s holds two roles", address)
+				}
+				seen[address] = true
+			}
+			outs, err := coinbaseOutputs(roles, 3)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(outs) != size+1 || outs[0].Amount != proposalReward+3 || outs[size].Amount != validatorReward {
+				t.Fatalf("coinbase pays %d outputs, want the proposer and %d validators", len(outs), size)
+			}
+		})
+	}
+}
+
+func TestElectNeedsMoreCandidatesThanSeats(t *testing.T) {
+	withCommitteeSize(t, len(genesisValidators))
+	prev := &Block{BlockHeader: BlockHeader{Height: genesisHeight + Epoch - 1}}
+	if _, err := elect(prev, nil); !errors.Is(err, ErrNotEnoughStakers) {
+		t.Fatalf("err = %v, want %v", err, ErrNotEnoughStakers)
+	}
+	committeeSize = 3
+	if _, err := elect(prev, stakers(1, 1, 1, 1)); err != nil {
+		t.Fatalf("four stakers for three seats: %v", err)
+	}
+}
+
+func TestLargerCommitteeOnChain(t *testing.T) {
+	withCommitteeSize(t, 5)
+	bc := newTestChain(t)
+	for i := 0; i < Epoch+1; i++ {
+		block := mine(t, bc, coinbaseOnly)
+		if n := len(block.RoleInfo.ValidatorAddress); n != 5 {
+			t.Fatalf("block %d has %d validators, want 5", block.Height, n)
+		}
+		coinbase := block.Transaction[len(block.Transaction)-1]
+		if len(coinbase.TxOuts) != 6 {
+			t.Fatalf("coinbase of block %d pays %d outputs, want 6", block.Height, len(coinbase.TxOuts))
+		}
+	}
+}
+
+func TestElectionSeed(t *testing.T) {
+	seed := electionSeed("aa", 4)
+	if electionSeed("aa", 4) != seed {
+		t.Fatal("election seed is not deterministic")
+	}
+	if electionSeed("ab", 4) == seed || electionSeed("aa", 5) == seed {
+		t.Fatal("election seed ignores the randao mix or the height")
+	}
+}
+
+func TestElectIsDeterministic(t *testing.T) {
+	withCommitteeSize(t, 3)
+	list := stakers(1, 2, 3, 4, 5, 6, 7)
+	prev := &Block{BlockHeader: BlockHeader{Height: genesisHeight + Epoch - 1, RandaoMix: "aa"}}
+	want, err := elect(prev, list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		if got, _ := elect(prev, list); !CompareRoleInfo(got, want) {
+			t.Fatalf("election %d = %+v, want %+v", i, got, want)
+		}
+	}
+	differs := false
+	for i := 0; i < 10 && !differs; i++ {
+		other := &Block{BlockHeader: BlockHeader{Height: prev.Height, RandaoMix: fmt.Sprintf("b%d", i)}}
+		got, err := elect(other, list)
+		if err != nil {
+			t.Fatal(err)
+		}
+		differs = !CompareRoleInfo(got, want)
+	}
+	if !differs {
+		t.Fatal("election does not depend on the randao mix")
+	}
+}
+
+func TestEpochStart(t *testing.T) {
+	tests := []struct {
+		height, want int
+	}{
+		{genesisHeight, genesisHeight},
+		{genesisHeight + 1, genesisHeight},
+		{genesisHeight + Epoch - 1, genesisHeight},
+		{genesisHeight + Epoch, genesisHeight + Epoch},
+		{genesisHeight + 2*Epoch - 1, genesisHeight + Epoch},
+		{genesisHeight + 2*Epoch, genesisHeight + 2*Epoch},
+	}
+	for _, tt := range tests {
+		if got := epochStart(tt.height); got != tt.want {
+			t.Fatalf("epochStart(%d) = %d, want %d", tt.height, got, tt.want)
+		}
+	}
+}
+
+// 어느 노드든 체인 데이터만으로 각 높이의 역할 정보를 다시 계산할 수 있고, 검증자는 에포크 동안 유지됨
+func TestSelectorRecomputesRoles(t *testing.T) {
+	bc := newTestChain(t)
+	var blocks []*Block
+	for i := 0; i < 2*Epoch; i++ {
+		blocks = append(blocks, mine(t, bc, coinbaseOnly))
+	}
+	for _, block := range blocks {
+		roles, msg := bc.Selector(block.Height)
+		if msg != "" || !CompareRoleInfo(roles, block.RoleInfo) {
+			t.Fatalf("roles of height %d = %+v (%s), want %+v", block.Height, roles, msg, block.RoleInfo)
+		}
+		start, err := epochStartBlock(block, block.Height)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if start.Height != epochStart(block.Height) {
+			t.Fatalf("epoch start of height %d = %d, want %d", block.Height, start.Height, epochStart(block.Height))
+		}
+		if !utils.CompareStringSlices(block.RoleInfo.ValidatorAddress, start.RoleInfo.ValidatorAddress) ||
+			block.RoleInfo.ValidatorSelectedHeight != start.Height {
+			t.Fatalf("block %d does not keep the committee of epoch start %d", block.Height, start.Height)
+		}
+	}
+	next, msg := bc.Selector(bc.Height + 1)
+	if msg != "" || next == nil {
+		t.Fatalf("roles of the next height: %s", msg)
+	}
+	if _, msg := bc.Selector(bc.Height + 2); msg == "" {
+		t.Fatal("roles beyond the next height were computed")
+	}
+}
@@ -0,0 +1,578 @@
+package blockchain
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/abcfe-op/abcfe-node/common/utils"
+	"github.com/abcfe-op/abcfe-node/wallet"
+)
+
+// 출력 잠금 스크립트
+// 공백으로 구분된 토큰을 왼쪽부터 차례로 실행하는 스택 언어. OP_로 시작하지 않는 토큰은 그대로 스택에 쌓는 데이터이고,
+// 입력의 증인(witness) 값들을 스택에 먼저 쌓은 뒤 출력의 잠금 스크립트를 실행하여 스택 맨 위 값이 참이면 사용을 허용한다.
+// 빈 문자열과 "0"은 거짓, 그 외의 값은 참으로 본다.
+
+const (
+	maxScriptTokens  = 201  // 잠금 스크립트의 최대 토큰 수
+	maxScriptItemLen = 520  // 스택에 쌓을 수 있는 값의 최대 길이
+	maxStackItems    = 100  // 스택의 최대 깊이
+	maxWitnessItems  = 20   // 입력 하나의 최대 증인 값 수 (다중서명 키 수 + 여유)
+	maxScriptCost    = 1000 // 스크립트 한 번 실행에 허용되는 최대 비용 (증인 값을 쌓는 비용 포함)
+	maxTxScriptCost  = 4000 // 트랜잭션 하나가 모든 입력의 스크립트 실행에 쓸 수 있는 최대 비용
+	sigOpCost        = 50   // 서명 검증 한 번의 비용
+	hashOpCost       = 10   // 해시 한 번의 비용
+	scriptTrue       = "1"
+	scriptFalse      = ""
+)
+
+var (
+	ErrInvalidScript      = errors.New("invalid script")
+	ErrScriptAddress      = errors.New("address does not match the locking script")
+	ErrScriptFailed       = errors.New("script evaluated to false")
+	ErrScriptVerify       = errors.New("script verify failed")
+	ErrScriptCost         = errors.New("script exceeds the cost limit")
+	ErrScriptStack        = errors.New("script stack error")
+	ErrScriptUnbalanced   = errors.New("unbalanced conditional in script")
+	ErrScriptReturn       = errors.New("script returned early")
+	ErrScriptNoInput      = errors.New("output is not locked by a script")
+	ErrUnknownTemplate    = errors.New("unknown script template")
+	ErrScriptWithMultisig = errors.New("script output cannot also have a multisig lock")
+)
+
+// 실행 중인 스크립트의 상태
+type scriptVM struct {
+	stack   []string
+	conds   []bool      // 실행 중인 OP_IF 분기들의 조건
+	payload string      // 서명 대상 (사용하는 트랜잭션의 해시 값)
+	entry   *utxoEntry  // 잠긴 출력 (상대 시간 잠금 판단에 사용, 없을 수 있음)
+	at      lockContext // 트랜잭션이 포함될 블록의 높이와 중간 시간
+	cost    int
+}
+
+type scriptOp struct {
+	cost int
+	run  func(vm *scriptVM) error
+}
+
+// 연산자 목록 (조건 분기 연산자는 실행 여부와 상관없이 처리하므로 따로 다룬다)
+var scriptOps = map[string]scriptOp{
+	"OP_0":                   {1, func(vm *scriptVM) error { return vm.push(scriptFalse) }},
+	"OP_1":                   {1, func(vm *scriptVM) error { return vm.push(scriptTrue) }},
+	"OP_DUP":                 {1, opDup},
+	"OP_DROP":                {1, func(vm *scriptVM) error { _, err := vm.pop(); return err }},
+	"OP_SWAP":                {1, opSwap},
+	"OP_EQUAL":               {1, opEqual},
+	"OP_EQUALVERIFY":         {1, verified(opEqual)},
+	"OP_VERIFY":              {1, opVerify},
+	"OP_RETURN":              {1, func(vm *scriptVM) error { return ErrScriptReturn }},
+	"OP_SIZE":                {1, opSize},
+	"OP_SHA256":              {hashOpCost, opSha256},
+	"OP_SHA256HEX":           {hashOpCost, opSha256Hex},
+	"OP_CHECKSIG":            {sigOpCost, opCheckSig},
+	"OP_CHECKSIGVERIFY":      {sigOpCost, verified(opCheckSig)},
+	"OP_CHECKMULTISIG":       {sigOpCost, opCheckMultisig}, // 공개키 수에 비례한 비용은 실행 중에 추가
+	"OP_CHECKLOCKTIMEVERIFY": {1, opCheckLockTime},
+	"OP_CHECKAGEVERIFY":      {1, opCheckAge},
+}
+
+// 스크립트 실행 과정의 한 단계 (디버깅용)
+type ScriptStep struct {
+	Op       string   `json:"op"`
+	Executed bool     `json:"executed"` // 실행되지 않은 분기의 토큰이면 false
+	Cost     int      `json:"cost"`     // 이 단계까지의 누적 비용
+	Stack    []string `json:"stack"`    // 이 단계 실행 후의 스택 (맨 뒤가 맨 위)
+}
+
+// 스크립트 실행 추적 결과
+type ScriptTrace struct {
+	Script  string        `json:"script"`
+	Witness []string      `json:"witness"`
+	Steps   []*ScriptStep `json:"steps"`
+	Cost    int           `json:"cost"`
+	Success bool          `json:"success"`
+	Error   string        `json:"error,omitempty"`
+}
+
+// 스크립트 템플릿 요청 (Type: p2pk, p2pkh, multisig, timelock, hashlock, htlc)
+// htlc는 PubKeys에 받는 쪽, 보낸 쪽 순서로 공개키를 넣고 LockTime을 기한으로 사용
+type ScriptTemplate struct {
+	Type     string   `json:"type"`
+	PubKeys  []string `json:"pubKeys"`
+	M        int      `json:"m,omitempty"`        // multisig
+	LockTime int      `json:"lockTime,omitempty"` // timelock (블록 높이 또는 유닉스 시간)
+	Hash     string   `json:"hash,omitempty"`     // hashlock (SHA-256 해시의 16진수 문자열)
+}
+
+// 잠금 스크립트 토큰 분리
+func scriptTokens(script string) []string {
+	return strings.Fields(script)
+}
+
+// 잠금 스크립트로 만든 주소 (공백 차이는 같은 스크립트로 취급)
+func ScriptAddress(script string) string {
+	e := &canonicalEncoder{}
+	e.writeString("script")
+	e.writeStrings(scriptTokens(script))
+	return utils.HashBytes(e.bytes())
+}
+
+// 잠금 스크립트의 형식 검증 (토큰 수, 데이터 길이, 알 수 없는 연산자)
+func validateScript(script string) error {
+	tokens := scriptTokens(script)
+	if len(tokens) == 0 || len(tokens) > maxScriptTokens {
+		return fmt.Errorf("%w: %d tokens", ErrInvalidScript, len(tokens))
+	}
+	for _, token := range tokens {
+		if len(token) > maxScriptItemLen {
+			return fmt.Errorf("%w: data too long", ErrInvalidScript)
+		}
+		if isOpcode(token) && !isKnownOpcode(token) {
+			return fmt.Errorf("%w: unknown opcode %s", ErrInvalidScript, token)
+		}
+	}
+	return nil
+}
+
+func isOpcode(token string) bool {
+	return strings.HasPrefix(token, "OP_")
+}
+
+func isKnownOpcode(token string) bool {
+	switch token {
+	case "OP_IF", "OP_NOTIF", "OP_ELSE", "OP_ENDIF":
+		return true
+	}
+	_, ok := scriptOps[token]
+	return ok
+}
+
+// 증인 값의 형식 검증 (값의 수와 길이). 스크립트로 잠기지 않은 입력의 증인도 트랜잭션 크기를 늘리므로 함께 검사한다
+func validateWitness(witness []string) error {
+	if len(witness) > maxWitnessItems {
+		return fmt.Errorf("%w: %d witness items", ErrInvalidScript, len(witness))
+	}
+	for _, item := range witness {
+		if len(item) > maxScriptItemLen {
+			return fmt.Errorf("%w: witness item too long", ErrInvalidScript)
+		}
+	}
+	return nil
+}
+
+// 증인 값을 스택에 쌓고 잠금 스크립트를 실행한 뒤, 실패하더라도 그때까지 쓴 비용을 반환 (trace가 nil이 아니면 실행 과정을 기록)
+func runScript(script string, witness []string, payload string, entry *utxoEntry, at lockContext, trace *ScriptTrace) (int, error) {
+	if err := validateScript(script); err != nil {
+		return 0, err
+	}
+	if err := validateWitness(witness); err != nil {
+		return 0, err
+	}
+	vm := &scriptVM{payload: payload, entry: entry, at: at}
+	for _, item := range witness { // 스크립트의 데이터 토큰과 같은 비용
+		if err := vm.charge(1); err != nil {
+			return vm.cost, err
+		}
+		if err := vm.push(item); err != nil {
+			return vm.cost, err
+		}
+	}
+	for _, token := range scriptTokens(script) {
+		executed := vm.executing()
+		if err := vm.step(token, executed); err != nil {
+			trace.record(token, executed, vm)
+			return vm.cost, err
+		}
+		trace.record(token, executed, vm)
+	}
+	if len(vm.conds) != 0 {
+		return vm.cost, ErrScriptUnbalanced
+	}
+	top, err := vm.pop()
+	if err != nil {
+		return vm.cost, err
+	}
+	if !truthy(top) {
+		return vm.cost, ErrScriptFailed
+	}
+	return vm.cost, nil
+}
+
+// 토큰 하나 실행
+func (vm *scriptVM) step(token string, executed bool) error {
+	switch token {
+	case "OP_IF", "OP_NOTIF":
+		cond := false
+		if executed {
+			top, err := vm.pop()
+			if err != nil {
+				return err
+			}
+			cond = truthy(top) == (token == "OP_IF")
+		}
+		vm.conds = append(vm.conds, cond)
+		return vm.charge(1)
+	case "OP_ELSE":
+		if len(vm.conds) == 0 {
+			return ErrScriptUnbalanced
+		}
+		vm.conds[len(vm.conds)-1] = !vm.conds[len(vm.conds)-1]
+		return vm.charge(1)
+	case "OP_ENDIF":
+		if len(vm.conds) == 0 {
+			return ErrScriptUnbalanced
+		}
+		vm.conds = vm.conds[:len(vm.conds)-1]
+		return vm.charge(1)
+	}
+	if !executed {
+		return nil
+	}
+	if !isOpcode(token) {
+		if err := vm.charge(1); err != nil {
+			return err
+		}
+		return vm.push(token)
+	}
+	op, ok := scriptOps[token]
+	if !ok {
+		return fmt.Errorf("%w: unknown opcode %s", ErrInvalidScript, token)
+	}
+	if err := vm.charge(op.cost); err != nil {
+		return err
+	}
+	return op.run(vm)
+}
+
+// 바깥쪽 분기들이 모두 참일 때만 실행 (OP_ELSE는 안쪽 분기만 뒤집으므로, 바깥쪽이 거짓이면 계속 실행하지 않음)
+func (vm *scriptVM) executing() bool {
+	for _, cond := range vm.conds {
+		if !cond {
+			return false
+		}
+	}
+	return true
+}
+
+func (vm *scriptVM) charge(cost int) error {
+	vm.cost += cost
+	if vm.cost > maxScriptCost {
+		return fmt.Errorf("%w: %d > %d", ErrScriptCost, vm.cost, maxScriptCost)
+	}
+	return nil
+}
+
+func (vm *scriptVM) push(item string) error {
+	if len(item) > maxScriptItemLen {
+		return fmt.Errorf("%w: item too long", ErrScriptStack)
+	}
+	if len(vm.stack) >= maxStackItems {
+		return fmt.Errorf("%w: stack overflow", ErrScriptStack)
+	}
+	vm.stack = append(vm.stack, item)
+	return nil
+}
+
+func (vm *scriptVM) pop() (string, error) {
+	if len(vm.stack) == 0 {
+		return "", fmt.Errorf("%w: stack underflow", ErrScriptStack)
+	}
+	item := vm.stack[len(vm.stack)-1]
+	vm.stack = vm.stack[:len(vm.stack)-1]
+	return item, nil
+}
+
+// 음이 아닌 정수 값 꺼내기
+func (vm *scriptVM) popInt() (int, error) {
+	item, err := vm.pop()
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(item)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%w: not a number %q", ErrScriptStack, item)
+	}
+	return n, nil
+}
+
+// 스택을 바꾸지 않고 맨 위의 정수 값 읽기
+func (vm *scriptVM) peekInt() (int, error) {
+	n, err := vm.popInt()
+	if err != nil {
+		return 0, err
+	}
+	vm.stack = append(vm.stack, strconv.Itoa(n))
+	return n, nil
+}
+
+func truthy(item string) bool {
+	return item != "" && item != "0"
+}
+
+func boolItem(v bool) string {
+	if v {
+		return scriptTrue
+	}
+	return scriptFalse
+}
+
+// 연산 결과가 참이 아니면 실패하는 *VERIFY 연산자 생성
+func verified(run func(vm *scriptVM) error) func(vm *scriptVM) error {
+	return func(vm *scriptVM) error {
+		if err := run(vm); err != nil {
+			return err
+		}
+		return opVerify(vm)
+	}
+}
+
+func opDup(vm *scriptVM) error {
+	top, err := vm.pop()
+	if err != nil {
+		return err
+	}
+	vm.stack = append(vm.stack, top)
+	return vm.push(top)
+}
+
+func opSwap(vm *scriptVM) error {
+	a, err := vm.pop()
+	if err != nil {
+		return err
+	}
+	b, err := vm.pop()
+	if err != nil {
+		return err
+	}
+	vm.stack = append(vm.stack, a, b)
+	return nil
+}
+
+func opEqual(vm *scriptVM) error {
+	a, err := vm.pop()
+	if err != nil {
+		return err
+	}
+	b, err := vm.pop()
+	if err != nil {
+		return err
+	}
+	return vm.push(boolItem(a == b))
+}
+
+func opVerify(vm *scriptVM) error {
+	top, err := vm.pop()
+	if err != nil {
+		return err
+	}
+	if !truthy(top) {
+		return ErrScriptVerify
+	}
+	return nil
+}
+
+// 맨 위 값의 SHA-256 해시 (값의 문자열 바이트를 해시)
+func opSha256(vm *scriptVM) error {
+	top, err := vm.pop()
+	if err != nil {
+		return err
+	}
+	return vm.push(utils.HashBytes([]byte(top)))
+}
+
+// <16진수 값> OP_SHA256HEX: 16진수를 디코딩한 바이트의 SHA-256 해시 (다른 체인과 같은 바이트 비밀 값을 쓰는 HTLC용). 16진수가 아니면 실패
+func opSha256Hex(vm *scriptVM) error {
+	top, err := vm.pop()
+	if err != nil {
+		return err
+	}
+	raw, err := hex.DecodeString(top)
+	if err != nil {
+		return fmt.Errorf("%w: not hex", ErrScriptStack)
+	}
+	return vm.push(utils.HashBytes(raw))
+}
+
+// <값> OP_SIZE: 값의 길이(문자 수)를 쌓음. 값은 스택에 남는다
+func opSize(vm *scriptVM) error {
+	if len(vm.stack) == 0 {
+		return fmt.Errorf("%w: stack underflow", ErrScriptStack)
+	}
+	return vm.push(strconv.Itoa(len(vm.stack[len(vm.stack)-1])))
+}
+
+// <서명> <공개키> OP_CHECKSIG: 서명이 사용하는 트랜잭션의 해시 값에 대한 공개키의 서명인지 확인
+func opCheckSig(vm *scriptVM) error {
+	key, err := vm.pop()
+	if err != nil {
+		return err
+	}
+	sig, err := vm.pop()
+	if err != nil {
+		return err
+	}
+	return vm.push(boolItem(wallet.Verify(sig, vm.payload, key)))
+}
+
+// <서명1> ... <서명m> <m> <공개키1> ... <공개키n> <n> OP_CHECKMULTISIG
+func opCheckMultisig(vm *scriptVM) error {
+	n, err := vm.popInt()
+	if err != nil {
+		return err
+	}
+	if n < 1 || n > maxMultisigKeys {
+		return fmt.Errorf("%w: %d keys", ErrInvalidMultisig, n)
+	}
+	if err := vm.charge(sigOpCost * (n - 1)); err != nil {
+		return err
+	}
+	keys := make([]string, n)
+	for i := n - 1; i >= 0; i-- {
+		if keys[i], err = vm.pop(); err != nil {
+			return err
+		}
+	}
+	m, err := vm.popInt()
+	if err != nil {
+		return err
+	}
+	lock := &MultisigLock{M: m, PubKeys: keys}
+	if err := lock.validate(); err != nil {
+		return err
+	}
+	sigs := make([]string, m)
+	for i := m - 1; i >= 0; i-- {
+		if sigs[i], err = vm.pop(); err != nil {
+			return err
+		}
+	}
+	return vm.push(boolItem(verifyMultisig(sigs, vm.payload, lock) == nil))
+}
+
+// <잠금 시점> OP_CHECKLOCKTIMEVERIFY: 트랜잭션이 포함될 블록의 높이(잠금 시점이 lockTimeThreshold 이상이면 중간 시간)가 잠금 시점에 이르렀는지 확인. 값은 스택에 남는다
+// BIP65와 달리 트랜잭션의 LockTime이 아닌 블록 기준과 직접 비교하므로, 사용하는 트랜잭션에 LockTime을 설정할 필요가 없다
+func opCheckLockTime(vm *scriptVM) error {
+	lockTime, err := vm.peekInt()
+	if err != nil {
+		return err
+	}
+	current := vm.at.height
+	if lockTime >= lockTimeThreshold {
+		current = vm.at.medianTime
+	}
+	if current < lockTime {
+		return fmt.Errorf("%w: %d < %d", ErrOutputLocked, current, lockTime)
+	}
+	return nil
+}
+
+// <블록 수> OP_CHECKAGEVERIFY: 잠긴 출력이 포함된 블록으로부터 주어진 블록 수가 지났는지 확인. 값은 스택에 남는다
+func opCheckAge(vm *scriptVM) error {
+	age, err := vm.peekInt()
+	if err != nil {
+		return err
+	}
+	if vm.entry == nil {
+		return fmt.Errorf("%w: no output to measure the age of", ErrOutputLocked)
+	}
+	if current := vm.at.height - vm.entry.Height; current < age {
+		return fmt.Errorf("%w: age %d < %d", ErrOutputLocked, current, age)
+	}
+	return nil
+}
+
+// 실행 과정 기록 (trace가 nil이면 아무것도 하지 않음)
+func (t *ScriptTrace) record(token string, executed bool, vm *scriptVM) {
+	if t == nil {
+		return
+	}
+	t.Steps = append(t.Steps, &ScriptStep{
+		Op:       token,
+		Executed: executed,
+		Cost:     vm.cost,
+		Stack:    append([]string{}, vm.stack...),
+	})
+	t.Cost = vm.cost
+}
+
+// 템플릿으로 잠금 스크립트 생성
+func BuildScript(t *ScriptTemplate) (string, error) {
+	one := func() (string, error) {
+		if len(t.PubKeys) != 1 || t.PubKeys[0] == "" {
+			return "", fmt.Errorf("%w: %s needs one public key", ErrUnknownTemplate, t.Type)
+		}
+		return t.PubKeys[0], nil
+	}
+	switch t.Type {
+	case "p2pk":
+		key, err := one()
+		return key + " OP_CHECKSIG", err
+	case "p2pkh":
+		key, err := one()
+		return "OP_DUP OP_SHA256 " + utils.HashBytes([]byte(key)) + " OP_EQUALVERIFY OP_CHECKSIG", err
+	case "multisig":
+		lock := &MultisigLock{M: t.M, PubKeys: t.PubKeys}
+		if err := lock.validate(); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d %s %d OP_CHECKMULTISIG", t.M, strings.Join(t.PubKeys, " "), len(t.PubKeys)), nil
+	case "timelock":
+		key, err := one()
+		if t.LockTime <= 0 {
+			return "", fmt.Errorf("%w: timelock needs a lock time", ErrUnknownTemplate)
+		}
+		return fmt.Sprintf("%d OP_CHECKLOCKTIMEVERIFY OP_DROP %s OP_CHECKSIG", t.LockTime, key), err
+	case "htlc":
+		if len(t.PubKeys) != 2 {
+			return "", fmt.Errorf("%w: htlc needs recipient and sender keys", ErrUnknownTemplate)
+		}
+		h := &HTLC{Recipient: t.PubKeys[0], Sender: t.PubKeys[1], Hash: t.Hash, Timeout: t.LockTime}
+		if err := h.validate(); err != nil {
+			return "", err
+		}
+		return h.Script(), nil
+	case "hashlock":
+		key, err := one()
+		if t.Hash == "" {
+			return "", fmt.Errorf("%w: hashlock needs a hash", ErrUnknownTemplate)
+		}
+		return "OP_SHA256 " + t.Hash + " OP_EQUALVERIFY " + key + " OP_CHECKSIG", err
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownTemplate, t.Type)
+}
+
+// 임의의 잠금 스크립트와 증인 값을 현재 체인 기준으로 실행하고 실행 과정을 반환
+func TraceScript(script string, witness []string, payload string) *ScriptTrace {
+	at := Blockchain().snapshot().nextLockContext()
+	return traceScript(script, witness, payload, nil, at)
+}
+
+// 트랜잭션의 특정 입력이 사용하는 출력의 잠금 스크립트를 현재 체인 기준으로 실행하고 실행 과정을 반환
+func TraceInput(tx *Tx, index int) (*ScriptTrace, error) {
+	if tx == nil || index < 0 || index >= len(tx.TxIns) || tx.TxIns[index] == nil {
+		return nil, fmt.Errorf("%w: input %d", ErrMalformedTx, index)
+	}
+	state := Blockchain().snapshot()
+	txIn := tx.TxIns[index]
+	prev := state.lookup(txIn.TxID, txIn.Index)
+	if prev == nil {
+		return nil, fmt.Errorf("%w: %s", ErrMissingInput, outpoint(txIn.TxID, txIn.Index))
+	}
+	if prev.Output.Script == "" {
+		return nil, ErrScriptNoInput
+	}
+	return traceScript(prev.Output.Script, txIn.Witness, tx.ID, prev, state.nextLockContext()), nil
+}
+
+func traceScript(script string, witness []string, payload string, entry *utxoEntry, at lockContext) *ScriptTrace {
+	trace := &ScriptTrace{Script: script, Witness: witness, Steps: []*ScriptStep{}}
+	if _, err := runScript(script, witness, payload, entry, at, trace); err != nil {
+		trace.Error = err.Error()
+	} else {
+		trace.Success = true
+	}
+	return trace
+}
@@ -0,0 +1,265 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/abcfe-op/abcfe-node/common/utils"
+)
+
+// 토큰을 n번 반복한 스크립트 조각
+func repeat(token string, n int) string {
+	return strings.TrimSpace(strings.Repeat(token+" ", n))
+}
+
+// 서로 다른 가짜 공개키 n개
+func fakeKeys(n int) string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("k%d", i)
+	}
+	return strings.Join(keys, " ")
+}
+
+func TestRunScript(t *testing.T) {
+	at := lockContext{height: 100, medianTime: lockTimeThreshold + 100}
+	entry := &utxoEntry{Height: 90}
+	tests := []struct {
+		name    string
+		script  string
+		witness []string
+		want    error
+	}{
+		{"true", "OP_1", nil, nil},
+		{"false", "OP_0", nil, ErrScriptFailed},
+		{"witness is pushed first", "OP_EQUAL", []string{"ab", "ab"}, nil},
+		{"empty script", " ", nil, ErrInvalidScript},
+		{"unknown opcode", "OP_1 OP_NOP", nil, ErrInvalidScript},
+		{"too many tokens", repeat("OP_1", maxScriptTokens+1), nil, ErrInvalidScript},
+		{"data longer than a stack item", strings.Repeat("a", maxScriptItemLen+1), nil, ErrInvalidScript},
+		{"stack underflow", "OP_DROP", nil, ErrScriptStack},
+		{"stack overflow", "OP_1 " + repeat("OP_DUP", maxStackItems), nil, ErrScriptStack},
+		{"too many witness items", "OP_1", make([]string, maxWitnessItems+1), ErrInvalidScript},
+		{"witness item longer than a stack item", "OP_1", []string{strings.Repeat("a", maxScriptItemLen+1)}, ErrInvalidScript},
+		{"empty stack at the end", "OP_1 OP_DROP", nil, ErrScriptStack},
+		{"early return", "OP_RETURN OP_1", nil, ErrScriptReturn},
+		{"verify fails", "OP_0 OP_VERIFY OP_1", nil, ErrScriptVerify},
+		{"if branch", "OP_IF OP_1 OP_ELSE OP_0 OP_ENDIF", []string{"1"}, nil},
+		{"else branch", "OP_IF OP_1 OP_ELSE OP_0 OP_ENDIF", []string{"0"}, ErrScriptFailed},
+		{"notif branch", "OP_NOTIF OP_1 OP_ELSE OP_0 OP_ENDIF", []string{""}, nil},
+		{"else inside a skipped branch stays skipped", "OP_0 OP_IF OP_0 OP_IF OP_0 OP_ELSE OP_RETURN OP_ENDIF OP_ENDIF OP_1", nil, nil},
+		{"unterminated if", "OP_1 OP_IF OP_1", nil, ErrScriptUnbalanced},
+		{"else without if", "OP_1 OP_ELSE", nil, ErrScriptUnbalanced},
+		{"endif without if", "OP_1 OP_ENDIF", nil, ErrScriptUnbalanced},
+		{"size leaves the item", "abc OP_SIZE 3 OP_EQUALVERIFY abc OP_EQUAL", nil, nil},
+		{"sha256 of hex-decoded bytes", "OP_SHA256HEX " + utils.HashBytes([]byte{0xab}) + " OP_EQUAL", []string{"ab"}, nil},
+		{"sha256hex of a non-hex item", "OP_SHA256HEX OP_DROP OP_1", []string{"xy"}, ErrScriptStack},
+		{"lock time reached", "100 OP_CHECKLOCKTIMEVERIFY", nil, nil},
+		{"lock height not reached", "101 OP_CHECKLOCKTIMEVERIFY", nil, ErrOutputLocked},
+		{"lock time not reached", fmt.Sprintf("%d OP_CHECKLOCKTIMEVERIFY", lockTimeThreshold+101), nil, ErrOutputLocked},
+		{"lock time is not a number", "ab OP_CHECKLOCKTIMEVERIFY", nil, ErrScriptStack},
+		{"age reached", "10 OP_CHECKAGEVERIFY", nil, nil},
+		{"age not reached", "11 OP_CHECKAGEVERIFY", nil, ErrOutputLocked},
+		{"multisig with too many keys", fmt.Sprintf("x 1 %s %d OP_CHECKMULTISIG", fakeKeys(maxMultisigKeys+1), maxMultisigKeys+1), nil, ErrInvalidMultisig},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := runScript(tt.script, tt.witness, "aabb", entry, at, nil); !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestScriptCostLimit(t *testing.T) {
+	multisig := fmt.Sprintf("x 1 %s %d OP_CHECKMULTISIG", fakeKeys(maxMultisigKeys), maxMultisigKeys)
+	tests := []struct {
+		name    string
+		script  string
+		witness []string
+		cost    int
+		want    error
+	}{
+		{"hashes up to the limit", repeat("OP_SHA256", maxScriptCost/hashOpCost-1) + " " + repeat("OP_DUP", hashOpCost-1), []string{"a"}, maxScriptCost, nil},
+		{"one step over the limit", repeat("OP_SHA256", maxScriptCost/hashOpCost-1) + " " + repeat("OP_DUP", hashOpCost), []string{"a"}, maxScriptCost + 1, ErrScriptCost},
+		{"witness pushes are charged", "OP_DROP " + repeat("OP_SHA256", maxScriptCost/hashOpCost-1) + " " + repeat("OP_DUP", hashOpCost-1), []string{"a", "b"}, 0, ErrScriptCost},
+		{"signature checks", repeat("x x OP_CHECKSIG OP_DROP", 18) + " OP_1", nil, 18*(3+sigOpCost) + 1, nil},
+		{"too many signature checks", repeat("x x OP_CHECKSIG OP_DROP", 19) + " OP_1", nil, 0, ErrScriptCost},
+		{"multisig is charged per key", multisig + " OP_DROP " + multisig, nil, 0, ErrScriptCost},
+		{"skipped branches are free", "OP_0 OP_IF " + repeat("OP_CHECKSIG", maxScriptTokens-5) + " OP_ENDIF OP_1", nil, 4, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trace := traceScript(tt.script, tt.witness, "aabb", nil, lockContext{})
+			_, err := runScript(tt.script, tt.witness, "aabb", nil, lockContext{}, nil)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			if tt.cost != 0 && trace.Cost != tt.cost {
+				t.Fatalf("cost = %d, want %d", trace.Cost, tt.cost)
+			}
+			if trace.Cost > maxScriptCost+sigOpCost*maxMultisigKeys {
+				t.Fatalf("execution went on past the limit: cost %d", trace.Cost)
+			}
+		})
+	}
+}
+
+func TestTxScriptCostLimit(t *testing.T) {
+	// 입력마다 한도 안인 스크립트라도, 트랜잭션 전체의 실행 비용은 maxTxScriptCost를 넘을 수 없음
+	script := repeat("OP_SHA256", maxScriptCost/hashOpCost-1) + " OP_DROP OP_1" // 증인 값 하나와 함께 993
+	lookup := func(txID string, index int) *utxoEntry {
+		return &utxoEntry{TxID: txID, Index: index, Output: &TxOut{Address: ScriptAddress(script), Amount: 10, Script: script}}
+	}
+	for inputs, want := range map[int]error{4: nil, 5: ErrScriptCost} {
+		tx := &Tx{Timestamp: 1700000000, TxOuts: []*TxOut{{Address: "ab", Amount: 1}}, Kind: KindTransfer}
+		for i := 0; i < inputs; i++ {
+			tx.TxIns = append(tx.TxIns, &TxIn{TxID: "aa", Index: i, Witness: []string{"a"}})
+		}
+		tx.getId()
+		if _, err := validateTx(tx, lookup, make(map[string]bool), lockContext{}); !errors.Is(err, want) {
+			t.Fatalf("%d inputs: err = %v, want %v", inputs, err, want)
+		}
+	}
+}
+
+func TestScriptTemplates(t *testing.T) {
+	const payload = "aabbcc"
+	key := nodeKey
+	other := testKeys[genesisValidators[1].Address]
+	sig := key.sign(payload)
+	secret := "secret"
+	build := func(t *testing.T, template *ScriptTemplate) string {
+		t.Helper()
+		script, err := BuildScript(template)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return script
+	}
+	tests := []struct {
+		name     string
+		template *ScriptTemplate
+		witness  []string
+		at       lockContext
+		want     error
+	}{
+		{"p2pk", &ScriptTemplate{Type: "p2pk", PubKeys: []string{key.address}}, []string{sig}, lockContext{}, nil},
+		{"p2pk by another key", &ScriptTemplate{Type: "p2pk", PubKeys: []string{key.address}}, []string{other.sign(payload)}, lockContext{}, ErrScriptFailed},
+		{"p2pkh", &ScriptTemplate{Type: "p2pkh", PubKeys: []string{key.address}}, []string{sig, key.address}, lockContext{}, nil},
+		{"p2pkh with another key", &ScriptTemplate{Type: "p2pkh", PubKeys: []string{key.address}}, []string{other.sign(payload), other.address}, lockContext{}, ErrScriptVerify},
+		{"multisig", &ScriptTemplate{Type: "multisig", M: 2, PubKeys: []string{key.address, other.address}}, []string{sig, other.sign(payload)}, lockContext{}, nil},
+		{"multisig short of signatures", &ScriptTemplate{Type: "multisig", M: 2, PubKeys: []string{key.address, other.address}}, []string{sig, sig}, lockContext{}, ErrScriptFailed},
+		{"timelock reached", &ScriptTemplate{Type: "timelock", PubKeys: []string{key.address}, LockTime: 10}, []string{sig}, lockContext{height: 10}, nil},
+		{"timelock not reached", &ScriptTemplate{Type: "timelock", PubKeys: []string{key.address}, LockTime: 10}, []string{sig}, lockContext{height: 9}, ErrOutputLocked},
+		{"hashlock", &ScriptTemplate{Type: "hashlock", PubKeys: []string{key.address}, Hash: utils.HashBytes([]byte(secret))}, []string{sig, secret}, lockContext{}, nil},
+		{"hashlock with a wrong preimage", &ScriptTemplate{Type: "hashlock", PubKeys: []string{key.address}, Hash: utils.HashBytes([]byte(secret))}, []string{sig, "guess"}, lockContext{}, ErrScriptVerify},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			script := build(t, tt.template)
+			if _, err := runScript(script, tt.witness, payload, nil, tt.at, nil); !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildScriptRejectsIncompleteTemplates(t *testing.T) {
+	tests := []struct {
+		name     string
+		template *ScriptTemplate
+		want     error
+	}{
+		{"unknown type", &ScriptTemplate{Type: "p2sh", PubKeys: []string{"ab"}}, ErrUnknownTemplate},
+		{"p2pk without a key", &ScriptTemplate{Type: "p2pk"}, ErrUnknownTemplate},
+		{"p2pkh with two keys", &ScriptTemplate{Type: "p2pkh", PubKeys: []string{"ab", "cd"}}, ErrUnknownTemplate},
+		{"multisig above its keys", &ScriptTemplate{Type: "multisig", M: 3, PubKeys: []string{"ab", "cd"}}, ErrInvalidMultisig},
+		{"timelock without a lock time", &ScriptTemplate{Type: "timelock", PubKeys: []string{"ab"}}, ErrUnknownTemplate},
+		{"hashlock without a hash", &ScriptTemplate{Type: "hashlock", PubKeys: []string{"ab"}}, ErrUnknownTemplate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := BuildScript(tt.template); !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestScriptTraceRecordsSteps(t *testing.T) {
+	trace := traceScript("OP_IF OP_1 OP_ELSE OP_0 OP_ENDIF", []string{"1"}, "aabb", nil, lockContext{})
+	if !trace.Success || trace.Error != "" || len(trace.Steps) != 5 {
+		t.Fatalf("trace = %+v", trace)
+	}
+	// 분기를 닫는 OP_ENDIF는 닫히기 전 분기의 실행 여부로 기록됨
+	executed := []bool{true, true, true, false, false}
+	for i, step := range trace.Steps {
+		if step.Executed != executed[i] {
+			t.Fatalf("step %d (%s) executed = %v", i, step.Op, step.Executed)
+		}
+		if i > 0 && step.Cost < trace.Steps[i-1].Cost {
+			t.Fatal("cost decreased between steps")
+		}
+	}
+	if last := trace.Steps[len(trace.Steps)-1]; len(last.Stack) != 1 || last.Stack[0] != scriptTrue {
+		t.Fatalf("final stack = %v", last.Stack)
+	}
+
+	failed := traceScript("OP_1 OP_RETURN OP_1", nil, "aabb", nil, lockContext{})
+	if failed.Success || len(failed.Steps) != 2 || !strings.Contains(failed.Error, ErrScriptReturn.Error()) {
+		t.Fatalf("trace of a failing script = %+v", failed)
+	}
+}
+
+func TestScriptOutputOnChain(t *testing.T) {
+	bc := newTestChain(t)
+	fund(t, bc)
+	secret := "secret"
+	script, err := BuildScript(&ScriptTemplate{Type: "hashlock", PubKeys: []string{nodeKey.address}, Hash: utils.HashBytes([]byte(secret))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Mempool().AddTxOut(&TxOut{Address: "ab", Amount: 5, Script: script}, 1, "", 0, nodeKey.port); !errors.Is(err, ErrScriptAddress) {
+		t.Fatalf("output address not derived from the script: err = %v, want %v", err, ErrScriptAddress)
+	}
+	tx, err := Mempool().AddTxOut(&TxOut{Address: ScriptAddress(script), Amount: 5, Script: script}, 1, "", 0, nodeKey.port)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mine(t, bc, fromMempool)
+	locked := findUTXO(tx.ID, len(tx.TxOuts)-1)
+
+	spend := func(witness ...string) *Tx {
+		spend := &Tx{Timestamp: 1700000000, TxIns: []*TxIn{{TxID: locked.TxID, Index: locked.Index}}, TxOuts: []*TxOut{{Address: "cd", Amount: 4}}, Kind: KindTransfer}
+		spend.getId()
+		spend.TxIns[0].Witness = witness
+		for i, item := range witness {
+			if item == "" {
+				spend.TxIns[0].Witness[i] = nodeKey.sign(spend.ID) // 서명 자리
+			}
+		}
+		return spend
+	}
+	wrong := spend("", "guess")
+	if err := Mempool().SubmitTx(wrong); !errors.Is(err, ErrScriptVerify) {
+		t.Fatalf("wrong preimage: err = %v, want %v", err, ErrScriptVerify)
+	}
+	trace, err := TraceInput(wrong, 0)
+	if err != nil || trace.Success || trace.Error == "" {
+		t.Fatalf("trace of the wrong preimage = %+v, %v", trace, err)
+	}
+	right := spend("", secret)
+	if trace, err := TraceInput(right, 0); err != nil || !trace.Success {
+		t.Fatalf("trace of the right preimage = %+v, %v", trace, err)
+	}
+	if err := Mempool().SubmitTx(right); err != nil {
+		t.Fatal(err)
+	}
+	mine(t, bc, fromMempool)
+	if balance(t, bc, "cd") != 4 {
+		t.Fatal("script spend was not confirmed")
+	}
+}
@@ -0,0 +1,119 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// 시간 잠금의 기준
+type LockKind string
+
+const (
+	LockByHeight LockKind = "height" // 블록 높이 기준
+	LockByTime   LockKind = "time"   // 중간 시간(median time past) 기준, 초 단위
+)
+
+const (
+	lockTimeThreshold = 500000000  // 트랜잭션 LockTime이 이 값보다 작으면 블록 높이, 크거나 같으면 유닉스 시간으로 해석
+	medianTimeBlocks  = 11         // 중간 시간 계산에 사용하는 최근 블록 수
+	stakingLockup     = MonthToSec // 스테이킹 출력의 락업 기간
+	maxFutureDrift    = 2 * 60     // 블록 타임스탬프가 노드의 현재 시각보다 앞설 수 있는 최대 시간(초). 노드 간 시계 차이를 허용
+)
+
+var (
+	ErrInvalidTimeLock = errors.New("invalid time lock")
+	ErrOutputLocked    = errors.New("output is time-locked")
+	ErrTxNotFinal      = errors.New("transaction lock time is not reached")
+	ErrStakeNotLocked  = errors.New("staking output must be locked for the lockup period")
+	ErrBlockTimeTooOld = errors.New("block timestamp is before the median time past")
+	ErrBlockTimeTooNew = errors.New("block timestamp is too far in the future")
+)
+
+// 출력에 거는 시간 잠금. 절대 잠금은 Value 자체가, 상대 잠금은 출력이 포함된 블록의 높이(또는 타임스탬프) + Value가 해제 시점이 된다
+type TimeLock struct {
+	Kind     LockKind `json:"kind"`
+	Relative bool     `json:"relative,omitempty"`
+	Value    int      `json:"value"`
+}
+
+// 트랜잭션이 포함될 블록의 높이와, 그 블록 직전까지의 중간 시간 (시간 잠금 판단 기준)
+type lockContext struct {
+	height     int
+	medianTime int
+}
+
+// 스테이킹 출력에 거는 잠금: 포함된 블록으로부터 락업 기간이 지나야 인출 가능
+func stakingLock() *TimeLock {
+	return &TimeLock{Kind: LockByTime, Relative: true, Value: stakingLockup}
+}
+
+// 잠금 조건 검증
+func (l *TimeLock) validate() error {
+	if (l.Kind != LockByHeight && l.Kind != LockByTime) || l.Value <= 0 {
+		return fmt.Errorf("%w: %s %d", ErrInvalidTimeLock, l.Kind, l.Value)
+	}
+	return nil
+}
+
+// 잠금이 풀리는 시점 (높이 또는 중간 시간). e는 잠긴 출력의 UTXO 정보
+func (l *TimeLock) unlockAt(e *utxoEntry) int {
+	if !l.Relative {
+		return l.Value
+	}
+	if l.Kind == LockByHeight {
+		return e.Height + l.Value
+	}
+	return e.Timestamp + l.Value
+}
+
+// 잠긴 출력을 주어진 시점에 사용할 수 있는지 확인
+func (l *TimeLock) check(e *utxoEntry, at lockContext) error {
+	unlock := l.unlockAt(e)
+	current := at.height
+	if l.Kind == LockByTime {
+		current = at.medianTime
+	}
+	if current < unlock {
+		return fmt.Errorf("%w: %s %d < %d", ErrOutputLocked, l.Kind, current, unlock)
+	}
+	return nil
+}
+
+// 트랜잭션의 LockTime이 지났는지 확인 (0이면 잠금 없음)
+func (t *Tx) checkFinal(at lockContext) error {
+	if t.LockTime == 0 {
+		return nil
+	}
+	current := at.height
+	if t.LockTime >= lockTimeThreshold {
+		current = at.medianTime
+	}
+	if current < t.LockTime {
+		return fmt.Errorf("%w: %d < %d", ErrTxNotFinal, current, t.LockTime)
+	}
+	return nil
+}
+
+// 특정 블록과 그 이전 블록들(최대 medianTimeBlocks개)의 타임스탬프 중간값
+func medianTimePast(hash string) int {
+	var times []int
+	for hash != "" && len(times) < medianTimeBlocks {
+		block, err := FindBlock(hash)
+		if err != nil {
+			break
+		}
+		times = append(times, block.Timestamp)
+		hash = block.PrevHash
+	}
+	if len(times) == 0 {
+		return 0
+	}
+	sort.Ints(times)
+	return times[len(times)/2]
+}
+
+// 체인 상태 다음 블록의 잠금 판단 기준
+func (s *chainState) nextLockContext() lockContext {
+	return lockContext{height: s.height + 1, medianTime: medianTimePast(s.hash)}
+}
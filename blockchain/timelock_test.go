@@ -0,0 +1,138 @@
+package blockchain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/abcfe-op/abcfe-node/common/utils"
+)
+
+func TestTimeLockCheck(t *testing.T) {
+	// 높이 10, 타임스탬프 1000인 블록이 만든 출력
+	e := &utxoEntry{Height: 10, Timestamp: 1000}
+	tests := []struct {
+		name   string
+		lock   TimeLock
+		at     lockContext
+		locked bool
+	}{
+		{"absolute height before", TimeLock{Kind: LockByHeight, Value: 20}, lockContext{height: 19}, true},
+		{"absolute height reached", TimeLock{Kind: LockByHeight, Value: 20}, lockContext{height: 20}, false},
+		{"relative height before", TimeLock{Kind: LockByHeight, Relative: true, Value: 5}, lockContext{height: 14}, true},
+		{"relative height reached", TimeLock{Kind: LockByHeight, Relative: true, Value: 5}, lockContext{height: 15}, false},
+		{"absolute time before", TimeLock{Kind: LockByTime, Value: 2000}, lockContext{height: 100, medianTime: 1999}, true},
+		{"absolute time reached", TimeLock{Kind: LockByTime, Value: 2000}, lockContext{medianTime: 2000}, false},
+		{"relative time before", TimeLock{Kind: LockByTime, Relative: true, Value: 500}, lockContext{height: 100, medianTime: 1499}, true},
+		{"relative time reached", TimeLock{Kind: LockByTime, Relative: true, Value: 500}, lockContext{medianTime: 1500}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.lock.check(e, tt.at)
+			if locked := errors.Is(err, ErrOutputLocked); locked != tt.locked || (err != nil && !locked) {
+				t.Fatalf("err = %v, want locked = %v", err, tt.locked)
+			}
+		})
+	}
+}
+
+func TestTimeLockValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		lock TimeLock
+		ok   bool
+	}{
+		{"height", TimeLock{Kind: LockByHeight, Value: 1}, true},
+		{"relative time", TimeLock{Kind: LockByTime, Relative: true, Value: 1}, true},
+		{"unknown kind", TimeLock{Kind: "slot", Value: 1}, false},
+		{"zero value", TimeLock{Kind: LockByHeight}, false},
+		{"negative value", TimeLock{Kind: LockByTime, Value: -1}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.lock.validate(); (err == nil) != tt.ok {
+				t.Fatalf("err = %v, want ok = %v", err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestTxCheckFinal(t *testing.T) {
+	at := lockContext{height: 100, medianTime: lockTimeThreshold + 100}
+	tests := []struct {
+		name     string
+		lockTime int
+		final    bool
+	}{
+		{"no lock time", 0, true},
+		{"height reached", 100, true},
+		{"height not reached", 101, false},
+		{"time reached", lockTimeThreshold + 100, true},
+		{"time not reached", lockTimeThreshold + 101, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&Tx{LockTime: tt.lockTime}).checkFinal(at)
+			if (err == nil) != tt.final || (err != nil && !errors.Is(err, ErrTxNotFinal)) {
+				t.Fatalf("err = %v, want final = %v", err, tt.final)
+			}
+		})
+	}
+}
+
+func TestLockedOutputCannotBeSpentEarly(t *testing.T) {
+	bc := newTestChain(t)
+	fund(t, bc)
+	lock := &TimeLock{Kind: LockByHeight, Relative: true, Value: 2}
+	tx, err := Mempool().AddTxOut(&TxOut{Address: nodeKey.address, Amount: 5, Lock: lock}, 1, "", 0, nodeKey.port)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := mine(t, bc, fromMempool)
+	locked := findUTXO(tx.ID, len(tx.TxOuts)-1)
+	if locked == nil || locked.Output.Lock == nil {
+		t.Fatal("locked output is missing")
+	}
+	spend := spendTx(nodeKey, []*utxoEntry{locked}, &TxOut{Address: "ab", Amount: 4})
+
+	// 다음 블록의 높이는 block.Height+1이므로 아직 잠겨 있음
+	if err := Mempool().SubmitTx(spend); !errors.Is(err, ErrOutputLocked) || RejectReason(err) != RejectLocked {
+		t.Fatalf("err = %v, want %v", err, ErrOutputLocked)
+	}
+	// 잠긴 출력을 담은 블록도 거절됨
+	early := certifiedBlock(t, block, 0, func(t *testing.T, roles *RoleInfo, height int) []*Tx {
+		coinbase, err := makeCoinbaseTx(roles, height, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return []*Tx{spend, coinbase}
+	})
+	if err := bc.ImportBlock(early); !errors.Is(err, ErrOutputLocked) {
+		t.Fatalf("block spending a locked output: err = %v, want %v", err, ErrOutputLocked)
+	}
+	mine(t, bc, coinbaseOnly)
+	if err := Mempool().SubmitTx(spend); err != nil {
+		t.Fatalf("unlocked output was rejected: %v", err)
+	}
+}
+
+func TestStakeOutputMustCarryLockup(t *testing.T) {
+	tests := []struct {
+		name string
+		lock *TimeLock
+		ok   bool
+	}{
+		{"staking lock", stakingLock(), true},
+		{"no lock", nil, false},
+		{"height lock", &TimeLock{Kind: LockByHeight, Relative: true, Value: stakingLockup}, false},
+		{"absolute time lock", &TimeLock{Kind: LockByTime, Value: stakingLockup}, false},
+		{"shorter than the lockup", &TimeLock{Kind: LockByTime, Relative: true, Value: stakingLockup - 1}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateStakeOutput(&TxOut{Address: utils.StakingAddress, Amount: 100, Lock: tt.lock})
+			if (err == nil) != tt.ok || (err != nil && !errors.Is(err, ErrStakeNotLocked)) {
+				t.Fatalf("err = %v, want ok = %v", err, tt.ok)
+			}
+		})
+	}
+}
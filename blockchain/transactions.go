@@ -1,8 +1,10 @@
 package blockchain
 
 import (
+	"bytes"
 	"errors"
-	"sync"
+	"fmt"
+	"sort"
 	"time"
 
 	"github.com/abcfe-op/abcfe-node/common/utils"
@@ -10,65 +12,67 @@ import (
 )
 
 const (
-	proposalReward  int = 50      // 제안자 보상
-	validatorReward int = 10      // 검증자 보상
+	proposalReward  Amount = 50 // 제안자 보상
+	validatorReward Amount = 10 // 검증자 한 자리의 보상
+	MinTxFee        Amount = 1  // 멤풀에 들어갈 수 있는 트랜잭션의 최소 수수료
+)
+
+const (
 	MonthToSec      int = 2592000 // 1달의 초단위 변환
 	WeekToSec       int = 604800  // 1주의 초단위 변환
 	DayToSec        int = 86400   // 1일의 초단위 변환
 	SlotSec         int = 12      // 슬롯의 초단위 변환
+	maxBlockTxBytes int = 1 << 20 // 블록 구성 시 담을 수 있는 트랜잭션의 최대 크기 합
 )
 
-type mempool struct {
-	Txs map[string]*Tx
-	m   sync.Mutex
-}
-
-var m *mempool = &mempool{}
-var memOnce sync.Once
-
-// 대기 중인 트랜잭션들을 저장
-func Mempool() *mempool {
-	memOnce.Do(func() {
-		m = &mempool{
-			Txs: make(map[string]*Tx),
-		}
-	})
-	return m
-}
-
 // 트랜잭션에 대한 구조체
 type Tx struct {
-	ID        string   `json:"id"`        // 트랜잭션의 해시 값
-	Timestamp int      `json:"timestamp"` // 트랜잭션의 타임스탬프
-	TxIns     []*TxIn  `json:"txIns"`     // 트랜잭션 Input
-	TxOuts    []*TxOut `json:"txOuts"`    // 트랜잭션 Outputs
-	InputData string   `json:"inputData"` // 트랜잭션에 추가적으로 기입한 문자열
+	ID        string     `json:"id"`                 // 트랜잭션의 해시 값
+	Timestamp int        `json:"timestamp"`          // 트랜잭션의 타임스탬프
+	TxIns     []*TxIn    `json:"txIns"`              // 트랜잭션 Input
+	TxOuts    []*TxOut   `json:"txOuts"`             // 트랜잭션 Outputs
+	InputData string     `json:"inputData"`          // 트랜잭션에 추가적으로 기입한 메모 (합의 규칙에서 해석하지 않음)
+	LockTime  int        `json:"lockTime,omitempty"` // 이 높이(또는 유닉스 시간) 전에는 블록에 포함될 수 없음. 0이면 잠금 없음
+	Kind      TxKind     `json:"kind"`               // 트랜잭션 종류
+	Payload   *TxPayload `json:"payload,omitempty"`  // 종류별 페이로드 (일반 전송은 없음)
 }
 
 // 트랜잭션 Input에 대한 구조체
 type TxIn struct {
-	TxID      string `json:"txId"`
-	Index     int    `json:"index"`
-	Signature string `json:"signature"`
+	TxID       string   `json:"txId"`
+	Index      int      `json:"index"`
+	Signature  string   `json:"signature"`
+	Signatures []string `json:"signatures,omitempty"` // 다중서명 출력을 사용할 때의 서명들
+	Witness    []string `json:"witness,omitempty"`    // 스크립트 출력을 사용할 때 잠금 스크립트 실행 전에 스택에 쌓는 값들
 }
 
 // 트랜잭션 Output에 대한 구조체
 type TxOut struct {
-	Address string `json:"address"`
-	Amount  int    `json:"amount"`
+	Address  string        `json:"address"`
+	Amount   Amount        `json:"amount"`             // 기본 단위의 금액
+	Multisig *MultisigLock `json:"multisig,omitempty"` // 다중서명 출력이라면 잠금 조건 (Address는 잠금 조건으로 만든 주소)
+	Lock     *TimeLock     `json:"lock,omitempty"`     // 시간 잠금 (해제 전에는 사용할 수 없음)
+	Script   string        `json:"script,omitempty"`   // 잠금 스크립트 (Address는 스크립트로 만든 주소)
+	Asset    string        `json:"asset,omitempty"`    // 자산 ID (비어있으면 기본 코인)
 }
 
 // UTXO에 대한 구조체 (사용하지 않은 TxOut)
 type UTxOut struct {
 	TxID      string
 	Index     int
-	Amount    int
+	Amount    Amount
 	InputData string
+	Asset     string `json:",omitempty"`
+}
+
+// 트랜잭션의 정규 직렬화 값을 해시화
+func (t *Tx) calculateID() string {
+	return utils.HashBytes(t.serialize())
 }
 
 // 트랜잭션 내용을 해시화 한 뒤 ID에 저장
 func (t *Tx) getId() {
-	t.ID = utils.Hash(t)
+	t.ID = t.calculateID()
 }
 
 // 트랜잭션 Input에 서명 저장
@@ -78,238 +82,185 @@ func (t *Tx) sign(port string) {
 	}
 }
 
-// unstaking 시, PoS 스테이킹 풀 제공자 노드의 대리서명을 이용하여 스테이킹 자금 인출
-func (t *Tx) delegateSign() {
-	for _, txIn := range t.TxIns {
-		txIn.Signature = wallet.DelegateSign(t.ID)
+// 트랜잭션의 유효성을 검증: 현재 UTXO 셋의 출력으로 구성되고, 소유자의 서명이 유효하며, 최소 수수료를 지불하는 트랜잭션인가
+func validate(tx *Tx) error {
+	state := Blockchain().snapshot()
+	fee, err := validateTx(tx, state.lookup, make(map[string]bool), state.nextLockContext())
+	if err != nil {
+		return err
+	}
+	if fee < MinTxFee {
+		return fmt.Errorf("%w: %d < %d", ErrFeeTooLow, fee, MinTxFee)
 	}
+	return nil
 }
 
-// 트랜잭션의 유효성을 검증: UTXO로 구성된 트랜잭션인가
-func validate(tx *Tx) bool {
-	valid := true
-	for _, txIn := range tx.TxIns {
-		prevTx := FindTx(Blockchain(), txIn.TxID)
-		if prevTx == nil {
-			valid = false
-			break
+// 트랜잭션의 크기 (수수료율 계산에 사용)
+func (t *Tx) size() int {
+	size := len(t.serialize())
+	for _, txIn := range t.TxIns {
+		size += len(txIn.Signature)
+		for _, sig := range txIn.Signatures {
+			size += len(sig)
 		}
-		address := prevTx.TxOuts[txIn.Index].Address
-		valid = wallet.Verify(txIn.Signature, tx.ID, address)
-		if !valid {
-			break
+		for _, item := range txIn.Witness {
+			size += len(item)
 		}
 	}
-	return valid
+	if t.Payload != nil && t.Payload.Issue != nil {
+		size += len(t.Payload.Issue.Signature)
+	}
+	return size
 }
 
-// UTXO가 mempool에 있는지 확인
-func isOnMempool(uTxOut *UTxOut) bool {
-	exists := false
-Outer: // label로 중첩반복문 탈출
-	for _, tx := range Mempool().Txs {
-		for _, input := range tx.TxIns {
-			if input.TxID == uTxOut.TxID && input.Index == uTxOut.Index {
-				exists = true
-				break Outer
-			}
-		}
+// 코인베이스 트랜잭션이 지급해야 하는 제안자, 검증자 보상 (블록의 수수료 합은 제안자가 받고, 검증자는 자리마다 같은 보상을 받는다)
+func coinbaseOutputs(roleInfo *RoleInfo, fees Amount) ([]*TxOut, error) {
+	reward, err := proposalReward.Add(fees)
+	if err != nil {
+		return nil, err
+	}
+	txOuts := []*TxOut{{Address: roleInfo.ProposerAddress, Amount: reward}}
+	for _, address := range roleInfo.ValidatorAddress {
+		txOuts = append(txOuts, &TxOut{Address: address, Amount: validatorReward})
 	}
-	return exists
+	return txOuts, nil
 }
 
 // 블록 채굴 시, 채굴자를 주소로 삼는 코인베이스 거래내역을 생성
-func makeCoinbaseTx(roleInfo *RoleInfo) *Tx {
+func makeCoinbaseTx(roleInfo *RoleInfo, height int, fees Amount) (*Tx, error) {
 	txIns := []*TxIn{
-		{"", -1, "COINBASE"}, // 소유주는 채굴자
+		{TxID: "", Index: -1, Signature: "COINBASE"}, // 소유주는 채굴자
 	}
-	txOuts := []*TxOut{
-		{roleInfo.ProposerAddress, proposalReward},
-		{roleInfo.ValidatorAddress[0], validatorReward},
-		{roleInfo.ValidatorAddress[1], validatorReward},
-		{roleInfo.ValidatorAddress[2], validatorReward},
+	txOuts, err := coinbaseOutputs(roleInfo, fees)
+	if err != nil {
+		return nil, err
 	}
+	payload := newPayload()
+	payload.Coinbase = &CoinbasePayload{Height: height} // 블록 높이를 포함하여 블록마다 코인베이스 트랜잭션 해시가 달라지도록 함
 	tx := Tx{
 		ID:        "",
 		Timestamp: int(time.Now().Unix()),
 		TxIns:     txIns,
 		TxOuts:    txOuts,
-		InputData: "Proof of Stake",
+		InputData: fmt.Sprintf("Proof of Stake #%d", height),
+		Kind:      KindCoinbase,
+		Payload:   payload,
 	}
 	tx.getId()
-	return &tx
+	return &tx, nil
 }
 
 var ErrorNoMoney = errors.New("not enough money")
 var ErrorNotValid = errors.New("Tx Invalid")
+var ErrFeeTooLow = errors.New("fee is below the minimum")
+var ErrNoOutputs = errors.New("no outputs to pay")
 
-// 일반 트랜잭션을 생성
-func makeTx(from, to string, amount int, inputData string, port string) (*Tx, error) {
-	if BalanceByAddress(from, Blockchain()) < amount {
-		return nil, ErrorNoMoney
-	}
-	var txOuts []*TxOut
-	var txIns []*TxIn
-	total := 0 // UTXO의 잔액 저장할 곳
-	uTxOuts := UTxOutsByAddress(from, Blockchain())
-	for _, uTxOut := range uTxOuts {
-		if total >= amount {
-			break
-		}
-		txIn := &TxIn{uTxOut.TxID, uTxOut.Index, from}
-		txIns = append(txIns, txIn)
-		total += uTxOut.Amount
+// 주어진 출력들을 만드는 트랜잭션을 생성 (입력 합 - 출력 합이 수수료가 됨). 기본 코인 외 자산을 보낸다면 수수료는 기본 코인으로 따로 지불한다
+// 입력은 모든 출력의 자산별 합을 한 번에 채우도록 고르며, 거스름돈 출력은 자산마다 하나씩 앞쪽에 붙는다
+func makeTx(kind TxKind, payload *TxPayload, from string, outputs []*TxOut, fee Amount, selection CoinSelection, inputData string, lockTime int, port string) (*Tx, error) {
+	if len(outputs) == 0 {
+		return nil, ErrNoOutputs
 	}
-	if change := total - amount; change != 0 { // change: 거스름돈 // change가 0이 아니라면 거슬러줘야함
-		changeTxOut := &TxOut{from, change}
-		txOuts = append(txOuts, changeTxOut)
+	if err := selection.validate(); err != nil {
+		return nil, err
 	}
-	txOut := &TxOut{to, amount}
-	txOuts = append(txOuts, txOut)
-	tx := &Tx{
-		ID:        "",
-		Timestamp: int(time.Now().Unix()),
-		TxIns:     txIns,
-		TxOuts:    txOuts,
-		InputData: inputData,
+	if fee < MinTxFee {
+		return nil, fmt.Errorf("%w: %d < %d", ErrFeeTooLow, fee, MinTxFee)
 	}
-	tx.getId()
-	tx.sign(port)
-	valid := validate(tx)
-	if !valid {
-		return nil, ErrorNotValid
+	need := amounts{NativeAsset: fee}
+	for _, txOut := range outputs {
+		if err := need.add(txOut.Asset, txOut.Amount); err != nil {
+			return nil, err
+		}
 	}
-	return tx, nil
-}
-
-// 사용하고자 하는 UTXO가 포함된 트랜잭션 생성
-func makeTxbyUTXO(from, to, inputData, mainPort string, amount int, sInfo *StakingInfo, indexes []int) (*Tx, error) {
-	if BalanceByAddress(from, Blockchain()) < amount {
-		return nil, ErrorNoMoney
+	txIns, txOuts, err := selectInputs(from, need, selection)
+	if err != nil {
+		return nil, err
 	}
-	var txOuts []*TxOut
-	var txIns []*TxIn
-
-	txIn := &TxIn{sInfo.ID, indexes[0], from}
-	txIns = append(txIns, txIn)
-
-	txOut := &TxOut{to, amount}
-	txOuts = append(txOuts, txOut)
 	tx := &Tx{
 		ID:        "",
 		Timestamp: int(time.Now().Unix()),
 		TxIns:     txIns,
-		TxOuts:    txOuts,
+		TxOuts:    append(txOuts, outputs...),
 		InputData: inputData,
+		LockTime:  lockTime,
+		Kind:      kind,
+		Payload:   payload,
 	}
 	tx.getId()
-	tx.delegateSign()
-	valid := validate(tx)
-	if !valid {
-		return nil, ErrorNotValid
-	}
-	return tx, nil
-}
-
-// mempool에 트랜잭션을 추가
-func (m *mempool) AddTx(to string, amount int, inputData string, port string) (*Tx, error) {
-	tx, err := makeTx(wallet.Wallet(port).Address, to, amount, inputData, port)
-	if err != nil {
-		return nil, err
-	}
-	m.Txs[tx.ID] = tx
-	return tx, nil
-}
-
-// Unstaking을 위해, 해당 주소의 UTXO로 트랜잭션구성
-func (m *mempool) AddTxFromStakingAddress(from, to, inputData, mainPort string, amount int, sInfo *StakingInfo, indexes []int) (*Tx, error) {
-	tx, err := makeTxbyUTXO(from, to, inputData, mainPort, amount, sInfo, indexes)
-	if err != nil {
-		return nil, err
+	tx.sign(port)
+	if err := validate(tx); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrorNotValid, err)
 	}
-	m.Txs[tx.ID] = tx
 	return tx, nil
 }
 
-// 확인할 트랜잭션들을 반환
-func (m *mempool) TxToConfirm(port string, roleInfo *RoleInfo) []*Tx {
-	coinbase := makeCoinbaseTx(roleInfo)
-	var txs []*Tx
-	for _, tx := range m.Txs {
-		txs = append(txs, tx)
-	}
-	txs = append(txs, coinbase)
-	m.Txs = make(map[string]*Tx)
-	return txs
-}
-
-// 노드간 전파된 peer의 트랜잭션 추가
-func (m *mempool) AddPeerTx(tx *Tx) {
-	m.m.Lock()
-	defer m.m.Unlock()
-	m.Txs[tx.ID] = tx
-}
-
-// Unstaking 시, 락업 기간이 남아있는지 확인
-func CheckLockupPeriod(timeStamp int) (ok bool, gapTime int) {
-	gapTime = int(time.Now().Unix()) - timeStamp
-	if gapTime > MonthToSec { // 한달 락업 기간이 지났는지 확인
-		return true, 0
-	}
-	return false, gapTime - MonthToSec
-}
-
-// 검증자가 트랜잭션 검증 시 트랜잭션 비교
-func compareTransactions(txs1, txs2 []*Tx) bool {
-	if len(txs1) != len(txs2) {
-		return false
-	}
-	for i := range txs1 {
-		if !compareSingleTransaction(txs1[i], txs2[i]) {
-			return false
+// 주소의 UTXO 중 자산별 필요 수량(need)을 채울 입력을 선택 전략에 따라 고르고, 자산별 거스름돈 출력을 만듦
+// 멤풀의 트랜잭션이 사용하려는 출력과 시간 잠금이 풀리지 않은 출력은 입력으로 사용하지 않는다
+func selectInputs(from string, need amounts, selection CoinSelection) ([]*TxIn, []*TxOut, error) {
+	candidates := make(map[string][]*utxoEntry) // 자산별 사용 가능한 UTXO (체인 순서)
+	at := Blockchain().snapshot().nextLockContext()
+	for _, e := range utxosByAddress(from) {
+		if asset := e.Output.Asset; need[asset] != 0 && spendable(e, at) {
+			candidates[asset] = append(candidates[asset], e)
 		}
 	}
-	return true
-}
-
-// 검증자가 트랜잭션 검증 시 세부 트랜잭션 비교
-func compareSingleTransaction(tx1, tx2 *Tx) bool {
-	if tx1.InputData != tx2.InputData {
-		return false
-	}
-	if !compareTxIns(tx1.TxIns, tx2.TxIns) {
-		return false
-	}
-	if !compareTxOuts(tx1.TxOuts, tx2.TxOuts) {
-		return false
-	}
-	return true
-}
-
-// 검증자가 트랜잭션 검증 시 트랜잭션 Input 비교
-func compareTxIns(ins1, ins2 []*TxIn) bool {
-	if len(ins1) != len(ins2) {
-		return false
+	assets := make([]string, 0, len(need))
+	for asset := range need {
+		assets = append(assets, asset)
 	}
-	for i, in1 := range ins1 {
-		in2 := ins2[i]
-		if in1.TxID != in2.TxID || in1.Index != in2.Index || in1.Signature != in2.Signature {
-			return false
+	sort.Strings(assets) // 입력과 거스름돈 출력 순서 고정 (기본 코인이 먼저)
+	var txIns []*TxIn
+	var change []*TxOut
+	for _, asset := range assets {
+		chosen, ok := selection.choose(candidates[asset], need[asset])
+		if !ok {
+			if asset != NativeAsset {
+				return nil, nil, fmt.Errorf("%w: asset %s", ErrorNoMoney, asset)
+			}
+			return nil, nil, ErrorNoMoney
+		}
+		var total Amount // 고른 UTXO의 잔액 합
+		for _, e := range chosen {
+			var err error
+			if total, err = total.Add(e.Output.Amount); err != nil {
+				return nil, nil, err
+			}
+			txIns = append(txIns, &TxIn{TxID: e.TxID, Index: e.Index, Signature: from})
+		}
+		if c := total - need[asset]; c != 0 { // change가 0이 아니라면 거슬러줘야함
+			change = append(change, &TxOut{Address: from, Amount: c, Asset: asset})
 		}
 	}
-	return true
+	return txIns, change, nil
 }
 
-// 검증자가 트랜잭션 검증 시 트랜잭션 Output 비교
+// 트랜잭션 Output 목록 비교
 func compareTxOuts(outs1, outs2 []*TxOut) bool {
 	if len(outs1) != len(outs2) {
 		return false
 	}
 	for i, out1 := range outs1 {
 		out2 := outs2[i]
-		if out1.Address != out2.Address || out1.Amount != out2.Amount {
+		if out1.Address != out2.Address || out1.Amount != out2.Amount || !sameMultisig(out1.Multisig, out2.Multisig) || !sameTimeLock(out1.Lock, out2.Lock) || out1.Script != out2.Script || out1.Asset != out2.Asset {
 			return false
 		}
 	}
 	return true
 }
+
+// 두 다중서명 잠금 조건이 같은지 비교 (둘 다 없으면 같음)
+func sameMultisig(l1, l2 *MultisigLock) bool {
+	if l1 == nil || l2 == nil {
+		return l1 == l2
+	}
+	return bytes.Equal(l1.serialize(), l2.serialize())
+}
+
+// 두 시간 잠금이 같은지 비교 (둘 다 없으면 같음)
+func sameTimeLock(l1, l2 *TimeLock) bool {
+	if l1 == nil || l2 == nil {
+		return l1 == l2
+	}
+	return *l1 == *l2
+}
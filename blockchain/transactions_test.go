@@ -0,0 +1,161 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestMakeTxPaysFeeFromChange(t *testing.T) {
+	// 노드 지갑의 UTXO: 제안자 보상 50 두 개와 검증자 보상 10 두 개
+	tests := []struct {
+		name       string
+		amount     Amount
+		fee        Amount
+		wantChange Amount
+		want       error
+	}{
+		{"change is inputs minus outputs and fee", 20, 5, 50 - 25, nil},
+		{"exact input needs no change", 49, 1, 0, nil},
+		{"fee below the minimum", 20, MinTxFee - 1, 0, ErrFeeTooLow},
+		{"fee that does not fit in the balance", 120, 1, 0, ErrorNoMoney},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bc := newTestChain(t)
+			fund(t, bc)
+			tx, err := makeTx(KindTransfer, nil, nodeKey.address, []*TxOut{{Address: "ab", Amount: tt.amount}}, tt.fee, SelectLargestFirst, "", 0, nodeKey.port)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			if err != nil {
+				return
+			}
+			var in, out, change Amount
+			for _, txIn := range tx.TxIns {
+				in += findUTXO(txIn.TxID, txIn.Index).Output.Amount
+			}
+			for _, txOut := range tx.TxOuts {
+				out += txOut.Amount
+				if txOut.Address == nodeKey.address {
+					change += txOut.Amount
+				}
+			}
+			if in-out != tt.fee || change != tt.wantChange {
+				t.Fatalf("fee = %d, change = %d, want %d and %d", in-out, change, tt.fee, tt.wantChange)
+			}
+		})
+	}
+}
+
+func TestCoinbaseCollectsFees(t *testing.T) {
+	bc := newTestChain(t)
+	fund(t, bc)
+	for _, fee := range []Amount{2, 3} {
+		if _, err := Mempool().AddTx("ab", 1, fee, "", nodeKey.port); err != nil {
+			t.Fatal(err)
+		}
+	}
+	block := mine(t, bc, fromMempool)
+	coinbase := block.Transaction[len(block.Transaction)-1]
+	if !coinbase.isCoinbase() || coinbase.TxOuts[0].Address != block.RoleInfo.ProposerAddress {
+		t.Fatal("coinbase does not pay the proposer first")
+	}
+	if got, want := coinbase.TxOuts[0].Amount, proposalReward+5; got != want {
+		t.Fatalf("proposer reward = %d, want %d", got, want)
+	}
+	for _, txOut := range coinbase.TxOuts[1:] {
+		if txOut.Amount != validatorReward {
+			t.Fatalf("validator reward = %d, want %d", txOut.Amount, validatorReward)
+		}
+	}
+}
+
+func TestBlockFeesMustMatchCoinbase(t *testing.T) {
+	tests := []struct {
+		name  string
+		extra Amount // 코인베이스가 실제 수수료 합보다 더 받는 양
+		want  error
+	}{
+		{"coinbase claims the fees", 0, nil},
+		{"coinbase claims more than the fees", 1, ErrInvalidCoinbase},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bc := newTestChain(t)
+			fund(t, bc)
+			if _, err := Mempool().AddTx("ab", 1, 4, "", nodeKey.port); err != nil {
+				t.Fatal(err)
+			}
+			block := certifiedBlock(t, tipBlock(t, bc), 0, func(t *testing.T, roles *RoleInfo, height int) []*Tx {
+				txs := fromMempool(t, roles, height)
+				coinbase := txs[len(txs)-1]
+				coinbase.TxOuts[0].Amount += tt.extra
+				coinbase.getId()
+				return txs
+			})
+			if err := bc.ImportBlock(block); !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddTxOutsPaysEveryRecipientAtOnce(t *testing.T) {
+	pay := func(amounts ...Amount) []*TxOut {
+		outs := make([]*TxOut, len(amounts))
+		for i, v := range amounts {
+			outs[i] = &TxOut{Address: fmt.Sprintf("a%d", i), Amount: v}
+		}
+		return outs
+	}
+	tests := []struct {
+		name       string
+		outputs    []*TxOut
+		wantInputs int
+		wantChange Amount
+		want       error
+	}{
+		{"batch with change", pay(30, 30, 30), 2, 100 - 91, nil},
+		{"batch spending everything", pay(50, 50, 10, 9), 4, 0, nil},
+		{"one recipient", pay(5), 1, 50 - 6, nil},
+		{"no recipients", nil, 0, 0, ErrNoOutputs},
+		{"batch above the balance", pay(50, 50, 20), 0, 0, ErrorNoMoney},
+		{"batch total overflows", pay(math.MaxUint64, 1), 0, 0, ErrAmountOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bc := newTestChain(t)
+			fund(t, bc)
+			tx, err := Mempool().AddTxOuts(tt.outputs, MinTxFee, SelectLargestFirst, "payroll", 0, nodeKey.port)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			if err != nil {
+				return
+			}
+			if len(tx.TxIns) != tt.wantInputs {
+				t.Fatalf("inputs = %d, want %d", len(tx.TxIns), tt.wantInputs)
+			}
+			var changes []*TxOut
+			for _, txOut := range tx.TxOuts {
+				if txOut.Address == nodeKey.address {
+					changes = append(changes, txOut)
+				}
+			}
+			switch {
+			case tt.wantChange == 0 && len(changes) != 0:
+				t.Fatalf("change outputs = %d, want none", len(changes))
+			case tt.wantChange != 0 && (len(changes) != 1 || changes[0].Amount != tt.wantChange):
+				t.Fatalf("change outputs = %v, want one of %d", changes, tt.wantChange)
+			}
+			mine(t, bc, fromMempool)
+			for _, txOut := range tt.outputs {
+				if got := balance(t, bc, txOut.Address); got != txOut.Amount {
+					t.Fatalf("recipient %s balance = %d, want %d", txOut.Address, got, txOut.Amount)
+				}
+			}
+		})
+	}
+}
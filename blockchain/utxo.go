@@ -0,0 +1,96 @@
+package blockchain
+
+import (
+	"github.com/abcfe-op/abcfe-node/common/utils"
+	"github.com/abcfe-op/abcfe-node/db"
+
+	log "github.com/abcfe-op/abcfe-node/common/logger"
+)
+
+// UTXO 셋에 저장되는 출력 정보
+type utxoEntry struct {
+	TxID      string        // 출력을 만든 트랜잭션의 해시 값
+	Index     int           // 트랜잭션 내 출력 인덱스
+	Output    *TxOut        // 출력 내용
+	InputData string        // 출력을 만든 트랜잭션의 메모
+	Height    int           // 출력을 만든 블록의 높이
+	Timestamp int           // 출력을 만든 블록의 타임스탬프
+	Kind      TxKind        // 출력을 만든 트랜잭션의 종류
+	Stake     *StakePayload // 스테이킹 트랜잭션이 만든 출력이라면 스테이킹 페이로드
+}
+
+// API 응답에 사용하는 UTxOut 형태로 변환
+func (e *utxoEntry) uTxOut() *UTxOut {
+	return &UTxOut{e.TxID, e.Index, e.Output.Amount, e.InputData, e.Output.Asset}
+}
+
+// bytes 형태의 UTXO 정보 복구
+func restoreUTXO(data []byte) *utxoEntry {
+	e := &utxoEntry{}
+	if err := utils.FromBytes(e, data); err != nil {
+		log.Error(err)
+		return nil
+	}
+	return e
+}
+
+// 디비의 UTXO 셋에서 특정 출력 조회 (사용되었거나 존재하지 않으면 nil)
+func findUTXO(txID string, index int) *utxoEntry {
+	data := dbStorage.FindUTXO(outpoint(txID, index))
+	if data == nil {
+		return nil
+	}
+	return restoreUTXO(data)
+}
+
+// 디비의 UTXO 셋에서 특정 주소의 출력 전체 조회
+func utxosByAddress(address string) []*utxoEntry {
+	var entries []*utxoEntry
+	for _, data := range dbStorage.UTXOsByAddress(address) {
+		if e := restoreUTXO(data); e != nil && e.Output.Address == address {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// 블록이 만든 UTXO 목록
+func blockUTXOs(block *Block, tx *Tx) []*utxoEntry {
+	entries := make([]*utxoEntry, 0, len(tx.TxOuts))
+	var stake *StakePayload
+	if tx.Kind == KindStake {
+		stake = tx.Payload.Stake
+	}
+	for index, txOut := range tx.TxOuts {
+		entries = append(entries, &utxoEntry{
+			TxID:      tx.ID,
+			Index:     index,
+			Output:    txOut,
+			InputData: tx.InputData,
+			Height:    block.Height,
+			Timestamp: block.Timestamp,
+			Kind:      tx.Kind,
+			Stake:     stake,
+		})
+	}
+	return entries
+}
+
+// 블록을 UTXO 셋에 반영하는 변경 작업(사용된 출력 제거, 새 출력 추가)을 배치에 추가
+func stageUTXOs(batch *db.Batch, block *Block) {
+	for _, tx := range block.Transaction {
+		if !tx.isCoinbase() {
+			for _, txIn := range tx.TxIns {
+				batch.SpendUTXO(outpoint(txIn.TxID, txIn.Index))
+			}
+		}
+		for _, e := range blockUTXOs(block, tx) {
+			data, err := utils.ToBytes(e)
+			if err != nil {
+				log.Error(err)
+				continue
+			}
+			batch.AddUTXO(outpoint(e.TxID, e.Index), e.Output.Address, data)
+		}
+	}
+}
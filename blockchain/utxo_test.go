@@ -0,0 +1,147 @@
+package blockchain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/abcfe-op/abcfe-node/db"
+)
+
+// 블록 전체를 제네시스부터 다시 훑어 얻은 주소별 UTXO (디비의 UTXO 셋과 비교하는 기준)
+func rescanUTXOs(bc *blockchain) map[string]map[string]bool {
+	bc.m.Lock()
+	blocks := bc.blocks()
+	bc.m.Unlock()
+	s := newChainState()
+	for i := len(blocks) - 1; i >= 0; i-- {
+		s.connect(blocks[i])
+	}
+	owned := make(map[string]map[string]bool)
+	for key, e := range s.utxos {
+		if e == nil {
+			continue
+		}
+		if owned[e.Output.Address] == nil {
+			owned[e.Output.Address] = make(map[string]bool)
+		}
+		owned[e.Output.Address][key] = true
+	}
+	return owned
+}
+
+func assertUTXOSetMatchesRescan(t *testing.T, bc *blockchain) {
+	t.Helper()
+	for address, want := range rescanUTXOs(bc) {
+		entries := utxosByAddress(address)
+		if len(entries) != len(want) {
+			t.Fatalf("address %q has %d utxos, rescan found %d", address, len(entries), len(want))
+		}
+		for _, e := range entries {
+			if !want[outpoint(e.TxID, e.Index)] {
+				t.Fatalf("address %q has utxo %s that the rescan spent", address, outpoint(e.TxID, e.Index))
+			}
+			if findUTXO(e.TxID, e.Index) == nil {
+				t.Fatalf("utxo %s is not found by outpoint", outpoint(e.TxID, e.Index))
+			}
+		}
+	}
+}
+
+func TestUTXOSetFollowsConnectedBlocks(t *testing.T) {
+	bc := newTestChain(t)
+	mine(t, bc, coinbaseOnly)
+	before := balance(t, bc, nodeKey.address)
+	if before != validatorReward {
+		t.Fatalf("validator balance = %d, want %d", before, validatorReward)
+	}
+
+	tx, err := Mempool().AddTx("ab", 3, MinTxFee, "", nodeKey.port)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := balance(t, bc, nodeKey.address); got != 0 {
+		t.Fatalf("balance with the input on the mempool = %d, want 0", got)
+	}
+	block := mine(t, bc, fromMempool)
+	if got := balance(t, bc, "ab"); got != 3 {
+		t.Fatalf("recipient balance = %d, want 3", got)
+	}
+	for _, txIn := range tx.TxIns {
+		if findUTXO(txIn.TxID, txIn.Index) != nil {
+			t.Fatalf("spent output %s is still in the utxo set", outpoint(txIn.TxID, txIn.Index))
+		}
+	}
+	var earned Amount
+	for _, txOut := range block.Transaction[len(block.Transaction)-1].TxOuts {
+		if txOut.Address == nodeKey.address {
+			earned += txOut.Amount
+		}
+	}
+	if got, want := balance(t, bc, nodeKey.address), before-3-MinTxFee+earned; got != want {
+		t.Fatalf("sender balance = %d, want %d", got, want)
+	}
+	assertUTXOSetMatchesRescan(t, bc)
+}
+
+func TestReindexRebuildsUTXOSet(t *testing.T) {
+	bc := newTestChain(t)
+	mine(t, bc, coinbaseOnly)
+	if _, err := Mempool().AddTx("ab", 3, MinTxFee, "", nodeKey.port); err != nil {
+		t.Fatal(err)
+	}
+	mine(t, bc, fromMempool)
+	want := balance(t, bc, nodeKey.address)
+
+	batch := db.NewBatch()
+	batch.ClearUTXOs()
+	batch.SaveIndexTip(utxoTipName, "")
+	if err := dbStorage.Write(batch); err != nil {
+		t.Fatal(err)
+	}
+	if got := balance(t, bc, "ab"); got != 0 {
+		t.Fatalf("cleared utxo set still holds %d", got)
+	}
+	bc.m.Lock()
+	err := bc.reindex()
+	bc.m.Unlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := balance(t, bc, nodeKey.address); got != want {
+		t.Fatalf("balance after reindex = %d, want %d", got, want)
+	}
+	if got := balance(t, bc, "ab"); got != 3 {
+		t.Fatalf("recipient balance after reindex = %d, want 3", got)
+	}
+	assertUTXOSetMatchesRescan(t, bc)
+}
+
+func TestMempoolRejectsInvalidAddresses(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+	}{
+		{"empty", ""},
+		{"not hex", "xyz0"},
+		{"uppercase", "AB"},
+		{"odd length", "abc"},
+		{"too long", strings.Repeat("ab", maxAddressLength/2+1)},
+	}
+	bc := newTestChain(t)
+	mine(t, bc, coinbaseOnly)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Mempool().AddTx(tt.address, 1, MinTxFee, "", nodeKey.port)
+			if !errors.Is(err, ErrInvalidAddress) {
+				t.Fatalf("err = %v, want %v", err, ErrInvalidAddress)
+			}
+			if RejectReason(err) != RejectInvalid {
+				t.Fatalf("reject reason = %q, want %q", RejectReason(err), RejectInvalid)
+			}
+		})
+	}
+	if got := balance(t, bc, nodeKey.address); got != validatorReward {
+		t.Fatalf("rejected transactions locked the inputs: balance = %d", got)
+	}
+}
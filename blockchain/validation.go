@@ -0,0 +1,441 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/abcfe-op/abcfe-node/common/utils"
+	"github.com/abcfe-op/abcfe-node/wallet"
+)
+
+// 블록 임포트 과정에서 발생하는 에러
+var (
+	ErrNilBlock               = errors.New("empty block")
+	ErrShorterChain           = errors.New("received chain is not preferred over the local chain")
+	ErrInvalidGenesis         = errors.New("genesis block mismatch")
+	ErrPrevHashMismatch       = errors.New("previous hash does not match the chain tip")
+	ErrHeightMismatch         = errors.New("block height is not continuous")
+	ErrMissingRoleInfo        = errors.New("role info is missing")
+	ErrInvalidBlockSignature  = errors.New("invalid validator signature")
+	ErrNotEnoughSignatures    = errors.New("not enough validator signatures")
+	ErrMixedCommitRounds      = errors.New("validator signatures are not from a single round")
+	ErrInvalidCoinbase        = errors.New("invalid coinbase transaction")
+	ErrDuplicateTx            = errors.New("duplicate transaction in block")
+	ErrEmptyTx                = errors.New("transaction has no inputs or outputs")
+	ErrMalformedTx            = errors.New("transaction has an empty input or output")
+	ErrInvalidOutputAmount    = errors.New("output amount must be positive")
+	ErrInvalidAddress         = errors.New("output address must be lowercase hex")
+	ErrMissingInput           = errors.New("input references an unknown or spent output")
+	ErrDoubleSpend            = errors.New("output is spent twice")
+	ErrInvalidTxSignature     = errors.New("invalid transaction signature")
+	ErrOutputsExceedInputs    = errors.New("outputs exceed inputs")
+	ErrUnexpectedCoinbaseSpot = errors.New("coinbase transaction must be the last transaction")
+)
+
+// 주소 문자열의 최대 길이 (P-256 공개 키의 X, Y 좌표)
+const maxAddressLength = 128
+
+// 블록 검증 시 기준이 되는 체인 상태 (현재 최신 블록과 사용 가능한 UTXO)
+type chainState struct {
+	hash   string
+	height int
+	utxos  map[string]*utxoEntry                   // 메모리에 반영된 UTXO 변경분 (nil 값은 사용된 출력). key: "트랜잭션 해시:출력 인덱스"
+	base   func(txID string, index int) *utxoEntry // 변경분에 없는 UTXO를 조회하는 함수 (nil이면 변경분만 사용)
+}
+
+// UTXO를 가리키는 키 생성
+func outpoint(txID string, index int) string {
+	return fmt.Sprintf("%s:%d", txID, index)
+}
+
+// 비어있는 체인 상태 생성 (제네시스 블록부터 검증할 때 사용)
+func newChainState() *chainState {
+	return &chainState{utxos: make(map[string]*utxoEntry)}
+}
+
+// 사용 가능한 UTXO 조회
+func (s *chainState) lookup(txID string, index int) *utxoEntry {
+	if e, ok := s.utxos[outpoint(txID, index)]; ok {
+		return e
+	}
+	if s.base != nil {
+		return s.base(txID, index)
+	}
+	return nil
+}
+
+// 블록을 체인 상태에 반영: 입력으로 사용된 출력은 제거하고, 새 출력은 추가
+// 트랜잭션마다 입력으로 사용된 출력 목록을 반환 (코인베이스는 nil)
+func (s *chainState) connect(block *Block) [][]*utxoEntry {
+	spent := make([][]*utxoEntry, len(block.Transaction))
+	for i, tx := range block.Transaction {
+		if !tx.isCoinbase() {
+			for _, txIn := range tx.TxIns {
+				if e := s.lookup(txIn.TxID, txIn.Index); e != nil {
+					spent[i] = append(spent[i], e)
+				}
+				s.utxos[outpoint(txIn.TxID, txIn.Index)] = nil
+			}
+		}
+		for _, e := range blockUTXOs(block, tx) {
+			s.utxos[outpoint(e.TxID, e.Index)] = e
+		}
+	}
+	s.hash = block.Hash
+	s.height = block.Height
+	return spent
+}
+
+// 코인베이스 트랜잭션 여부
+func (t *Tx) isCoinbase() bool {
+	return t.Kind == KindCoinbase
+}
+
+// 블록 검증: 검증자 서명을 제외한 블록 내용과 검증자 서명을 차례로 확인. 역할 정보는 서명이 커밋된 라운드의 것이어야 함
+func validateBlock(block *Block, state *chainState) error {
+	round, err := commitRound(block)
+	if err != nil {
+		return err
+	}
+	if err := validateUnsigned(block, state, round); err != nil {
+		return err
+	}
+	if block.Height == genesisHeight {
+		return nil
+	}
+	return validateBlockSignatures(block, round)
+}
+
+// 검증자 서명을 제외한 블록 검증: 해시, 체인 연결, 라운드의 역할 정보, 선출 무작위 값, 코인베이스, 트랜잭션 서명과 이중지불을 차례로 확인
+func validateUnsigned(block *Block, state *chainState, round int) error {
+	if block == nil {
+		return ErrNilBlock
+	}
+	if err := block.verifyHash(); err != nil {
+		return err
+	}
+	if block.Height == genesisHeight {
+		if block.Hash != createGenesisBlock().Hash {
+			return fmt.Errorf("%w: %s", ErrInvalidGenesis, block.Hash)
+		}
+		return nil
+	}
+	if block.PrevHash != state.hash {
+		return fmt.Errorf("%w: %s", ErrPrevHashMismatch, block.PrevHash)
+	}
+	if block.Height != state.height+1 {
+		return fmt.Errorf("%w: expected %d, got %d", ErrHeightMismatch, state.height+1, block.Height)
+	}
+	if mtp := medianTimePast(block.PrevHash); block.Timestamp < mtp {
+		return fmt.Errorf("%w: %d < %d", ErrBlockTimeTooOld, block.Timestamp, mtp)
+	}
+	// 중간 시간은 타임스탬프의 하한만 정하므로, 미래의 타임스탬프로 시간 잠금을 앞당기지 못하도록 상한도 둠
+	if limit := int(time.Now().Unix()) + maxFutureDrift; block.Timestamp > limit {
+		return fmt.Errorf("%w: %d > %d", ErrBlockTimeTooNew, block.Timestamp, limit)
+	}
+	if block.RoleInfo == nil {
+		return ErrMissingRoleInfo
+	}
+	if err := validateRoleInfo(block, state, round); err != nil {
+		return err
+	}
+	if err := validateRandao(block); err != nil {
+		return err
+	}
+	return validateTransactions(block, state)
+}
+
+// 합의 라운드에 제안된 블록을 현재 최신 블록에 이어 붙일 수 있는지 검증 (검증자 서명은 커밋할 때 붙으므로 제외)
+func (b *blockchain) ValidateProposal(block *Block, round int) error {
+	b.m.Lock()
+	defer b.m.Unlock()
+	return validateUnsigned(block, b.tipState(), round)
+}
+
+// 검증자 자리 수에 대한 정족수 (2/3 초과). 비잔틴 검증자가 1/3 미만이라면 같은 높이에서 두 블록이 정족수를 얻을 수 없다
+func Quorum(seats int) int {
+	return seats*2/3 + 1
+}
+
+// 블록이 커밋된 라운드. 검증자 서명은 모두 같은 라운드의 사전 커밋이어야 함 (서명이 없다면 0)
+func commitRound(block *Block) (int, error) {
+	round := -1
+	for _, sig := range block.Signature {
+		if sig == nil {
+			continue
+		}
+		if sig.Round < 0 || (round != -1 && sig.Round != round) {
+			return 0, fmt.Errorf("%w: %s", ErrMixedCommitRounds, block.Hash)
+		}
+		round = sig.Round
+	}
+	return max(round, 0), nil
+}
+
+// 검증자 서명 검증: 역할 정보에 기록된 검증자 자리의 정족수가 커밋 라운드의 커밋 값에 유효한 서명을 남겨야 함
+func validateBlockSignatures(block *Block, round int) error {
+	if block.RoleInfo == nil || len(block.RoleInfo.ValidatorAddress) == 0 {
+		return ErrMissingRoleInfo
+	}
+	signed := make(map[string]bool)
+	for _, sig := range block.Signature {
+		if sig == nil {
+			continue
+		}
+		if !contains(block.RoleInfo.ValidatorAddress, sig.Address) || !wallet.Verify(sig.Signature, CommitPayload(block.Height, round, block.Hash), sig.Address) {
+			return fmt.Errorf("%w: %s", ErrInvalidBlockSignature, sig.Address)
+		}
+		signed[sig.Address] = true
+	}
+	count := 0
+	for _, address := range block.RoleInfo.ValidatorAddress {
+		if signed[address] {
+			count++
+		}
+	}
+	if count < Quorum(len(block.RoleInfo.ValidatorAddress)) {
+		return fmt.Errorf("%w: %d of %d", ErrNotEnoughSignatures, count, len(block.RoleInfo.ValidatorAddress))
+	}
+	return nil
+}
+
+// 블록내 트랜잭션 검증. 블록 앞쪽 트랜잭션의 출력은 뒤쪽 트랜잭션이 사용할 수 있다
+func validateTransactions(block *Block, state *chainState) error {
+	if len(block.Transaction) == 0 {
+		return ErrInvalidCoinbase
+	}
+	seen := make(map[string]bool)
+	spent := make(map[string]bool)
+	var fees Amount
+	created := make(map[string]*utxoEntry)
+	at := lockContext{height: block.Height, medianTime: medianTimePast(block.PrevHash)}
+	last := len(block.Transaction) - 1
+	for i, tx := range block.Transaction {
+		if seen[tx.ID] {
+			return fmt.Errorf("%w: %s", ErrDuplicateTx, tx.ID)
+		}
+		seen[tx.ID] = true
+		if tx.isCoinbase() {
+			if i != last {
+				return fmt.Errorf("%w: %s", ErrUnexpectedCoinbaseSpot, tx.ID)
+			}
+			if err := validateCoinbase(tx, block.RoleInfo, block.Height, fees); err != nil {
+				return err
+			}
+			continue
+		}
+		if i == last {
+			return fmt.Errorf("%w: missing", ErrInvalidCoinbase)
+		}
+		lookup := func(txID string, index int) *utxoEntry {
+			if e, ok := created[outpoint(txID, index)]; ok {
+				return e
+			}
+			return state.lookup(txID, index)
+		}
+		fee, err := validateTx(tx, lookup, spent, at)
+		if err != nil {
+			return err
+		}
+		if fees, err = fees.Add(fee); err != nil {
+			return fmt.Errorf("%w: %s", err, tx.ID)
+		}
+		for _, e := range blockUTXOs(block, tx) {
+			created[outpoint(e.TxID, e.Index)] = e
+		}
+	}
+	return nil
+}
+
+// 코인베이스 검증: 입력은 COINBASE 하나이고, 페이로드에 블록 높이가 기록되어 있어야 하며, 제안자 보상(블록 수수료 합 포함)과 검증자 보상만을 정해진 순서로 지급해야 함
+func validateCoinbase(tx *Tx, roleInfo *RoleInfo, height int, fees Amount) error {
+	if err := tx.checkPayload(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidCoinbase, err)
+	}
+	if len(tx.TxIns) != 1 || tx.TxIns[0] == nil || tx.TxIns[0].TxID != "" || tx.TxIns[0].Index != -1 || tx.TxIns[0].Signature != "COINBASE" {
+		return fmt.Errorf("%w: %s", ErrInvalidCoinbase, tx.ID)
+	}
+	if len(roleInfo.ValidatorAddress) == 0 || tx.Payload.Coinbase.Height != height {
+		return fmt.Errorf("%w: %s", ErrInvalidCoinbase, tx.ID)
+	}
+	expected, err := coinbaseOutputs(roleInfo, fees)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidCoinbase, err)
+	}
+	if !compareTxOuts(tx.TxOuts, expected) {
+		return fmt.Errorf("%w: %s", ErrInvalidCoinbase, tx.ID)
+	}
+	return nil
+}
+
+// 일반 트랜잭션 검증: 입력이 사용 가능한 UTXO를 가리키는지, 소유자의 서명이 유효한지, 종류별 규칙을 지키는지, 자산별 입출력 합이 맞는지 확인
+// spent에는 같은 블록에서 이미 사용된 UTXO가 기록된다. 기본 코인의 입력 합 - 출력 합(수수료)을 반환
+func validateTx(tx *Tx, lookup func(txID string, index int) *utxoEntry, spent map[string]bool, at lockContext) (Amount, error) {
+	if len(tx.TxIns) == 0 || len(tx.TxOuts) == 0 {
+		return 0, fmt.Errorf("%w: %s", ErrEmptyTx, tx.ID)
+	}
+	for _, txIn := range tx.TxIns {
+		if txIn == nil {
+			return 0, fmt.Errorf("%w: %s", ErrMalformedTx, tx.ID)
+		}
+		if err := validateWitness(txIn.Witness); err != nil {
+			return 0, fmt.Errorf("%w: %s", err, tx.ID)
+		}
+	}
+	for _, txOut := range tx.TxOuts {
+		if txOut == nil {
+			return 0, fmt.Errorf("%w: %s", ErrMalformedTx, tx.ID)
+		}
+	}
+	if tx.isCoinbase() { // 코인베이스 트랜잭션은 블록 마지막 자리에서만 허용
+		return 0, fmt.Errorf("%w: %s", ErrUnexpectedCoinbaseSpot, tx.ID)
+	}
+	if tx.ID != tx.calculateID() {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidTxID, tx.ID)
+	}
+	if err := tx.checkFinal(at); err != nil {
+		return 0, fmt.Errorf("%w: %s", err, tx.ID)
+	}
+	if err := tx.checkPayload(); err != nil {
+		return 0, err
+	}
+	inputs := make(amounts) // 자산별 입력 합
+	prevs := make([]*utxoEntry, 0, len(tx.TxIns))
+	scriptCost := 0 // 모든 입력의 스크립트 실행 비용 합
+	for _, txIn := range tx.TxIns {
+		key := outpoint(txIn.TxID, txIn.Index)
+		if spent[key] {
+			return 0, fmt.Errorf("%w: %s", ErrDoubleSpend, key)
+		}
+		prev := lookup(txIn.TxID, txIn.Index)
+		if prev == nil {
+			return 0, fmt.Errorf("%w: %s", ErrMissingInput, key)
+		}
+		prevOut := prev.Output
+		if prevOut.Lock != nil && tx.Kind != KindSlash { // 몰수는 스테이킹 출력의 락업 기간과 관계없이 가능
+			if err := prevOut.Lock.check(prev, at); err != nil {
+				return 0, fmt.Errorf("%w: %s", err, key)
+			}
+		}
+		if isStakeEntry(prev) {
+			// 스테이킹 출력은 스테이커의 서명으로 돌려받으며, 몰수는 서명 대신 이중 서명 증거로 검증 (validateSlash)
+			if tx.Kind != KindSlash && !wallet.Verify(txIn.Signature, tx.ID, prev.Stake.Staker) {
+				return 0, fmt.Errorf("%w: %s", ErrInvalidTxSignature, tx.ID)
+			}
+		} else if prevOut.Script != "" {
+			cost, err := runScript(prevOut.Script, txIn.Witness, tx.ID, prev, at, nil)
+			if err != nil {
+				return 0, fmt.Errorf("%w: %s", err, key)
+			}
+			if scriptCost += cost; scriptCost > maxTxScriptCost {
+				return 0, fmt.Errorf("%w: %d > %d in %s", ErrScriptCost, scriptCost, maxTxScriptCost, tx.ID)
+			}
+		} else if prevOut.Multisig != nil {
+			if err := verifyMultisig(txIn.Signatures, tx.ID, prevOut.Multisig); err != nil {
+				return 0, fmt.Errorf("%w: %s", err, tx.ID)
+			}
+		} else if !wallet.Verify(txIn.Signature, tx.ID, prevOut.Address) {
+			return 0, fmt.Errorf("%w: %s", ErrInvalidTxSignature, tx.ID)
+		}
+		spent[key] = true
+		if err := inputs.add(prevOut.Asset, prevOut.Amount); err != nil {
+			return 0, fmt.Errorf("%w: %s", err, tx.ID)
+		}
+		prevs = append(prevs, prev)
+	}
+	outputs := make(amounts) // 자산별 출력 합
+	for _, txOut := range tx.TxOuts {
+		if txOut.Amount == 0 {
+			return 0, fmt.Errorf("%w: %s", ErrInvalidOutputAmount, tx.ID)
+		}
+		if !isValidAddress(txOut.Address) {
+			return 0, fmt.Errorf("%w: %s", ErrInvalidAddress, tx.ID)
+		}
+		if err := validateOutputLock(txOut); err != nil {
+			return 0, fmt.Errorf("%w: %s", err, tx.ID)
+		}
+		if err := validateStakeOutput(txOut); err != nil {
+			return 0, fmt.Errorf("%w: %s", err, tx.ID)
+		}
+		if err := outputs.add(txOut.Asset, txOut.Amount); err != nil {
+			return 0, fmt.Errorf("%w: %s", err, tx.ID)
+		}
+	}
+	if err := validateKind(tx, prevs); err != nil {
+		return 0, err
+	}
+	if err := checkAssetConservation(tx, inputs, outputs); err != nil {
+		return 0, err
+	}
+	return inputs[NativeAsset].Sub(outputs[NativeAsset])
+}
+
+// 주소는 공개 키 또는 잠금 조건 해시의 소문자 16진수 문자열 (최대 공개 키 길이)
+func isValidAddress(address string) bool {
+	if address == "" || len(address) > maxAddressLength || len(address)%2 != 0 {
+		return false
+	}
+	for _, c := range address {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// 잠금 조건이 있는 출력은 조건이 올바르고, 스크립트나 다중서명 출력의 주소는 조건에서 만든 주소와 같아야 함
+func validateOutputLock(txOut *TxOut) error {
+	if txOut.Lock != nil {
+		if err := txOut.Lock.validate(); err != nil {
+			return err
+		}
+	}
+	if txOut.Script != "" {
+		if txOut.Multisig != nil {
+			return ErrScriptWithMultisig
+		}
+		if err := validateScript(txOut.Script); err != nil {
+			return err
+		}
+		if txOut.Address != ScriptAddress(txOut.Script) {
+			return ErrScriptAddress
+		}
+		return nil
+	}
+	if txOut.Multisig == nil {
+		return nil
+	}
+	if err := txOut.Multisig.validate(); err != nil {
+		return err
+	}
+	if txOut.Address != txOut.Multisig.Address() {
+		return ErrMultisigAddress
+	}
+	return nil
+}
+
+// 스테이킹 풀로 보내는 출력은 기본 코인이어야 하며, 포함된 블록으로부터 락업 기간 이상 시간 잠금이 걸려 있어야 함
+func validateStakeOutput(txOut *TxOut) error {
+	if txOut.Address != utils.StakingAddress {
+		return nil
+	}
+	if txOut.Asset != NativeAsset {
+		return ErrStakeAsset
+	}
+	l := txOut.Lock
+	if l == nil || l.Kind != LockByTime || !l.Relative || l.Value < stakingLockup {
+		return ErrStakeNotLocked
+	}
+	return nil
+}
+
+// 문자열 슬라이스에 특정 값이 있는지 확인
+func contains(list []string, target string) bool {
+	for _, item := range list {
+		if item == target {
+			return true
+		}
+	}
+	return false
+}
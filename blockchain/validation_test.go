@@ -0,0 +1,135 @@
+package blockchain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestImportBlockValidatesPeerBlocks(t *testing.T) {
+	// 블록을 고친 뒤 해시를 다시 계산하고 정족수의 서명을 다시 붙임 (서명만으로는 잘못된 내용을 숨길 수 없음을 확인)
+	resign := func(block *Block) {
+		block.seal()
+		signBlock(block, 0, Quorum(len(block.RoleInfo.ValidatorAddress)))
+	}
+	// 선출되지 않은 제네시스 검증자 (검증자도 제안자도 아님)
+	outsider := func(roles *RoleInfo) *testKey {
+		for _, v := range genesisValidators {
+			if v.Address != roles.ProposerAddress && !contains(roles.ValidatorAddress, v.Address) {
+				return testKeys[v.Address]
+			}
+		}
+		return nil
+	}
+	tests := []struct {
+		name   string
+		mutate func(block, genesis *Block)
+		want   error
+	}{
+		{"valid block", func(block, genesis *Block) {}, nil},
+		{"block hash does not match the header", func(block, genesis *Block) { block.Timestamp++ }, ErrInvalidBlockHash},
+		{"wrong height", func(block, genesis *Block) { block.Height++; resign(block) }, ErrHeightMismatch},
+		{"timestamp before the median time past", func(block, genesis *Block) {
+			block.Timestamp = genesis.Timestamp - 1
+			resign(block)
+		}, ErrBlockTimeTooOld},
+		{"timestamp too far in the future", func(block, genesis *Block) {
+			block.Timestamp = int(time.Now().Unix()) + maxFutureDrift + 60
+			resign(block)
+		}, ErrBlockTimeTooNew},
+		{"missing role info", func(block, genesis *Block) {
+			block.RoleInfo = nil
+			block.seal()
+		}, ErrMissingRoleInfo},
+		{"proposer was not elected", func(block, genesis *Block) {
+			key := outsider(block.RoleInfo)
+			block.RoleInfo.ProposerAddress, block.RoleInfo.ProposerPort = key.address, key.port
+			revealBy(block, genesis, key)
+			resign(block)
+		}, ErrRoleInfoMismatch},
+		{"randao revealed by another key", func(block, genesis *Block) {
+			revealBy(block, genesis, outsider(block.RoleInfo))
+			resign(block)
+		}, ErrInvalidRandao},
+		{"randao mix not derived from the reveal", func(block, genesis *Block) {
+			block.RandaoMix = genesis.RandaoMix
+			resign(block)
+		}, ErrInvalidRandao},
+		{"coinbase pays more than the reward", func(block, genesis *Block) {
+			coinbase := block.Transaction[len(block.Transaction)-1]
+			coinbase.TxOuts[0].Amount++
+			coinbase.getId()
+			resign(block)
+		}, ErrInvalidCoinbase},
+		{"too few validator signatures", func(block, genesis *Block) {
+			signBlock(block, 0, Quorum(len(block.RoleInfo.ValidatorAddress))-1)
+		}, ErrNotEnoughSignatures},
+		{"signatures from different rounds", func(block, genesis *Block) {
+			key := testKeys[block.Signature[0].Address]
+			block.Signature[0] = commitSignature(block, 1, key)
+		}, ErrMixedCommitRounds},
+		{"signature over another round's commit value", func(block, genesis *Block) {
+			key := testKeys[block.Signature[0].Address]
+			block.Signature[0].Signature = key.sign(CommitPayload(block.Height, 1, block.Hash))
+		}, ErrInvalidBlockSignature},
+		{"role info of another round than the commit", func(block, genesis *Block) {
+			signBlock(block, 2, Quorum(len(block.RoleInfo.ValidatorAddress)))
+		}, ErrRoleInfoMismatch},
+		{"signature from outside the committee", func(block, genesis *Block) {
+			block.Signature = append(block.Signature, commitSignature(block, 0, outsider(block.RoleInfo)))
+		}, ErrInvalidBlockSignature},
+		{"signature over the bare block hash", func(block, genesis *Block) {
+			key := testKeys[block.Signature[0].Address]
+			block.Signature[0].Signature = key.sign(block.Hash)
+		}, ErrInvalidBlockSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bc := newTestChain(t)
+			genesis := tipBlock(t, bc)
+			block := certifiedBlock(t, genesis, 0, coinbaseOnly)
+			tt.mutate(block, genesis)
+			err := bc.ImportBlock(block)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			if tt.want == nil {
+				if bc.NewestHash != block.Hash {
+					t.Fatal("valid block was not connected")
+				}
+				return
+			}
+			if bc.NewestHash != genesis.Hash {
+				t.Fatal("invalid block moved the tip")
+			}
+			if _, err := FindBlock(block.Hash); !errors.Is(err, ErrNotFound) {
+				t.Fatal("invalid block was stored")
+			}
+		})
+	}
+}
+
+func TestImportBlockAcceptsLaterRounds(t *testing.T) {
+	bc := newTestChain(t)
+	block := certifiedBlock(t, tipBlock(t, bc), 2, coinbaseOnly)
+	if err := bc.ImportBlock(block); err != nil {
+		t.Fatalf("block of round 2 rejected: %v", err)
+	}
+}
+
+func TestValidateProposalSkipsSignatures(t *testing.T) {
+	bc := newTestChain(t)
+	genesis := tipBlock(t, bc)
+	block := certifiedBlock(t, genesis, 0, coinbaseOnly)
+	block.Signature = nil
+	if err := bc.ValidateProposal(block, 0); err != nil {
+		t.Fatalf("unsigned proposal rejected: %v", err)
+	}
+	if err := bc.ValidateProposal(block, 2); !errors.Is(err, ErrRoleInfoMismatch) {
+		t.Fatalf("proposal in a round with another proposer: err = %v, want %v", err, ErrRoleInfoMismatch)
+	}
+	mine(t, bc, coinbaseOnly)
+	if err := bc.ValidateProposal(block, 0); !errors.Is(err, ErrPrevHashMismatch) {
+		t.Fatalf("proposal on a stale tip: err = %v, want %v", err, ErrPrevHashMismatch)
+	}
+}
@@ -14,7 +14,13 @@ func usage() {
 	fmt.Printf("Welcome to 민석's Blockchain Project\n\n")
 	fmt.Printf("Please use the following flags:\n\n")
 	fmt.Printf("-port:	Set the PORT of the server\n")
-	fmt.Printf("-mode:	Choose between 'auto' and 'rest'\n")
+	fmt.Printf("-mode:	Choose between 'auto', 'rest' and 'swap'\n")
+	fmt.Printf("	swap: -step=initiate -node -recipient -amount [-timeout]\n")
+	fmt.Printf("	      -step=participate -node -recipient -amount -hash [-timeout]\n")
+	fmt.Printf("	      -step=audit -role -node -htlc -hash -amount [-timeout] [-wait]\n")
+	fmt.Printf("	      -step=redeem -role -node -htlc -secret -amount [-timeout] [-wait]\n")
+	fmt.Printf("	      -step=extract -node -htlc [-wait]\n")
+	fmt.Printf("	      -step=refund -node -htlc\n")
 	os.Exit(0)
 }
 
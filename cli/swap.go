@@ -0,0 +1,351 @@
+package cli
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abcfe-op/abcfe-node/blockchain"
+	"github.com/abcfe-op/abcfe-node/common/utils"
+)
+
+// 서로 다른 체인(A, B)의 두 사람이 HTLC로 코인을 맞바꾸는 스왑 도우미 (-mode=swap -step=...)
+// 개시자는 체인 A의 코인을, 참여자는 체인 B의 코인을 내놓는다. 각 단계는 실행하는 사람의 노드 하나(-node)에만 요청하며,
+// 단계 사이에 필요한 값(해시, HTLC 출력, 비밀 값)은 출력된 값을 상대방에게 직접 전달한다
+//  1. initiate (개시자, 체인 A): 비밀 값을 만들고 참여자에게 HTLC를 건다 (기한: 2 * timeout 블록)
+//  2. audit -role=participant (참여자, 체인 A): 개시자의 HTLC가 약속한 조건인지 확인한다
+//  3. participate (참여자, 체인 B): 같은 해시로 개시자에게 HTLC를 건다 (기한: timeout 블록)
+//  4. audit 후 redeem -role=initiator (개시자, 체인 B): 비밀 값을 공개하며 코인을 가져간다
+//  5. extract (참여자, 체인 B): 공개된 비밀 값을 읽는다
+//  6. redeem -role=participant (참여자, 체인 A): 그 비밀 값으로 코인을 가져간다
+//
+// 상대방이 응하지 않으면 각자 기한이 지난 뒤 refund로 돌려받는다
+// audit은 금액과 해시뿐 아니라 기한도 확인한다. 개시자의 HTLC가 참여자의 것보다 충분히 늦게 만료되지 않으면,
+// 개시자가 참여자의 HTLC를 가져가며 비밀 값을 공개한 뒤 참여자가 그 값을 쓰기 전에 자기 HTLC를 돌려받을 수 있다
+var (
+	swapStep      = flag.String("step", "", "swap: initiate, participate, audit, redeem, extract or refund")
+	swapRole      = flag.String("role", "", "swap: your role, initiator or participant (audit, redeem)")
+	swapNode      = flag.String("node", "", "swap: REST URL of your own node on the chain of this step")
+	swapRecipient = flag.String("recipient", "", "swap: counterparty's wallet address on this chain (initiate, participate)")
+	swapAmount    = flag.Uint64("amount", 0, "swap: amount to lock (initiate, participate) or to expect (audit, redeem)")
+	swapHash      = flag.String("hash", "", "swap: hash of the secret from the initiator (participate, audit)")
+	swapSecret    = flag.String("secret", "", "swap: secret revealing the hash (redeem)")
+	swapHTLC      = flag.String("htlc", "", "swap: HTLC output as txid:index (audit, redeem, extract, refund)")
+	swapTimeout   = flag.Int("timeout", 20, "swap: blocks until the participant's HTLC can be refunded (the initiator's waits twice as long)")
+	swapWait      = flag.Duration("wait", 10*time.Minute, "swap: how long to wait for an HTLC to be confirmed or claimed")
+)
+
+const (
+	swapPollInterval = 3 * time.Second
+	swapSlack        = 3 // audit에서 기한을 확인할 때 허용하는 블록 수 (HTLC가 담긴 뒤 확인하기까지 늘어난 높이)
+)
+
+// 스왑 참가자의 역할
+const (
+	swapInitiator   = "initiator"
+	swapParticipant = "participant"
+)
+
+var (
+	errSwapTimeout = errors.New("timed out waiting for the swap step")
+	errSwapTerms   = errors.New("HTLC does not match the agreed terms")
+)
+
+// 스왑 중 노드가 응답한 HTLC 생성 결과
+type swapLock struct {
+	Tx      *blockchain.Tx `json:"tx"`
+	Index   int            `json:"index"`
+	Address string         `json:"address"`
+}
+
+// 스왑의 한 단계를 실행 (자기 노드 하나에만 요청)
+func Swap() error {
+	if *swapNode == "" {
+		return errors.New("-node is required")
+	}
+	switch *swapStep {
+	case "initiate":
+		return swapInitiate()
+	case "participate":
+		return swapParticipate()
+	case "audit":
+		counterparty, err := swapCounterparty()
+		if err != nil {
+			return err
+		}
+		_, err = swapAudit(counterparty)
+		return err
+	case "redeem":
+		return swapRedeem()
+	case "extract":
+		return swapExtract()
+	case "refund":
+		return swapRefund()
+	}
+	return fmt.Errorf("unknown swap step %q", *swapStep)
+}
+
+// 개시자: 비밀 값을 만들고 상대방에게 HTLC를 건다. 비밀 값은 redeem 전까지 공개하지 않는다
+func swapInitiate() error {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return err
+	}
+	hash := utils.HashBytes(secret) // 다른 체인과 같도록 16진수 문자열이 아닌 바이트를 해시
+	lock, timeout, err := swapLockHTLC(hash, 2**swapTimeout)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("secret:  %s (keep it private until you redeem)\n", hex.EncodeToString(secret))
+	fmt.Printf("hash:    %s\n", hash)
+	fmt.Printf("htlc:    %s:%d (refundable after height %d)\n", lock.Tx.ID, lock.Index, timeout)
+	return nil
+}
+
+// 참여자: 개시자의 해시로 상대방에게 HTLC를 건다 (개시자의 HTLC를 audit으로 확인한 뒤 실행)
+func swapParticipate() error {
+	if _, err := hex.DecodeString(*swapHash); err != nil || len(*swapHash) != 64 {
+		return errors.New("-hash must be the initiator's SHA-256 hash")
+	}
+	lock, timeout, err := swapLockHTLC(*swapHash, *swapTimeout)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("htlc:    %s:%d (refundable after height %d)\n", lock.Tx.ID, lock.Index, timeout)
+	return nil
+}
+
+// 노드의 지갑에서 recipient에게 현재 높이 + blocks를 기한으로 HTLC를 건다
+func swapLockHTLC(hash string, blocks int) (*swapLock, int, error) {
+	if *swapRecipient == "" || *swapAmount == 0 || *swapTimeout <= 0 {
+		return nil, 0, errors.New("-recipient, -amount and -timeout are required")
+	}
+	height, err := chainHeight(*swapNode)
+	if err != nil {
+		return nil, 0, err
+	}
+	timeout := height + blocks
+	lock := &swapLock{}
+	payload := map[string]interface{}{"recipient": *swapRecipient, "hash": hash, "timeout": timeout, "amount": *swapAmount}
+	if err := swapRequest("POST", *swapNode+"/htlc", payload, lock); err != nil {
+		return nil, 0, err
+	}
+	return lock, timeout, nil
+}
+
+// -role로 정한 자기 역할의 상대방 역할
+func swapCounterparty() (string, error) {
+	switch *swapRole {
+	case swapInitiator:
+		return swapParticipant, nil
+	case swapParticipant:
+		return swapInitiator, nil
+	}
+	return "", errors.New("-role must be initiator or participant")
+}
+
+// counterparty가 건 HTLC가 약속한 조건인지 확인
+// 두 체인의 높이는 비교할 수 없으므로, 기한은 남은 블록 수를 약속한 timeout과 비교한다
+//   - 개시자의 HTLC: 2 * timeout 블록 가까이 남아 참여자의 HTLC(timeout 블록)보다 충분히 늦게 만료되어야 함
+//   - 참여자의 HTLC: timeout 블록 이하로 남아 개시자의 HTLC보다 먼저 만료되되, 가져갈 시간은 남아야 함
+func swapAudit(counterparty string) (*blockchain.HTLCStatus, error) {
+	status, height, err := swapCheckHTLC()
+	if err != nil {
+		return nil, err
+	}
+	remaining := status.Timeout - height
+	switch counterparty {
+	case swapInitiator:
+		if need := 2**swapTimeout - swapSlack; remaining < need {
+			return nil, fmt.Errorf("%w: refundable in %d blocks, need at least %d", errSwapTerms, remaining, need)
+		}
+	case swapParticipant:
+		if limit := *swapTimeout + swapSlack; remaining > limit || remaining <= swapSlack {
+			return nil, fmt.Errorf("%w: refundable in %d blocks, need between %d and %d", errSwapTerms, remaining, swapSlack+1, limit)
+		}
+	default:
+		return nil, fmt.Errorf("unknown swap role %q", counterparty)
+	}
+	fmt.Printf("htlc %s:%d pays %d to %s, refundable by %s after height %d (now %d)\n", status.TxID, status.Index, status.Amount, status.Recipient, status.Sender, status.Timeout, height)
+	return status, nil
+}
+
+// 상대방의 HTLC가 블록에 담기기를 기다린 뒤, 아직 열려 있고 받는 쪽이 노드의 지갑이며 해시와 금액이 약속한 값인지 확인. 현재 높이도 함께 반환
+func swapCheckHTLC() (*blockchain.HTLCStatus, int, error) {
+	if *swapHash == "" || *swapAmount == 0 || *swapTimeout <= 0 {
+		return nil, 0, errors.New("-hash, -amount and -timeout are required")
+	}
+	status, err := swapWaitHTLC(func(s *blockchain.HTLCStatus) bool { return s.State != blockchain.HTLCPending })
+	if err != nil {
+		return nil, 0, err
+	}
+	address, err := walletAddress(*swapNode)
+	if err != nil {
+		return nil, 0, err
+	}
+	if status.State != blockchain.HTLCOpen {
+		return nil, 0, fmt.Errorf("%w: already %s", errSwapTerms, status.State)
+	}
+	if status.Recipient != address || status.Hash != *swapHash || status.Amount != blockchain.Amount(*swapAmount) {
+		return nil, 0, errSwapTerms
+	}
+	height, err := chainHeight(*swapNode)
+	if err != nil {
+		return nil, 0, err
+	}
+	return status, height, nil
+}
+
+// 비밀 값을 공개하며 상대방의 HTLC를 가져감
+func swapRedeem() error {
+	if *swapSecret == "" {
+		return errors.New("-secret is required")
+	}
+	secret, err := hex.DecodeString(*swapSecret)
+	if err != nil {
+		return errors.New("-secret must be hex")
+	}
+	*swapHash = utils.HashBytes(secret)
+	counterparty, err := swapCounterparty()
+	if err != nil {
+		return err
+	}
+	// 개시자는 참여자의 HTLC 기한을 다시 확인하고, 참여자는 이미 audit에서 확인했으므로 아직 돌려받을 수 없는지만 확인
+	var status *blockchain.HTLCStatus
+	if counterparty == swapParticipant {
+		status, err = swapAudit(counterparty)
+	} else {
+		var height int
+		status, height, err = swapCheckHTLC()
+		if err == nil && status.Timeout <= height {
+			err = fmt.Errorf("%w: refundable since height %d", errSwapTerms, status.Timeout)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	payload := map[string]interface{}{"txId": status.TxID, "index": status.Index, "preimage": *swapSecret}
+	if err := swapRequest("POST", *swapNode+"/htlc/claim", payload, nil); err != nil {
+		return err
+	}
+	fmt.Printf("redeemed %d from %s:%d\n", status.Amount, status.TxID, status.Index)
+	return nil
+}
+
+// 상대방이 가져가면서 공개한 비밀 값을 기다려 출력
+func swapExtract() error {
+	status, err := swapWaitHTLC(func(s *blockchain.HTLCStatus) bool { return s.Preimage != "" || s.State == blockchain.HTLCRefunded })
+	if err != nil {
+		return err
+	}
+	if status.Preimage == "" {
+		return fmt.Errorf("htlc %s:%d was refunded", status.TxID, status.Index)
+	}
+	fmt.Printf("secret:  %s\n", status.Preimage)
+	return nil
+}
+
+// 기한이 지난 자기 HTLC를 돌려받음
+func swapRefund() error {
+	txID, index, err := parseOutpoint(*swapHTLC)
+	if err != nil {
+		return err
+	}
+	payload := map[string]interface{}{"txId": txID, "index": index}
+	if err := swapRequest("POST", *swapNode+"/htlc/refund", payload, nil); err != nil {
+		return err
+	}
+	fmt.Printf("refunded %s:%d\n", txID, index)
+	return nil
+}
+
+// txid:index 형태의 출력 위치
+func parseOutpoint(s string) (string, int, error) {
+	txID, index, ok := strings.Cut(s, ":")
+	if !ok || txID == "" {
+		return "", 0, errors.New("-htlc must be txid:index")
+	}
+	i, err := strconv.Atoi(index)
+	if err != nil {
+		return "", 0, errors.New("-htlc must be txid:index")
+	}
+	return txID, i, nil
+}
+
+// 노드 지갑의 주소
+func walletAddress(node string) (string, error) {
+	var res struct {
+		Address string `json:"address"`
+	}
+	if err := swapRequest("GET", node+"/wallet", nil, &res); err != nil {
+		return "", err
+	}
+	return res.Address, nil
+}
+
+// 노드가 알고 있는 체인의 높이
+func chainHeight(node string) (int, error) {
+	var res struct {
+		Height int `json:"height"`
+	}
+	if err := swapRequest("GET", node+"/status", nil, &res); err != nil {
+		return 0, err
+	}
+	return res.Height, nil
+}
+
+// -htlc의 상태가 조건을 만족할 때까지 노드에 주기적으로 조회
+func swapWaitHTLC(done func(s *blockchain.HTLCStatus) bool) (*blockchain.HTLCStatus, error) {
+	txID, index, err := parseOutpoint(*swapHTLC)
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(*swapWait)
+	for time.Now().Before(deadline) {
+		status := &blockchain.HTLCStatus{}
+		err := swapRequest("GET", fmt.Sprintf("%s/htlc/%s/%d", *swapNode, txID, index), nil, status)
+		if err == nil && status.HTLC != nil && done(status) {
+			return status, nil
+		}
+		time.Sleep(swapPollInterval)
+	}
+	return nil, fmt.Errorf("%w: %s on %s", errSwapTimeout, *swapHTLC, *swapNode)
+}
+
+// 노드의 REST API 호출 (2xx가 아니면 응답 본문을 에러로 반환)
+func swapRequest(method, url string, payload, result interface{}) error {
+	var body bytes.Buffer
+	if payload != nil {
+		if err := json.NewEncoder(&body).Encode(payload); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequest(method, url, &body)
+	if err != nil {
+		return err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode/100 != 2 {
+		var errRes struct {
+			ErrorMessage string `json:"errorMessage"`
+		}
+		json.NewDecoder(res.Body).Decode(&errRes)
+		return fmt.Errorf("%s %s: %d %s", method, url, res.StatusCode, errRes.ErrorMessage)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(result)
+}
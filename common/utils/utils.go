@@ -13,9 +13,8 @@ import (
 )
 
 const (
-	StakingAddress  = "c8546a75af42fd63669afa3d2e72b3567790aa8f2a54da1abb94ec03239c76638f45ada90e6e2a5af42efff001a66d90106fa898ae55d3168b11d9e120a0763d" // PoS 스테이킹 풀 지갑 주소
+	StakingAddress  = "c8546a75af42fd63669afa3d2e72b3567790aa8f2a54da1abb94ec03239c76638f45ada90e6e2a5af42efff001a66d90106fa898ae55d3168b11d9e120a0763d" // 스테이킹 출력을 표시하는 주소 (스테이킹 출력은 이 주소의 키가 아니라 스테이커의 서명으로 사용)
 	StakingQuantity = 100                                                                                                                                // PoS 스테이킹 필수 수량
-	StakingNodePort = "3000"                                                                                                                             // PoS 스테이킹 풀 제공자 노드
 )
 
 func HomeDir() string {
@@ -51,6 +50,12 @@ func Hash(i interface{}) string {
 	return fmt.Sprintf("%x", hash)
 }
 
+// 정규 직렬화된 바이트열을 해싱한 후 해시의 16진수 인코딩을 반환
+func HashBytes(data []byte) string {
+	hash := sha256.Sum256(data)
+	return fmt.Sprintf("%x", hash)
+}
+
 // 문자열을 원하는 주문에 맞춰 쪼개주는 함수
 func Splitter(s string, sep string, index int) string {
 	r := strings.Split(s, sep)
@@ -24,9 +24,34 @@ type LogInfos struct {
 	DevChatId  int64
 }
 
+type IndexInfos struct {
+	TxIndex      bool // 트랜잭션 해시 -> 블록 내 위치 색인
+	AddressIndex bool // 주소 -> 잔액 변화 기록 색인
+}
+
+type MempoolInfos struct {
+	MaxCount     int // 최대 트랜잭션 수
+	MaxBytes     int // 트랜잭션 크기의 최대 합
+	MaxPerSender int // 주소별 최대 트랜잭션 수
+	ExpiryHour   int // 트랜잭션 보관 기한
+}
+
+type DenominationInfos struct {
+	Symbol   string // 금액 표시 기호
+	Decimals int    // 1 표시 단위에 해당하는 기본 단위의 10진 자릿수
+}
+
+type ConsensusInfos struct {
+	CommitteeSize int // 에포크마다 선출하는 검증자 수 (네트워크의 모든 노드가 같아야 함)
+}
+
 type Config struct {
-	Common  Common
-	LogInfo LogInfos
+	Common       Common
+	LogInfo      LogInfos
+	Index        IndexInfos
+	Mempool      MempoolInfos
+	Denomination DenominationInfos
+	Consensus    ConsensusInfos
 }
 
 func NewConfig(filepath string) *Config {
@@ -63,3 +88,19 @@ func (p *Config) GetConfig() *Config {
 func (p *Config) GetLogInfoConfig() *LogInfos {
 	return &p.LogInfo
 }
+
+func (p *Config) GetIndexConfig() *IndexInfos {
+	return &p.Index
+}
+
+func (p *Config) GetMempoolConfig() *MempoolInfos {
+	return &p.Mempool
+}
+
+func (p *Config) GetDenominationConfig() *DenominationInfos {
+	return &p.Denomination
+}
+
+func (p *Config) GetConsensusConfig() *ConsensusInfos {
+	return &p.Consensus
+}
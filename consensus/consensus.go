@@ -0,0 +1,140 @@
+// consensus 패키지는 제안, 사전 투표, 사전 커밋 단계와 라운드 타임아웃, 제안 잠금을 갖춘 라운드 기반 BFT (Tendermint 방식) 합의 엔진을 제공합니다.
+package consensus
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/abcfe-op/abcfe-node/blockchain"
+)
+
+// 라운드 안에서의 단계
+type Step int
+
+const (
+	StepNewHeight Step = iota // 커밋 후 다음 높이의 라운드 0을 기다리는 중 (역할 정보를 정하지 못했다면 다시 시도)
+	StepPropose               // 제안 블록을 기다리는 중
+	StepPrevote               // 사전 투표를 마치고 사전 투표를 모으는 중
+	StepPrecommit             // 사전 커밋을 마치고 사전 커밋을 모으는 중
+)
+
+func (s Step) String() string {
+	switch s {
+	case StepNewHeight:
+		return "new-height"
+	case StepPropose:
+		return "propose"
+	case StepPrevote:
+		return "prevote"
+	case StepPrecommit:
+		return "precommit"
+	}
+	return "unknown"
+}
+
+// 단계별 타임아웃. 라운드가 올라갈수록 Delta만큼 늘어나, 네트워크가 느려도 언젠가는 한 라운드 안에 메세지가 모두 도착한다
+type TimeoutConfig struct {
+	Propose        time.Duration // 제안 블록을 기다리는 시간
+	ProposeDelta   time.Duration
+	Prevote        time.Duration // 2/3의 사전 투표가 모였지만 한 블록으로 모이지 않았을 때 기다리는 시간
+	PrevoteDelta   time.Duration
+	Precommit      time.Duration // 2/3의 사전 커밋이 모였지만 한 블록으로 모이지 않았을 때 기다리는 시간
+	PrecommitDelta time.Duration
+	Commit         time.Duration // 커밋 후 다음 높이를 시작하기까지의 시간 (블록 간격이며, 그동안 트랜잭션과 늦은 투표를 모음)
+	Retry          time.Duration // 역할 정보를 정할 수 없을 때 (스테이커 부족 등) 다시 시도하기까지의 시간
+}
+
+var DefaultTimeouts = TimeoutConfig{
+	Propose:        3 * time.Second,
+	ProposeDelta:   500 * time.Millisecond,
+	Prevote:        time.Second,
+	PrevoteDelta:   500 * time.Millisecond,
+	Precommit:      time.Second,
+	PrecommitDelta: 500 * time.Millisecond,
+	Commit:         12 * time.Second, // 이더리움의 슬롯 시간
+	Retry:          12 * time.Second,
+}
+
+// 단계와 라운드에 따른 타임아웃
+func (c TimeoutConfig) duration(step Step, round int) time.Duration {
+	switch step {
+	case StepPropose:
+		return c.Propose + time.Duration(round)*c.ProposeDelta
+	case StepPrevote:
+		return c.Prevote + time.Duration(round)*c.PrevoteDelta
+	case StepPrecommit:
+		return c.Precommit + time.Duration(round)*c.PrecommitDelta
+	}
+	return c.Commit
+}
+
+// 예약된 타임아웃 (발생 시 Engine.HandleTimeout으로 전달)
+type Timeout struct {
+	Height int
+	Round  int
+	Step   Step
+}
+
+// 이 노드가 서명한 메세지의 위치 (높이, 라운드, 단계)와 블록 해시.
+// 서명 전에 기록해 두어, 재시작하더라도 이미 서명한 위치에 다른 블록을 서명하거나 지난 위치로 돌아가 서명하지 않는다
+type SignState struct {
+	Height int    `json:"height"`
+	Round  int    `json:"round"`
+	Step   Step   `json:"step"`
+	Hash   string `json:"hash"`
+}
+
+// 위치 비교 (앞서면 음수, 같으면 0, 뒤면 양수)
+func (s SignState) compare(o SignState) int {
+	switch {
+	case s.Height != o.Height:
+		return s.Height - o.Height
+	case s.Round != o.Round:
+		return s.Round - o.Round
+	}
+	return int(s.Step) - int(o.Step)
+}
+
+// 엔진이 노드와 주고받는 기능. 엔진은 시간, 네트워크, 체인에 직접 접근하지 않으므로 테스트에서는 가짜 구현으로 결정적으로 실행할 수 있다
+type Driver interface {
+	Height() int                                                      // 체인의 현재 높이
+	Address() string                                                  // 이 노드의 지갑 주소
+	Roles(height int) (*blockchain.RoleInfo, error)                   // 높이의 역할 정보 (라운드 0 기준)
+	Propose(height int, roles *blockchain.RoleInfo) *blockchain.Block // 제안할 블록 구성 (구성할 수 없다면 nil)
+	Validate(block *blockchain.Block, round int) error                // 라운드에 제안된 블록 검증
+	Sign(payload string) *blockchain.ValidateSignature                // 이 노드의 지갑으로 서명
+	LastSigned() SignState                                            // 마지막으로 서명한 위치 (서명한 적이 없다면 빈 값)
+	SaveSigned(s SignState) error                                     // 서명할 위치를 디스크에 기록 (서명 전에 호출)
+	BroadcastProposal(p *Proposal)
+	BroadcastVote(v *Vote)
+	Schedule(t Timeout, d time.Duration)  // d 이후 HandleTimeout(t) 호출
+	Commit(block *blockchain.Block) error // 커밋 서명을 모은 블록을 체인에 연결하고 전파
+}
+
+var (
+	ErrInvalidProposal     = errors.New("invalid proposal")
+	ErrConflictingProposal = errors.New("proposer sent two proposals for the same round")
+	ErrInvalidVote         = errors.New("invalid vote")
+	ErrConflictingVote     = errors.New("validator sent two different votes for the same round")
+	ErrNotValidator        = errors.New("vote is not from a validator of the height")
+	ErrStaleMessage        = errors.New("message is for an already committed height")
+	ErrFutureMessage       = errors.New("message is too far ahead of the chain")
+	ErrFutureRound         = errors.New("message is too far ahead of the current round")
+	ErrTooManyPending      = errors.New("too many held messages from the signer")
+)
+
+var current atomic.Pointer[Engine]
+
+// 노드의 합의 엔진을 만들고 시작
+func Start(d Driver, timeouts TimeoutConfig) *Engine {
+	e := NewEngine(d, timeouts)
+	current.Store(e)
+	e.Start()
+	return e
+}
+
+// 실행 중인 합의 엔진 (rest 모드처럼 합의에 참여하지 않는 노드는 nil)
+func Current() *Engine {
+	return current.Load()
+}
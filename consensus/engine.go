@@ -0,0 +1,567 @@
+package consensus
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/abcfe-op/abcfe-node/blockchain"
+	"github.com/abcfe-op/abcfe-node/wallet"
+
+	log "github.com/abcfe-op/abcfe-node/common/logger"
+)
+
+const (
+	maxHeightLookahead  = 10   // 현재 높이보다 이만큼 이상 앞선 메세지는 받지 않음
+	maxRoundLookahead   = 5    // 현재 라운드보다 이만큼을 넘어 앞선 라운드의 메세지는 받지 않음 (집계 메모리 보호)
+	maxPending          = 1000 // 앞선 높이의 메세지를 보관하는 최대 수 (메모리 보호)
+	maxPendingPerSigner = 50   // 서명자 한 명의 메세지를 보관하는 최대 수 (한 서명자가 보관 공간을 차지하지 못하도록)
+)
+
+// 라운드 기반 BFT 합의 상태 기계. 모든 입력 (제안, 투표, 타임아웃)은 잠금 아래에서 처리되고, 출력은 Driver를 통해서만 내보낸다
+type Engine struct {
+	d        Driver
+	timeouts TimeoutConfig
+	m        sync.Mutex
+
+	height int
+	round  int
+	step   Step
+	roles  *blockchain.RoleInfo // 높이의 역할 정보 (라운드 0 기준, 없으면 아직 정하지 못함)
+	power  map[string]int       // 검증자 주소 -> 검증자 자리 수
+	seats  int                  // 전체 검증자 자리 수
+
+	lockedRound int               // 사전 커밋한 라운드 (-1이면 잠금 없음)
+	lockedBlock *blockchain.Block // 사전 커밋한 블록. 다른 블록이 이후 라운드에서 2/3 사전 투표를 받기 전까지 이 블록에만 투표한다
+	validRound  int               // 2/3 사전 투표를 확인한 마지막 라운드 (-1이면 없음)
+	validBlock  *blockchain.Block // 그 라운드의 블록 (다음에 제안자가 되면 새로 만들지 않고 다시 제안)
+
+	proposals  map[int]*Proposal // 라운드 -> 제안
+	prevotes   map[int]*tally    // 라운드 -> 사전 투표 집계
+	precommits map[int]*tally    // 라운드 -> 사전 커밋 집계
+	valid      map[string]bool   // 블록 해시 -> 검증 결과 (같은 블록을 여러 번 검증하지 않도록)
+	failed     map[string]bool   // 체인에 연결하지 못한 블록 해시
+	polka      map[int]bool      // 라운드에서 제안 블록의 2/3 사전 투표를 이미 처리했는가
+	scheduled  map[Timeout]bool  // 이미 예약한 사전 투표, 사전 커밋 타임아웃
+
+	pending []interface{}   // 앞선 높이 또는 역할 정보를 정하기 전에 받은 제안과 투표
+	seen    map[string]bool // 보관 중인 메세지의 서명 (중복 보관 방지)
+	held    map[string]int  // 서명자 주소 -> 보관 중인 메세지 수
+
+	signed SignState // 이 노드가 마지막으로 서명한 위치
+}
+
+func NewEngine(d Driver, timeouts TimeoutConfig) *Engine {
+	return &Engine{d: d, timeouts: timeouts, seen: make(map[string]bool), held: make(map[string]int)}
+}
+
+// 체인의 다음 높이부터 합의 시작
+func (e *Engine) Start() {
+	e.m.Lock()
+	defer e.m.Unlock()
+	e.signed = e.d.LastSigned()
+	e.startHeight(e.d.Height() + 1)
+	e.process()
+}
+
+// 현재 높이, 라운드, 단계
+func (e *Engine) State() (int, int, Step) {
+	e.m.Lock()
+	defer e.m.Unlock()
+	return e.height, e.round, e.step
+}
+
+// 동기화나 가십으로 체인이 앞서 나갔다면 새 높이로 이동
+func (e *Engine) Sync() {
+	e.m.Lock()
+	defer e.m.Unlock()
+	if e.sync() {
+		e.process()
+	}
+}
+
+func (e *Engine) sync() bool {
+	if tip := e.d.Height(); tip >= e.height {
+		e.startHeight(tip + 1)
+		return true
+	}
+	return false
+}
+
+// 제안 처리. 처음 받은 제안이라면 true (다른 peer에게 중계할지 판단)
+func (e *Engine) HandleProposal(p *Proposal) (bool, error) {
+	if p == nil || p.Block == nil || p.Signature == nil {
+		return false, ErrInvalidProposal
+	}
+	e.m.Lock()
+	defer e.m.Unlock()
+	e.sync()
+	if ok, err := e.checkHeight(p.Height); !ok {
+		return false, err
+	}
+	if p.Height > e.height || e.roles == nil {
+		if p.Signature.Address == "" || !wallet.Verify(p.Signature.Signature, p.signBytes(), p.Signature.Address) {
+			return false, fmt.Errorf("%w: bad signature", ErrInvalidProposal)
+		}
+		return e.hold(p, p.Signature)
+	}
+	added, err := e.addProposal(p)
+	if added {
+		e.process()
+	}
+	return added, err
+}
+
+// 투표 처리. 처음 받은 투표라면 true (다른 peer에게 중계할지 판단)
+func (e *Engine) HandleVote(v *Vote) (bool, error) {
+	if v == nil || v.Signature == nil {
+		return false, ErrInvalidVote
+	}
+	e.m.Lock()
+	defer e.m.Unlock()
+	e.sync()
+	if ok, err := e.checkHeight(v.Height); !ok {
+		return false, err
+	}
+	if v.Height > e.height || e.roles == nil {
+		if !v.verify() {
+			return false, fmt.Errorf("%w: bad signature", ErrInvalidVote)
+		}
+		return e.hold(v, v.Signature)
+	}
+	added, err := e.addVote(v)
+	if added {
+		e.process()
+	}
+	return added, err
+}
+
+// 타임아웃 처리 (지난 높이, 라운드, 단계의 타임아웃은 무시)
+func (e *Engine) HandleTimeout(t Timeout) {
+	e.m.Lock()
+	defer e.m.Unlock()
+	if e.sync() || t.Height != e.height {
+		e.process()
+		return
+	}
+	switch t.Step {
+	case StepNewHeight:
+		if e.roles == nil {
+			e.startHeight(e.height)
+		} else if e.step == StepNewHeight {
+			e.startRound(0)
+		}
+	case StepPropose:
+		if t.Round == e.round && e.step == StepPropose {
+			e.vote(Prevote, "")
+		}
+	case StepPrevote:
+		if t.Round == e.round && e.step == StepPrevote {
+			e.vote(Precommit, "")
+		}
+	case StepPrecommit:
+		if t.Round == e.round {
+			e.startRound(e.round + 1)
+		}
+	}
+	e.process()
+}
+
+func (e *Engine) checkHeight(height int) (bool, error) {
+	if height < e.height {
+		return false, fmt.Errorf("%w: %d < %d", ErrStaleMessage, height, e.height)
+	}
+	if height >= e.height+maxHeightLookahead {
+		return false, fmt.Errorf("%w: %d", ErrFutureMessage, height)
+	}
+	return true, nil
+}
+
+// 서명을 확인한 메세지 중 지금 처리할 수 없는 메세지 보관. 처음 보관하는 메세지라면 true
+// (앞선 높이의 검증자는 아직 알 수 없으므로, 서명자마다 보관하는 수를 제한하고 높이에 도달했을 때 검증자가 아닌 서명자의 메세지를 버림)
+func (e *Engine) hold(msg interface{}, sig *blockchain.ValidateSignature) (bool, error) {
+	if e.seen[sig.Signature] || len(e.pending) >= maxPending {
+		return false, nil
+	}
+	if e.held[sig.Address] >= maxPendingPerSigner {
+		return false, fmt.Errorf("%w: %s", ErrTooManyPending, sig.Address)
+	}
+	e.seen[sig.Signature] = true
+	e.held[sig.Address]++
+	e.pending = append(e.pending, msg)
+	return true, nil
+}
+
+// 새 높이 시작: 라운드 상태를 초기화하고 역할 정보를 정한 뒤, 블록 간격만큼 기다렸다가 라운드 0 시작.
+// 기다리는 동안에도 먼저 시작한 노드의 제안과 투표는 집계한다
+func (e *Engine) startHeight(height int) {
+	e.height, e.round, e.step = height, 0, StepNewHeight
+	e.roles, e.power, e.seats = nil, nil, 0
+	e.lockedRound, e.lockedBlock = -1, nil
+	e.validRound, e.validBlock = -1, nil
+	e.proposals = make(map[int]*Proposal)
+	e.prevotes = make(map[int]*tally)
+	e.precommits = make(map[int]*tally)
+	e.valid = make(map[string]bool)
+	e.failed = make(map[string]bool)
+	e.polka = make(map[int]bool)
+	e.scheduled = make(map[Timeout]bool)
+
+	roles, err := e.d.Roles(height)
+	if err != nil {
+		log.Error(err)
+		e.d.Schedule(Timeout{Height: height, Step: StepNewHeight}, e.timeouts.Retry)
+		return
+	}
+	e.roles = roles
+	e.power = make(map[string]int)
+	for _, address := range roles.ValidatorAddress {
+		e.power[address]++
+	}
+	e.seats = len(roles.ValidatorAddress)
+	fmt.Printf("Consensus for height %d started with %d validator seats\n", height, e.seats)
+	e.d.Schedule(Timeout{Height: height, Step: StepNewHeight}, e.timeouts.Commit)
+	e.replay()
+}
+
+// 보관해 둔 현재 높이의 메세지 처리 (지난 높이의 메세지는 버림)
+func (e *Engine) replay() {
+	var rest []interface{}
+	var ready []interface{}
+	for _, msg := range e.pending {
+		switch m := msg.(type) {
+		case *Proposal:
+			if m.Height == e.height {
+				ready = append(ready, m)
+			} else if m.Height > e.height {
+				rest = append(rest, m)
+			}
+		case *Vote:
+			if m.Height == e.height {
+				ready = append(ready, m)
+			} else if m.Height > e.height {
+				rest = append(rest, m)
+			}
+		}
+	}
+	e.pending = rest
+	e.seen = make(map[string]bool)
+	e.held = make(map[string]int)
+	for _, msg := range rest {
+		var sig *blockchain.ValidateSignature
+		switch m := msg.(type) {
+		case *Proposal:
+			sig = m.Signature
+		case *Vote:
+			sig = m.Signature
+		}
+		e.seen[sig.Signature] = true
+		e.held[sig.Address]++
+	}
+	for _, msg := range ready {
+		var err error
+		switch m := msg.(type) {
+		case *Proposal:
+			_, err = e.addProposal(m)
+		case *Vote:
+			_, err = e.addVote(m)
+		}
+		if err != nil {
+			log.Warn(err.Error())
+		}
+	}
+}
+
+// 라운드 시작: 이 노드가 라운드의 제안자라면 블록을 제안하고, 제안 타임아웃 예약
+func (e *Engine) startRound(round int) {
+	e.round, e.step = round, StepPropose
+	roles := e.roles.ForRound(round)
+	if roles.ProposerAddress == e.d.Address() {
+		// 블록의 역할 정보는 커밋 라운드의 것이어야 하므로, 이전 라운드의 블록은 역할 정보가 같은 라운드에서만 다시 제안
+		block, polRound := e.validBlock, e.validRound
+		if block == nil || !blockchain.CompareRoleInfo(block.RoleInfo, roles) {
+			block, polRound = e.d.Propose(e.height, roles), -1
+		}
+		if block != nil {
+			p := &Proposal{Height: e.height, Round: round, POLRound: polRound, Block: block}
+			if p.Signature = e.sign(StepPropose, block.Hash, p.signBytes()); p.Signature != nil {
+				e.proposals[round] = p
+				e.d.BroadcastProposal(p)
+			}
+		}
+	}
+	e.d.Schedule(Timeout{Height: e.height, Round: round, Step: StepPropose}, e.timeouts.duration(StepPropose, round))
+}
+
+// 제안 확인 후 저장: 라운드 제안자의 서명, 블록 높이, 블록의 역할 정보가 그 라운드의 역할 정보와 일치해야 함 (블록은 제안된 라운드에서 커밋되므로)
+func (e *Engine) addProposal(p *Proposal) (bool, error) {
+	if p.Round < 0 || p.POLRound < -1 || p.POLRound >= p.Round || p.Block.Height != p.Height {
+		return false, fmt.Errorf("%w: height %d round %d", ErrInvalidProposal, p.Block.Height, p.Round)
+	}
+	if p.Round > e.round+maxRoundLookahead {
+		return false, fmt.Errorf("%w: round %d, current %d", ErrFutureRound, p.Round, e.round)
+	}
+	proposer := e.roles.ForRound(p.Round).ProposerAddress
+	if p.Signature.Address != proposer || !wallet.Verify(p.Signature.Signature, p.signBytes(), proposer) {
+		return false, fmt.Errorf("%w: not signed by the proposer of round %d", ErrInvalidProposal, p.Round)
+	}
+	if p.Block.RoleInfo == nil || !blockchain.CompareRoleInfo(p.Block.RoleInfo, e.roles.ForRound(p.Round)) {
+		return false, fmt.Errorf("%w: role info is not of round %d", ErrInvalidProposal, p.Round)
+	}
+	if prev, ok := e.proposals[p.Round]; ok {
+		if prev.Block.Hash != p.Block.Hash {
+			return false, fmt.Errorf("%w: %s", ErrConflictingProposal, proposer)
+		}
+		return false, nil
+	}
+	e.proposals[p.Round] = p
+	return true, nil
+}
+
+// 투표 확인 후 집계 (높이의 검증자가 아닌 주소의 투표와 너무 앞선 라운드의 투표는 집계하지 않음)
+func (e *Engine) addVote(v *Vote) (bool, error) {
+	if (v.Type != Prevote && v.Type != Precommit) || v.Round < 0 {
+		return false, ErrInvalidVote
+	}
+	if v.Round > e.round+maxRoundLookahead {
+		return false, fmt.Errorf("%w: round %d, current %d", ErrFutureRound, v.Round, e.round)
+	}
+	power := e.power[v.Signature.Address]
+	if power == 0 {
+		return false, fmt.Errorf("%w: %s", ErrNotValidator, v.Signature.Address)
+	}
+	if !v.verify() {
+		return false, fmt.Errorf("%w: %s", ErrInvalidVote, v.Signature.Address)
+	}
+	return e.tally(v.Type, v.Round).add(v, power)
+}
+
+func (e *Engine) tally(t VoteType, round int) *tally {
+	tallies := e.prevotes
+	if t == Precommit {
+		tallies = e.precommits
+	}
+	if _, ok := tallies[round]; !ok {
+		tallies[round] = newTally()
+	}
+	return tallies[round]
+}
+
+// 집계가 정족수를 넘었는가 (hash가 nil이면 블록과 상관없이 투표한 자리 수 기준)
+func (e *Engine) quorum(t *tally, hash *string) bool {
+	if t == nil {
+		return false
+	}
+	if hash == nil {
+		return t.total >= blockchain.Quorum(e.seats)
+	}
+	return t.power[*hash] >= blockchain.Quorum(e.seats)
+}
+
+// 제안 블록을 제안된 라운드 기준으로 검증 (결과는 높이 안에서 보관)
+func (e *Engine) isValid(p *Proposal) bool {
+	block := p.Block
+	if valid, ok := e.valid[block.Hash]; ok {
+		return valid
+	}
+	err := e.d.Validate(block, p.Round)
+	if err != nil {
+		fmt.Printf("Proposal %s is not valid: %s\n", block.Hash, err)
+	}
+	e.valid[block.Hash] = err == nil
+	return err == nil
+}
+
+// 투표: 단계를 옮기고, 이 노드가 검증자라면 서명하여 집계에 넣은 뒤 가십 (검증자가 아닌 노드는 지켜보기만 함)
+func (e *Engine) vote(t VoteType, hash string) {
+	e.step = StepPrevote
+	if t == Precommit {
+		e.step = StepPrecommit
+	}
+	if e.power[e.d.Address()] == 0 {
+		return
+	}
+	v := &Vote{Type: t, Height: e.height, Round: e.round, ProposalHash: hash}
+	if v.Signature = e.sign(e.step, hash, v.signBytes()); v.Signature == nil {
+		return
+	}
+	if t == Precommit {
+		v.Signature.Round = e.round
+	}
+	if _, err := e.tally(t, e.round).add(v, e.power[e.d.Address()]); err != nil {
+		log.Error(err)
+		return
+	}
+	e.d.BroadcastVote(v)
+}
+
+// 현재 높이와 라운드의 단계에서 블록 해시에 서명. 서명 위치를 먼저 기록하며, 이미 지난 위치이거나
+// 같은 위치에 다른 블록을 서명했다면 (재시작 전의 서명 등) 서명하지 않고 nil
+func (e *Engine) sign(step Step, hash, payload string) *blockchain.ValidateSignature {
+	s := SignState{Height: e.height, Round: e.round, Step: step, Hash: hash}
+	if c := s.compare(e.signed); c < 0 || (c == 0 && hash != e.signed.Hash) {
+		log.Warn(fmt.Sprintf("Refusing to sign %s at height %d round %d: already signed up to height %d round %d", step, s.Height, s.Round, e.signed.Height, e.signed.Round))
+		return nil
+	}
+	if err := e.d.SaveSigned(s); err != nil {
+		log.Error(err)
+		return nil
+	}
+	e.signed = s
+	return e.d.Sign(payload)
+}
+
+// 입력을 받은 뒤 더 이상 상태가 바뀌지 않을 때까지 규칙 적용
+func (e *Engine) process() {
+	for e.roles != nil && e.advance() {
+	}
+}
+
+// 상태 전이 하나를 적용하고, 상태가 바뀌었다면 true
+func (e *Engine) advance() bool {
+	// 어느 라운드든 제안 블록이 2/3 사전 커밋을 받았다면 커밋
+	for _, round := range sortedRounds(e.proposals) {
+		p := e.proposals[round]
+		// 검증은 체인에 연결하면서 수행하므로, 이 노드의 제안 검증 결과와 상관없이 커밋을 시도
+		if !e.failed[p.Block.Hash] && e.quorum(e.precommits[round], &p.Block.Hash) && e.commit(p.Block, round) {
+			return true
+		}
+	}
+	// 더 높은 라운드에서 1/3을 넘는 검증자 자리의 투표를 보았다면 그 라운드로 건너뜀 (뒤처진 노드가 따라잡음)
+	for _, round := range e.futureRounds() {
+		if e.voters(round)*3 > e.seats {
+			e.startRound(round)
+			return true
+		}
+	}
+
+	if e.step == StepNewHeight {
+		return false
+	}
+	p := e.proposals[e.round]
+	if e.step == StepPropose && p != nil {
+		hash := p.Block.Hash
+		if p.POLRound == -1 {
+			if e.isValid(p) && (e.lockedRound == -1 || e.lockedBlock.Hash == hash) {
+				e.vote(Prevote, hash)
+			} else {
+				e.vote(Prevote, "")
+			}
+			return true
+		}
+		if e.quorum(e.prevotes[p.POLRound], &hash) {
+			// 잠금 라운드 이후에 2/3 사전 투표를 받은 블록이라면 잠금과 달라도 투표할 수 있음
+			if e.isValid(p) && (e.lockedRound <= p.POLRound || e.lockedBlock.Hash == hash) {
+				e.vote(Prevote, hash)
+			} else {
+				e.vote(Prevote, "")
+			}
+			return true
+		}
+	}
+	if e.step >= StepPrevote && p != nil && !e.polka[e.round] && e.quorum(e.prevotes[e.round], &p.Block.Hash) && e.isValid(p) {
+		e.polka[e.round] = true
+		e.validRound, e.validBlock = e.round, p.Block
+		if e.step == StepPrevote {
+			e.lockedRound, e.lockedBlock = e.round, p.Block
+			e.vote(Precommit, p.Block.Hash)
+		}
+		return true
+	}
+	nilHash := ""
+	if e.step == StepPrevote && e.quorum(e.prevotes[e.round], &nilHash) {
+		e.vote(Precommit, "")
+		return true
+	}
+	if e.step == StepPrevote && e.quorum(e.prevotes[e.round], nil) {
+		e.schedule(StepPrevote)
+	}
+	if e.quorum(e.precommits[e.round], nil) {
+		e.schedule(StepPrecommit)
+	}
+	return false
+}
+
+// 사전 투표, 사전 커밋 타임아웃을 라운드마다 한 번만 예약
+func (e *Engine) schedule(step Step) {
+	t := Timeout{Height: e.height, Round: e.round, Step: step}
+	if e.scheduled[t] {
+		return
+	}
+	e.scheduled[t] = true
+	e.d.Schedule(t, e.timeouts.duration(step, e.round))
+}
+
+// 라운드의 사전 커밋 서명을 블록에 붙여 체인에 연결한 뒤 다음 높이 시작. 연결에 실패한 블록은 다시 커밋하지 않음
+func (e *Engine) commit(block *blockchain.Block, round int) bool {
+	committed := *block
+	committed.Signature = nil
+	t := e.precommits[round]
+	added := make(map[string]bool)
+	for _, address := range e.roles.ValidatorAddress {
+		v, ok := t.votes[address]
+		if !ok || added[address] || v.ProposalHash != block.Hash {
+			continue
+		}
+		sig := *v.Signature
+		sig.Round = round
+		committed.Signature = append(committed.Signature, &sig)
+		added[address] = true
+	}
+	if err := e.d.Commit(&committed); err != nil {
+		log.Error(fmt.Errorf("committed block %s rejected: %w", block.Hash, err))
+		e.failed[block.Hash] = true
+		return false
+	}
+	fmt.Printf("Committed block %s at height %d in round %d\n", block.Hash, block.Height, round)
+	e.startHeight(e.height + 1)
+	return true
+}
+
+// 현재 라운드보다 높은 라운드 중 투표를 받은 라운드 (오름차순)
+func (e *Engine) futureRounds() []int {
+	set := make(map[int]bool)
+	for round := range e.prevotes {
+		if round > e.round {
+			set[round] = true
+		}
+	}
+	for round := range e.precommits {
+		if round > e.round {
+			set[round] = true
+		}
+	}
+	var rounds []int
+	for round := range set {
+		rounds = append(rounds, round)
+	}
+	sort.Ints(rounds)
+	return rounds
+}
+
+// 라운드에서 종류에 상관없이 투표한 검증자 자리 수
+func (e *Engine) voters(round int) int {
+	seen := make(map[string]bool)
+	count := 0
+	for _, t := range []*tally{e.prevotes[round], e.precommits[round]} {
+		if t == nil {
+			continue
+		}
+		for address := range t.votes {
+			if !seen[address] {
+				seen[address] = true
+				count += e.power[address]
+			}
+		}
+	}
+	return count
+}
+
+func sortedRounds(proposals map[int]*Proposal) []int {
+	var rounds []int
+	for round := range proposals {
+		rounds = append(rounds, round)
+	}
+	sort.Ints(rounds)
+	return rounds
+}
@@ -0,0 +1,599 @@
+package consensus
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/abcfe-op/abcfe-node/blockchain"
+	"github.com/abcfe-op/abcfe-node/common/utils"
+	"github.com/abcfe-op/abcfe-node/config"
+	"github.com/abcfe-op/abcfe-node/wallet"
+
+	log "github.com/abcfe-op/abcfe-node/common/logger"
+)
+
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "consensus")
+	if err != nil {
+		panic(err)
+	}
+	cfg := &config.Config{}
+	cfg.Common.Mode = "alpha"
+	cfg.LogInfo.Fpath = dir + "/log"
+	log.InitLogger(cfg)
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// 테스트 서명자 (run-nodes의 제네시스 검증자 지갑)
+type signer struct {
+	address string
+	port    string
+	sign    func(payload string) string
+}
+
+func loadSigner(port string) signer {
+	w := wallet.Load("../run-nodes/wallets/" + port + ".wallet")
+	return signer{address: w.Address, port: port, sign: func(payload string) string { return wallet.Sign(payload, w) }}
+}
+
+// 0~3은 검증자, 4는 검증자가 아닌 노드
+var signers = func() []signer {
+	var list []signer
+	for i := 0; i < 5; i++ {
+		list = append(list, loadSigner(fmt.Sprintf("%d", 4000+i)))
+	}
+	return list
+}()
+
+func (s signer) signature(payload string) *blockchain.ValidateSignature {
+	return &blockchain.ValidateSignature{Port: s.port, Address: s.address, Signature: s.sign(payload)}
+}
+
+// 라운드 0의 제안자는 0번, 이후 라운드 r의 제안자는 (r-1)번 검증자
+func testRoles() *blockchain.RoleInfo {
+	roles := &blockchain.RoleInfo{ProposerAddress: signers[0].address, ProposerPort: signers[0].port}
+	for _, s := range signers[:4] {
+		roles.ValidatorAddress = append(roles.ValidatorAddress, s.address)
+		roles.ValidatorPort = append(roles.ValidatorPort, s.port)
+	}
+	return roles
+}
+
+func testBlock(height int, name string) *blockchain.Block {
+	block := &blockchain.Block{Hash: utils.HashBytes([]byte(name)), RoleInfo: testRoles()}
+	block.Height = height
+	return block
+}
+
+// 시간, 네트워크, 체인 대신 호출을 기록하는 가짜 Driver
+type fakeDriver struct {
+	self      signer
+	height    int
+	invalid   map[string]bool
+	proposals []*Proposal
+	votes     []*Vote
+	timeouts  []Timeout
+	committed []*blockchain.Block
+	signed    SignState
+	saveErr   error
+}
+
+func (d *fakeDriver) Height() int     { return d.height }
+func (d *fakeDriver) Address() string { return d.self.address }
+func (d *fakeDriver) Roles(height int) (*blockchain.RoleInfo, error) {
+	return testRoles(), nil
+}
+func (d *fakeDriver) Propose(height int, roles *blockchain.RoleInfo) *blockchain.Block {
+	block := testBlock(height, "own")
+	block.RoleInfo = roles
+	return block
+}
+func (d *fakeDriver) Validate(block *blockchain.Block, round int) error {
+	if d.invalid[block.Hash] {
+		return errors.New("invalid block")
+	}
+	return nil
+}
+func (d *fakeDriver) Sign(payload string) *blockchain.ValidateSignature {
+	return d.self.signature(payload)
+}
+func (d *fakeDriver) LastSigned() SignState { return d.signed }
+func (d *fakeDriver) SaveSigned(s SignState) error {
+	if d.saveErr != nil {
+		return d.saveErr
+	}
+	d.signed = s
+	return nil
+}
+func (d *fakeDriver) BroadcastProposal(p *Proposal)       { d.proposals = append(d.proposals, p) }
+func (d *fakeDriver) BroadcastVote(v *Vote)               { d.votes = append(d.votes, v) }
+func (d *fakeDriver) Schedule(t Timeout, _ time.Duration) { d.timeouts = append(d.timeouts, t) }
+func (d *fakeDriver) Commit(block *blockchain.Block) error {
+	d.committed = append(d.committed, block)
+	d.height = block.Height
+	return nil
+}
+
+// 체인 높이 1에서 self번 검증자로 엔진을 시작하고, 높이 2의 라운드 0까지 진행
+func startEngine(t *testing.T, self int, configure func(d *fakeDriver)) (*Engine, *fakeDriver) {
+	t.Helper()
+	d := &fakeDriver{self: signers[self], height: 1, invalid: make(map[string]bool)}
+	if configure != nil {
+		configure(d)
+	}
+	e := NewEngine(d, DefaultTimeouts)
+	e.Start()
+	e.HandleTimeout(Timeout{Height: 2, Step: StepNewHeight})
+	return e, d
+}
+
+func proposal(proposer, round, polRound int, block *blockchain.Block) *Proposal {
+	p := &Proposal{Height: block.Height, Round: round, POLRound: polRound, Block: block}
+	p.Signature = signers[proposer].signature(p.signBytes())
+	return p
+}
+
+func vote(voter int, t VoteType, height, round int, hash string) *Vote {
+	v := &Vote{Type: t, Height: height, Round: round, ProposalHash: hash}
+	v.Signature = signers[voter].signature(v.signBytes())
+	return v
+}
+
+func mustHandleVote(t *testing.T, e *Engine, v *Vote) {
+	t.Helper()
+	if _, err := e.HandleVote(v); err != nil {
+		t.Fatalf("vote from %s rejected: %v", v.Signature.Port, err)
+	}
+}
+
+func mustHandleProposal(t *testing.T, e *Engine, p *Proposal) {
+	t.Helper()
+	if _, err := e.HandleProposal(p); err != nil {
+		t.Fatalf("proposal for round %d rejected: %v", p.Round, err)
+	}
+}
+
+// 노드가 가장 최근에 가십한 투표 (없다면 nil)
+func lastVote(d *fakeDriver) *Vote {
+	if len(d.votes) == 0 {
+		return nil
+	}
+	return d.votes[len(d.votes)-1]
+}
+
+func expectVote(t *testing.T, d *fakeDriver, typ VoteType, round int, hash string) {
+	t.Helper()
+	v := lastVote(d)
+	if v == nil || v.Type != typ || v.Round != round || v.ProposalHash != hash {
+		t.Fatalf("last vote = %+v, want %s round %d for %q", v, typ, round, hash)
+	}
+}
+
+func TestEngineCommitsWithQuorum(t *testing.T) {
+	e, d := startEngine(t, 0, nil)
+	if len(d.proposals) != 1 {
+		t.Fatalf("proposer broadcast %d proposals, want 1", len(d.proposals))
+	}
+	block := d.proposals[0].Block
+	expectVote(t, d, Prevote, 0, block.Hash)
+
+	// 다음 높이의 투표는 보관했다가 높이에 도달하면 집계 (검증자가 아닌 서명자의 투표는 버림)
+	for _, voter := range []int{1, 4} {
+		if added, err := e.HandleVote(vote(voter, Prevote, 3, 0, "")); !added || err != nil {
+			t.Fatalf("future vote from %d: added %v, err %v", voter, added, err)
+		}
+	}
+
+	mustHandleVote(t, e, vote(1, Prevote, 2, 0, block.Hash))
+	expectVote(t, d, Prevote, 0, block.Hash)
+	mustHandleVote(t, e, vote(2, Prevote, 2, 0, block.Hash))
+	expectVote(t, d, Precommit, 0, block.Hash)
+	if e.lockedRound != 0 || e.lockedBlock.Hash != block.Hash {
+		t.Fatalf("locked on round %d, want round 0", e.lockedRound)
+	}
+
+	mustHandleVote(t, e, vote(1, Precommit, 2, 0, block.Hash))
+	if len(d.committed) != 0 {
+		t.Fatal("committed without a quorum of precommits")
+	}
+	mustHandleVote(t, e, vote(2, Precommit, 2, 0, block.Hash))
+	if len(d.committed) != 1 {
+		t.Fatalf("committed %d blocks, want 1", len(d.committed))
+	}
+	committed := d.committed[0]
+	if len(committed.Signature) != 3 {
+		t.Fatalf("commit carries %d signatures, want 3", len(committed.Signature))
+	}
+	for _, sig := range committed.Signature {
+		if !wallet.Verify(sig.Signature, blockchain.CommitPayload(2, sig.Round, block.Hash), sig.Address) {
+			t.Fatalf("signature of %s does not verify as a commit signature", sig.Port)
+		}
+	}
+	if height, round, step := e.State(); height != 3 || round != 0 || step != StepNewHeight {
+		t.Fatalf("state = (%d, %d, %s), want (3, 0, new-height)", height, round, step)
+	}
+	if tally := e.prevotes[0]; tally == nil || tally.total != 1 {
+		t.Fatalf("replayed prevotes at height 3 = %+v, want only the validator's", tally)
+	}
+}
+
+func TestEngineTimeouts(t *testing.T) {
+	tests := []struct {
+		name  string
+		run   func(t *testing.T, e *Engine, d *fakeDriver)
+		check func(t *testing.T, e *Engine, d *fakeDriver)
+	}{
+		{
+			name: "propose timeout prevotes nil",
+			run: func(t *testing.T, e *Engine, d *fakeDriver) {
+				e.HandleTimeout(Timeout{Height: 2, Round: 0, Step: StepPropose})
+			},
+			check: func(t *testing.T, e *Engine, d *fakeDriver) {
+				expectVote(t, d, Prevote, 0, "")
+			},
+		},
+		{
+			name: "split prevotes schedule the prevote timeout, which precommits nil",
+			run: func(t *testing.T, e *Engine, d *fakeDriver) {
+				e.HandleTimeout(Timeout{Height: 2, Round: 0, Step: StepPropose})
+				mustHandleVote(t, e, vote(0, Prevote, 2, 0, testBlock(2, "a").Hash))
+				mustHandleVote(t, e, vote(1, Prevote, 2, 0, testBlock(2, "b").Hash))
+				if d.timeouts[len(d.timeouts)-1] != (Timeout{Height: 2, Round: 0, Step: StepPrevote}) {
+					t.Fatalf("prevote timeout not scheduled: %+v", d.timeouts)
+				}
+				expectVote(t, d, Prevote, 0, "")
+				e.HandleTimeout(Timeout{Height: 2, Round: 0, Step: StepPrevote})
+			},
+			check: func(t *testing.T, e *Engine, d *fakeDriver) {
+				expectVote(t, d, Precommit, 0, "")
+			},
+		},
+		{
+			name: "nil polka precommits nil without waiting",
+			run: func(t *testing.T, e *Engine, d *fakeDriver) {
+				e.HandleTimeout(Timeout{Height: 2, Round: 0, Step: StepPropose})
+				mustHandleVote(t, e, vote(0, Prevote, 2, 0, ""))
+				mustHandleVote(t, e, vote(1, Prevote, 2, 0, ""))
+			},
+			check: func(t *testing.T, e *Engine, d *fakeDriver) {
+				expectVote(t, d, Precommit, 0, "")
+			},
+		},
+		{
+			name: "precommit timeout starts the next round",
+			run: func(t *testing.T, e *Engine, d *fakeDriver) {
+				e.HandleTimeout(Timeout{Height: 2, Round: 0, Step: StepPrecommit})
+			},
+			check: func(t *testing.T, e *Engine, d *fakeDriver) {
+				if _, round, step := e.State(); round != 1 || step != StepPropose {
+					t.Fatalf("state = round %d %s, want round 1 propose", round, step)
+				}
+			},
+		},
+		{
+			name: "timeouts of another round or height are ignored",
+			run: func(t *testing.T, e *Engine, d *fakeDriver) {
+				e.HandleTimeout(Timeout{Height: 2, Round: 1, Step: StepPropose})
+				e.HandleTimeout(Timeout{Height: 1, Round: 0, Step: StepPrecommit})
+			},
+			check: func(t *testing.T, e *Engine, d *fakeDriver) {
+				if len(d.votes) != 0 {
+					t.Fatalf("voted on a stale timeout: %+v", d.votes)
+				}
+				if _, round, step := e.State(); round != 0 || step != StepPropose {
+					t.Fatalf("state = round %d %s, want round 0 propose", round, step)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, d := startEngine(t, 3, nil)
+			tt.run(t, e, d)
+			tt.check(t, e, d)
+		})
+	}
+}
+
+func TestEngineLocking(t *testing.T) {
+	e, d := startEngine(t, 3, nil)
+	a, b := testBlock(2, "a"), testBlock(2, "b")
+
+	// 라운드 0: a가 2/3 사전 투표를 받아 a에 잠금
+	mustHandleProposal(t, e, proposal(0, 0, -1, a))
+	mustHandleVote(t, e, vote(0, Prevote, 2, 0, a.Hash))
+	mustHandleVote(t, e, vote(1, Prevote, 2, 0, a.Hash))
+	expectVote(t, d, Precommit, 0, a.Hash)
+
+	// 라운드 1: 잠금과 다른 새 블록에는 nil 사전 투표
+	e.HandleTimeout(Timeout{Height: 2, Round: 0, Step: StepPrecommit})
+	mustHandleProposal(t, e, proposal(0, 1, -1, b))
+	expectVote(t, d, Prevote, 1, "")
+	if e.lockedBlock.Hash != a.Hash {
+		t.Fatal("lock released without a newer polka")
+	}
+
+	// 라운드 2의 제안자는 역할 정보가 다르므로 b를 다시 제안할 수 없음
+	e.HandleTimeout(Timeout{Height: 2, Round: 1, Step: StepPrevote})
+	e.HandleTimeout(Timeout{Height: 2, Round: 1, Step: StepPrecommit})
+	if _, err := e.HandleProposal(proposal(1, 2, 1, b)); !errors.Is(err, ErrInvalidProposal) {
+		t.Fatalf("re-proposal with the role info of round 1 in round 2: err = %v, want %v", err, ErrInvalidProposal)
+	}
+
+	// 라운드 5: 역할 정보가 라운드 1과 같으므로 다시 제안할 수 있지만, 근거 라운드(1)의 2/3 사전 투표가 모이기 전에는 투표하지 않음
+	for round := 2; round < 5; round++ {
+		e.HandleTimeout(Timeout{Height: 2, Round: round, Step: StepPrecommit})
+	}
+	votes := len(d.votes)
+	mustHandleProposal(t, e, proposal(0, 5, 1, b))
+	if len(d.votes) != votes {
+		t.Fatalf("prevoted a proposal whose POL round has no polka: %+v", lastVote(d))
+	}
+
+	// 잠금 라운드 이후의 라운드 1에서 b가 2/3 사전 투표를 받았으므로 잠금과 달라도 b에 사전 투표
+	for _, voter := range []int{0, 1, 2} {
+		mustHandleVote(t, e, vote(voter, Prevote, 2, 1, b.Hash))
+	}
+	expectVote(t, d, Prevote, 5, b.Hash)
+}
+
+func TestEngineReproposesOnlyWithMatchingRoles(t *testing.T) {
+	tests := []struct {
+		name          string
+		self          int
+		round         int
+		wantRepropose bool
+	}{
+		{"same role info as the valid round", 0, 1, true},
+		{"another proposer", 1, 2, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, d := startEngine(t, tt.self, nil)
+			a := testBlock(2, "a")
+			if tt.self != 0 {
+				mustHandleProposal(t, e, proposal(0, 0, -1, a))
+			} else {
+				a = d.proposals[0].Block
+			}
+			for _, voter := range []int{0, 1, 2} {
+				mustHandleVote(t, e, vote(voter, Prevote, 2, 0, a.Hash))
+			}
+			for round := 0; round < tt.round; round++ {
+				e.HandleTimeout(Timeout{Height: 2, Round: round, Step: StepPrecommit})
+			}
+			p := d.proposals[len(d.proposals)-1]
+			if p.Round != tt.round {
+				t.Fatalf("last proposal is of round %d, want %d", p.Round, tt.round)
+			}
+			if reproposed := p.Block.Hash == a.Hash; reproposed != tt.wantRepropose {
+				t.Fatalf("re-proposed the valid block: %v, want %v", reproposed, tt.wantRepropose)
+			}
+			if !tt.wantRepropose && (p.POLRound != -1 || !blockchain.CompareRoleInfo(p.Block.RoleInfo, testRoles().ForRound(tt.round))) {
+				t.Fatalf("new proposal has POL round %d and role info %+v", p.POLRound, p.Block.RoleInfo)
+			}
+		})
+	}
+}
+
+func TestEngineRejectsInvalidProposalsWhenUnlocked(t *testing.T) {
+	e, d := startEngine(t, 3, nil)
+	a := testBlock(2, "a")
+	d.invalid[a.Hash] = true
+	mustHandleProposal(t, e, proposal(0, 0, -1, a))
+	expectVote(t, d, Prevote, 0, "")
+}
+
+func TestEngineSkipsToRoundWithOneThirdOfVotes(t *testing.T) {
+	tests := []struct {
+		name   string
+		voters []int
+		round  int
+	}{
+		{"single validator is not enough", []int{1}, 0},
+		{"more than a third of the seats", []int{1, 2}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, _ := startEngine(t, 3, nil)
+			for _, voter := range tt.voters {
+				mustHandleVote(t, e, vote(voter, Prevote, 2, 3, ""))
+			}
+			if _, round, _ := e.State(); round != tt.round {
+				t.Fatalf("round = %d, want %d", round, tt.round)
+			}
+		})
+	}
+}
+
+func TestEngineRejectsMessages(t *testing.T) {
+	a, b := testBlock(2, "a"), testBlock(2, "b")
+	forged := vote(1, Prevote, 2, 0, a.Hash)
+	forged.Signature.Signature = signers[2].sign(forged.signBytes())
+
+	tests := []struct {
+		name    string
+		prepare func(t *testing.T, e *Engine)
+		handle  func(e *Engine) (bool, error)
+		want    error
+	}{
+		{
+			name:   "vote from a non-committee member",
+			handle: func(e *Engine) (bool, error) { return e.HandleVote(vote(4, Prevote, 2, 0, a.Hash)) },
+			want:   ErrNotValidator,
+		},
+		{
+			name:   "vote signed by another key",
+			handle: func(e *Engine) (bool, error) { return e.HandleVote(forged) },
+			want:   ErrInvalidVote,
+		},
+		{
+			name: "conflicting vote",
+			prepare: func(t *testing.T, e *Engine) {
+				mustHandleVote(t, e, vote(1, Prevote, 2, 0, a.Hash))
+			},
+			handle: func(e *Engine) (bool, error) { return e.HandleVote(vote(1, Prevote, 2, 0, b.Hash)) },
+			want:   ErrConflictingVote,
+		},
+		{
+			name:   "vote for the last allowed round",
+			handle: func(e *Engine) (bool, error) { return e.HandleVote(vote(1, Prevote, 2, maxRoundLookahead, "")) },
+		},
+		{
+			name:   "vote too far ahead of the current round",
+			handle: func(e *Engine) (bool, error) { return e.HandleVote(vote(1, Prevote, 2, maxRoundLookahead+1, "")) },
+			want:   ErrFutureRound,
+		},
+		{
+			name:   "vote for a committed height",
+			handle: func(e *Engine) (bool, error) { return e.HandleVote(vote(1, Prevote, 1, 0, "")) },
+			want:   ErrStaleMessage,
+		},
+		{
+			name:   "vote too far ahead of the chain",
+			handle: func(e *Engine) (bool, error) { return e.HandleVote(vote(1, Prevote, 2+maxHeightLookahead, 0, "")) },
+			want:   ErrFutureMessage,
+		},
+		{
+			name: "future vote with a bad signature is not held",
+			handle: func(e *Engine) (bool, error) {
+				v := vote(1, Prevote, 3, 0, "")
+				v.Signature.Signature = signers[2].sign(v.signBytes())
+				return e.HandleVote(v)
+			},
+			want: ErrInvalidVote,
+		},
+		{
+			name: "too many held messages from one signer",
+			prepare: func(t *testing.T, e *Engine) {
+				for i := 0; i < maxPendingPerSigner; i++ {
+					mustHandleVote(t, e, vote(4, Prevote, 3, i, ""))
+				}
+			},
+			handle: func(e *Engine) (bool, error) { return e.HandleVote(vote(4, Precommit, 3, 0, "")) },
+			want:   ErrTooManyPending,
+		},
+		{
+			name:   "proposal not signed by the round proposer",
+			handle: func(e *Engine) (bool, error) { return e.HandleProposal(proposal(1, 0, -1, a)) },
+			want:   ErrInvalidProposal,
+		},
+		{
+			name:   "proposal with a POL round that is not earlier",
+			handle: func(e *Engine) (bool, error) { return e.HandleProposal(proposal(0, 1, 1, a)) },
+			want:   ErrInvalidProposal,
+		},
+		{
+			name:   "proposal too far ahead of the current round",
+			handle: func(e *Engine) (bool, error) { return e.HandleProposal(proposal(0, maxRoundLookahead+1, -1, a)) },
+			want:   ErrFutureRound,
+		},
+		{
+			name: "second proposal for the same round",
+			prepare: func(t *testing.T, e *Engine) {
+				mustHandleProposal(t, e, proposal(0, 0, -1, a))
+			},
+			handle: func(e *Engine) (bool, error) { return e.HandleProposal(proposal(0, 0, -1, b)) },
+			want:   ErrConflictingProposal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, _ := startEngine(t, 3, nil)
+			if tt.prepare != nil {
+				tt.prepare(t, e)
+			}
+			added, err := tt.handle(e)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			if tt.want != nil && added {
+				t.Fatal("rejected message reported as added")
+			}
+		})
+	}
+	t.Run("non-committee votes are not tallied", func(t *testing.T) {
+		e, _ := startEngine(t, 3, nil)
+		e.HandleVote(vote(4, Prevote, 2, 0, a.Hash))
+		if tally := e.prevotes[0]; tally != nil && tally.total != 0 {
+			t.Fatalf("tally counted a non-committee vote: %+v", tally)
+		}
+	})
+}
+
+func TestEngineSignWatermark(t *testing.T) {
+	a := testBlock(2, "a")
+	tests := []struct {
+		name     string
+		self     int
+		signed   SignState
+		saveErr  error
+		proposal *Proposal
+		want     *SignState // 서명 후 기록된 위치 (nil이면 서명하지 않아야 함)
+	}{
+		{
+			name:     "fresh node signs and records the position first",
+			self:     3,
+			proposal: proposal(0, 0, -1, a),
+			want:     &SignState{Height: 2, Round: 0, Step: StepPrevote, Hash: a.Hash},
+		},
+		{
+			name:     "signed a later position before restarting",
+			self:     3,
+			signed:   SignState{Height: 2, Round: 1, Step: StepPropose},
+			proposal: proposal(0, 0, -1, a),
+		},
+		{
+			name:     "signed another block at the same position",
+			self:     3,
+			signed:   SignState{Height: 2, Round: 0, Step: StepPrevote, Hash: testBlock(2, "b").Hash},
+			proposal: proposal(0, 0, -1, a),
+		},
+		{
+			name:     "signed the same vote before restarting",
+			self:     3,
+			signed:   SignState{Height: 2, Round: 0, Step: StepPrevote, Hash: a.Hash},
+			proposal: proposal(0, 0, -1, a),
+			want:     &SignState{Height: 2, Round: 0, Step: StepPrevote, Hash: a.Hash},
+		},
+		{
+			name:     "position cannot be saved",
+			self:     3,
+			saveErr:  errors.New("disk full"),
+			proposal: proposal(0, 0, -1, a),
+		},
+		{
+			name:   "proposer already signed another proposal for the round",
+			self:   0,
+			signed: SignState{Height: 2, Round: 0, Step: StepPropose, Hash: a.Hash},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, d := startEngine(t, tt.self, func(d *fakeDriver) {
+				d.signed, d.saveErr = tt.signed, tt.saveErr
+			})
+			if tt.proposal != nil {
+				mustHandleProposal(t, e, tt.proposal)
+			}
+			signed := len(d.votes) + len(d.proposals)
+			if tt.want == nil {
+				if signed != 0 {
+					t.Fatalf("signed %d messages, want none", signed)
+				}
+				if d.signed != tt.signed {
+					t.Fatalf("watermark moved to %+v", d.signed)
+				}
+				return
+			}
+			if signed != 1 || d.signed != *tt.want {
+				t.Fatalf("signed %d messages with watermark %+v, want 1 with %+v", signed, d.signed, *tt.want)
+			}
+		})
+	}
+}
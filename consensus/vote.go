@@ -0,0 +1,73 @@
+package consensus
+
+import (
+	"fmt"
+
+	"github.com/abcfe-op/abcfe-node/blockchain"
+	"github.com/abcfe-op/abcfe-node/wallet"
+)
+
+// 투표 종류
+type VoteType string
+
+const (
+	Prevote   VoteType = blockchain.PrevoteKind   // 제안 블록이 유효하고 잠금과 어긋나지 않는다는 1차 투표
+	Precommit VoteType = blockchain.PrecommitKind // 2/3의 사전 투표를 확인한 블록을 커밋하자는 2차 투표
+)
+
+// 라운드의 제안 블록
+type Proposal struct {
+	Height    int                           `json:"height"`
+	Round     int                           `json:"round"`
+	POLRound  int                           `json:"polRound"`  // 블록이 2/3의 사전 투표를 받은 이전 라운드 (새로 만든 블록이라면 -1)
+	Block     *blockchain.Block             `json:"block"`     // 제안 블록
+	Signature *blockchain.ValidateSignature `json:"signature"` // 라운드 제안자의 서명
+}
+
+// 검증자가 가십하는 서명된 투표
+type Vote struct {
+	Type         VoteType                      `json:"type"`
+	Height       int                           `json:"height"`
+	Round        int                           `json:"round"`
+	ProposalHash string                        `json:"proposalHash"` // 투표한 블록 해시 (빈 값이면 어느 블록에도 찬성하지 않는 nil 투표)
+	Signature    *blockchain.ValidateSignature `json:"signature"`
+}
+
+func (p *Proposal) signBytes() string {
+	return blockchain.ProposalPayload(p.Height, p.Round, p.POLRound, p.Block.Hash)
+}
+
+// 블록에 대한 사전 커밋의 서명은 블록의 커밋 서명(CommitPayload)으로 그대로 쓰인다
+func (v *Vote) signBytes() string {
+	return blockchain.VotePayload(string(v.Type), v.Height, v.Round, v.ProposalHash)
+}
+
+func (v *Vote) verify() bool {
+	return v.Signature != nil && wallet.Verify(v.Signature.Signature, v.signBytes(), v.Signature.Address)
+}
+
+// 한 라운드에서 한 종류의 투표 집계
+type tally struct {
+	votes map[string]*Vote // 검증자 주소 -> 투표
+	power map[string]int   // 블록 해시 (nil 투표는 빈 값) -> 투표한 검증자 자리 수
+	total int              // 투표한 검증자 자리 수
+}
+
+func newTally() *tally {
+	return &tally{votes: make(map[string]*Vote), power: make(map[string]int)}
+}
+
+// 투표 추가. 처음 받은 투표라면 true, 같은 검증자가 다른 블록에 투표했다면 에러
+func (t *tally) add(v *Vote, power int) (bool, error) {
+	address := v.Signature.Address
+	if prev, ok := t.votes[address]; ok {
+		if prev.ProposalHash != v.ProposalHash {
+			return false, fmt.Errorf("%w: %s", ErrConflictingVote, address)
+		}
+		return false, nil
+	}
+	t.votes[address] = v
+	t.power[v.ProposalHash] += power
+	t.total += power
+	return true, nil
+}
@@ -0,0 +1,89 @@
+package consensus
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestVoteVerify(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(v *Vote)
+		ok     bool
+	}{
+		{"signed vote", func(v *Vote) {}, true},
+		{"nil vote", func(v *Vote) { v.ProposalHash = ""; v.Signature = signers[1].signature(v.signBytes()) }, true},
+		{"no signature", func(v *Vote) { v.Signature = nil }, false},
+		{"changed type", func(v *Vote) { v.Type = Precommit }, false},
+		{"changed height", func(v *Vote) { v.Height++ }, false},
+		{"changed round", func(v *Vote) { v.Round++ }, false},
+		{"changed block", func(v *Vote) { v.ProposalHash = "aa" }, false},
+		{"claims another signer", func(v *Vote) { v.Signature.Address = signers[2].address }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := vote(1, Prevote, 2, 0, testBlock(2, "a").Hash)
+			tt.mutate(v)
+			if got := v.verify(); got != tt.ok {
+				t.Fatalf("verify = %v, want %v", got, tt.ok)
+			}
+		})
+	}
+}
+
+func TestTallyAdd(t *testing.T) {
+	a, b := testBlock(2, "a").Hash, testBlock(2, "b").Hash
+	tl := newTally()
+	steps := []struct {
+		vote  *Vote
+		power int
+		added bool
+		err   error
+	}{
+		{vote(0, Prevote, 2, 0, a), 1, true, nil},
+		{vote(1, Prevote, 2, 0, a), 2, true, nil},
+		{vote(2, Prevote, 2, 0, ""), 1, true, nil},
+		{vote(1, Prevote, 2, 0, a), 2, false, nil},                // 같은 투표를 다시 받음
+		{vote(0, Prevote, 2, 0, b), 1, false, ErrConflictingVote}, // 같은 라운드에서 다른 블록에 투표
+		{vote(2, Prevote, 2, 0, a), 1, false, ErrConflictingVote}, // nil 투표 후 블록에 투표
+	}
+	for i, s := range steps {
+		added, err := tl.add(s.vote, s.power)
+		if added != s.added || !errors.Is(err, s.err) {
+			t.Fatalf("step %d: added %v, err %v, want %v and %v", i, added, err, s.added, s.err)
+		}
+	}
+	if tl.power[a] != 3 || tl.power[""] != 1 || tl.power[b] != 0 || tl.total != 4 {
+		t.Fatalf("power = %v, total %d, want 3 for the block, 1 nil and total 4", tl.power, tl.total)
+	}
+}
+
+// 제안자나 특정 노드가 아니어도, 가십된 투표로 2/3를 확인한 검증자는 스스로 커밋을 조립함
+func TestEngineCommitsFromGossipedVotes(t *testing.T) {
+	e, d := startEngine(t, 3, nil)
+	if len(d.proposals) != 0 {
+		t.Fatal("validator that is not the proposer broadcast a proposal")
+	}
+	block := testBlock(2, "proposed")
+	mustHandleProposal(t, e, proposal(0, 0, -1, block))
+	expectVote(t, d, Prevote, 0, block.Hash)
+	for _, voter := range []int{0, 1} {
+		mustHandleVote(t, e, vote(voter, Prevote, 2, 0, block.Hash))
+	}
+	expectVote(t, d, Precommit, 0, block.Hash)
+	for _, voter := range []int{1, 2} {
+		mustHandleVote(t, e, vote(voter, Precommit, 2, 0, block.Hash))
+	}
+	if len(d.committed) != 1 || d.committed[0].Hash != block.Hash {
+		t.Fatalf("committed %d blocks, want the proposed block", len(d.committed))
+	}
+	signed := make(map[string]bool)
+	for _, sig := range d.committed[0].Signature {
+		signed[sig.Address] = true
+	}
+	for _, s := range []int{1, 2, 3} {
+		if !signed[signers[s].address] {
+			t.Fatalf("commit misses the precommit of %s", signers[s].port)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package db
+
+import (
+	"bytes"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// 자산 발행 기록 버킷
+//   - assets: "자산 ID/높이(8바이트)/트랜잭션 해시" -> 발행 기록
+//
+// 같은 자산의 기록은 발행된 블록 높이 순으로 정렬되므로, 첫 기록이 자산을 처음 만든 발행이 된다
+const assetBucket = "assets"
+
+// 자산별 발행 기록의 키 접두사
+func assetPrefix(asset string) []byte {
+	return []byte(asset + "/")
+}
+
+// 발행 기록의 키 생성
+func issuanceKey(asset string, height int, txID string) []byte {
+	key := assetPrefix(asset)
+	key = append(key, heightKey(height)...)
+	key = append(key, '/')
+	return append(key, []byte(txID)...)
+}
+
+func (DB) AssetIssuances(asset string) [][]byte {
+	return assetIssuances(asset)
+}
+func (DB) AssetIDs() []string {
+	return assetIDs()
+}
+
+// 발행 기록 추가
+func (b *Batch) AddIssuance(asset string, height int, txID string, data []byte) {
+	b.add(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(assetBucket)).Put(issuanceKey(asset, height, txID), data)
+	})
+}
+
+// 발행 기록 제거
+func (b *Batch) DeleteIssuance(asset string, height int, txID string) {
+	b.add(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(assetBucket)).Delete(issuanceKey(asset, height, txID))
+	})
+}
+
+// 발행 기록 전체 제거
+func (b *Batch) ClearIssuances() {
+	b.add(func(tx *bolt.Tx) error {
+		return resetBucket(tx, assetBucket)
+	})
+}
+
+// 특정 자산의 발행 기록을 오래된 순으로 조회
+func assetIssuances(asset string) [][]byte {
+	var list [][]byte
+	prefix := assetPrefix(asset)
+	db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket([]byte(assetBucket)).Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			list = append(list, copyBytes(v))
+		}
+		return nil
+	})
+	return list
+}
+
+// 발행 기록이 있는 자산 ID 전체 조회 (ID 순)
+func assetIDs() []string {
+	var ids []string
+	db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket([]byte(assetBucket)).Cursor()
+		for k, _ := c.First(); k != nil; {
+			id := string(k[:bytes.IndexByte(k, '/')])
+			ids = append(ids, id)
+			k, _ = c.Seek(append([]byte(id), '/'+1)) // 접두사 바로 다음 키, 즉 다음 자산의 첫 기록으로 이동
+		}
+		return nil
+	})
+	return ids
+}
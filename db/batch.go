@@ -0,0 +1,63 @@
+package db
+
+import (
+	bolt "go.etcd.io/bbolt"
+)
+
+// 여러 버킷에 대한 변경을 하나의 bolt 트랜잭션으로 묶어 원자적으로 반영하기 위한 구조체
+// (블록 저장, 체인 상태, UTXO 셋이 항상 같은 시점을 가리키도록 함)
+type Batch struct {
+	ops []func(tx *bolt.Tx) error
+}
+
+// 비어있는 배치 생성
+func NewBatch() *Batch {
+	return &Batch{}
+}
+
+// 배치에 변경 작업 추가
+func (b *Batch) add(op func(tx *bolt.Tx) error) {
+	b.ops = append(b.ops, op)
+}
+
+// 블록 데이터 저장
+func (b *Batch) SaveBlock(hash string, data []byte) {
+	b.add(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(blocksBucket)).Put([]byte(hash), data)
+	})
+}
+
+// 체인 데이터 저장
+func (b *Batch) SaveChain(data []byte) {
+	b.add(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(dataBucket)).Put([]byte(checkpoint), data)
+	})
+}
+
+// 블록 데이터 제거 (검증에 실패한 곁가지 블록을 버릴 때 사용)
+func (b *Batch) DeleteBlock(hash string) {
+	b.add(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(blocksBucket)).Delete([]byte(hash))
+	})
+}
+
+// 배치에 쌓인 변경 작업을 하나의 트랜잭션으로 반영. 하나라도 실패하면 전체가 취소된다
+func (DB) Write(b *Batch) error {
+	return db.Update(func(tx *bolt.Tx) error {
+		for _, op := range b.ops {
+			if err := op(tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// 버킷을 지운 뒤 다시 생성
+func resetBucket(tx *bolt.Tx, name string) error {
+	if err := tx.DeleteBucket([]byte(name)); err != nil && err != bolt.ErrBucketNotFound {
+		return err
+	}
+	_, err := tx.CreateBucket([]byte(name))
+	return err
+}
@@ -0,0 +1,28 @@
+package db
+
+import (
+	bolt "go.etcd.io/bbolt"
+)
+
+// 합의 엔진이 마지막으로 서명한 위치 (data 버킷)
+const signStateKey = "signState"
+
+func (DB) LoadSignState() []byte {
+	return loadSignState()
+}
+
+// 서명할 위치 저장 (서명 전에 반영해야 재시작 후 같은 위치에 다른 서명을 하지 않음)
+func (b *Batch) SaveSignState(data []byte) {
+	b.add(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(dataBucket)).Put([]byte(signStateKey), data)
+	})
+}
+
+func loadSignState() []byte {
+	var data []byte
+	db.View(func(tx *bolt.Tx) error {
+		data = copyBytes(tx.Bucket([]byte(dataBucket)).Get([]byte(signStateKey)))
+		return nil
+	})
+	return data
+}
@@ -28,15 +28,6 @@ func (DB) FindBlock(hash string) []byte {
 func (DB) LoadChain() []byte {
 	return loadChain()
 }
-func (DB) SaveBlock(hash string, data []byte) {
-	saveBlock(hash, data)
-}
-func (DB) SaveChain(data []byte) {
-	saveChain(data)
-}
-func (DB) DeleteAllBlocks() {
-	emptyBlocks()
-}
 
 // 노드 포트 번호를 이용하여 DB를 탐색 (Ex. blockchain_4000.db)
 func getDbName() string {
@@ -53,15 +44,13 @@ func InitDB() {
 			log.Error(err)
 		}
 		err = db.Update(func(tx *bolt.Tx) error {
-			_, err := tx.CreateBucketIfNotExists([]byte(dataBucket)) // bucket 생성
-			if err != nil {
-				log.Error(err)
+			for _, name := range []string{dataBucket, blocksBucket, utxoBucket, addressUtxoBucket, undoBucket, heightBucket, sideBlockBucket, txIndexBucket, addressHistoryBucket, assetBucket, validatorBucket} {
+				if _, err := tx.CreateBucketIfNotExists([]byte(name)); err != nil { // bucket 생성
+					log.Error(err)
+					return err
+				}
 			}
-			_, err = tx.CreateBucketIfNotExists([]byte(blocksBucket))
-			if err != nil {
-				log.Error(err)
-			}
-			return err
+			return nil
 		})
 		if err != nil {
 			log.Error(err)
@@ -69,33 +58,10 @@ func InitDB() {
 	}
 }
 
-// DB의 data 손상을 막기 위해 DB 닫기
+// DB의 data 손상을 막기 위해 DB 닫기 (닫은 뒤 InitDB로 다시 열 수 있음)
 func Close() {
 	db.Close()
-}
-
-// DB내에 블록 데이터 저장
-func saveBlock(hash string, data []byte) {
-	err := db.Update(func(tx *bolt.Tx) error {
-		bucket := tx.Bucket([]byte(blocksBucket))
-		err := bucket.Put([]byte(hash), data) // key: value = hash: data
-		return err
-	})
-	if err != nil {
-		log.Error(err)
-	}
-}
-
-// DB내에 체인 데이터 저장
-func saveChain(data []byte) {
-	err := db.Update(func(tx *bolt.Tx) error {
-		bucket := tx.Bucket([]byte(dataBucket))
-		err := bucket.Put([]byte(checkpoint), data) // key: value = blockchain: data
-		return err
-	})
-	if err != nil {
-		log.Error(err)
-	}
+	db = nil
 }
 
 // DB내의 체인 정보 체크포인트까지 불러오기
@@ -103,7 +69,7 @@ func loadChain() []byte {
 	var data []byte
 	db.View(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket([]byte(dataBucket))
-		data = bucket.Get([]byte(checkpoint))
+		data = copyBytes(bucket.Get([]byte(checkpoint)))
 		return nil
 	})
 	return data
@@ -114,22 +80,8 @@ func findBlock(hash string) []byte {
 	var data []byte
 	db.View(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket([]byte(blocksBucket))
-		data = bucket.Get([]byte(hash))
+		data = copyBytes(bucket.Get([]byte(hash)))
 		return nil
 	})
 	return data
 }
-
-// 모든 블록 제거 (노드간 블록높이 비교 후 대체 할때 사용)
-func emptyBlocks() {
-	db.Update(func(tx *bolt.Tx) error {
-		if err := tx.DeleteBucket([]byte(blocksBucket)); err != nil {
-			log.Error(err)
-		}
-		_, err := tx.CreateBucket([]byte(blocksBucket))
-		if err != nil {
-			log.Error(err)
-		}
-		return nil
-	})
-}
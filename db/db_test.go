@@ -0,0 +1,42 @@
+package db
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	os.Args = []string{os.Args[0], "-mode=rest", "-port=4000"} // DB 파일 이름은 실행 인자의 포트로 정해짐
+	os.Exit(m.Run())
+}
+
+// 테스트 디렉터리에 빈 DB를 열고, 테스트가 끝나면 닫음
+func openTestDB(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "node_dbs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+	InitDB()
+	t.Cleanup(Close)
+}
+
+func write(t *testing.T, fill func(b *Batch)) {
+	t.Helper()
+	batch := NewBatch()
+	fill(batch)
+	if err := (DB{}).Write(batch); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,224 @@
+package db
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// 색인 버킷
+//   - heights:        블록 높이(8바이트) -> 메인 체인의 블록 해시 (항상 사용)
+//   - txIndex:        트랜잭션 해시 -> 트랜잭션 위치 (블록 해시, 블록 내 순서)
+//   - addressHistory: 주소 키 + 높이(8바이트) + 트랜잭션 해시 -> 주소의 잔액 변화량 (주소 키는 addressKey 참고)
+//   - sideBlocks:     확정 높이 위의 곁가지 블록 해시 -> 블록 높이(8바이트) (블록 데이터는 blocks 버킷, 항상 사용)
+//
+// 색인(또는 UTXO 셋)이 반영하고 있는 최신 블록 해시는 data 버킷에 "tip/색인 이름" 키로 저장한다
+const (
+	heightBucket         = "heights"
+	txIndexBucket        = "txIndex"
+	addressHistoryBucket = "addressHistory"
+	sideBlockBucket      = "sideBlocks"
+	tipPrefix            = "tip/"
+)
+
+// 블록 높이 키 생성 (높이 순으로 정렬됨)
+func heightKey(height int) []byte {
+	var key [8]byte
+	binary.BigEndian.PutUint64(key[:], uint64(height))
+	return key[:]
+}
+
+// 주소별 기록의 키 접두사
+func historyPrefix(address string) []byte {
+	return addressKey(address)
+}
+
+// 주소별 기록의 키 생성 (같은 주소 안에서는 블록 높이 순으로 정렬됨)
+func historyKey(address string, height int, txID string) []byte {
+	key := historyPrefix(address)
+	key = append(key, heightKey(height)...)
+	return append(key, []byte(txID)...)
+}
+
+func (DB) FindHashByHeight(height int) string {
+	return findHashByHeight(height)
+}
+func (DB) FindTxIndex(txID string) []byte {
+	return findTxIndex(txID)
+}
+func (DB) AddressHistory(address, cursor string, limit int) ([][]byte, string) {
+	return addressHistory(address, cursor, limit)
+}
+func (DB) LoadIndexTip(name string) string {
+	return loadIndexTip(name)
+}
+func (DB) SideBlocks() map[string]int {
+	return sideBlocks()
+}
+
+// 블록 높이 -> 블록 해시 저장
+func (b *Batch) SaveHeight(height int, hash string) {
+	b.add(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(heightBucket)).Put(heightKey(height), []byte(hash))
+	})
+}
+
+// 블록 높이 -> 블록 해시 제거
+func (b *Batch) DeleteHeight(height int) {
+	b.add(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(heightBucket)).Delete(heightKey(height))
+	})
+}
+
+// 곁가지 블록 기록 저장
+func (b *Batch) SaveSideBlock(hash string, height int) {
+	b.add(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(sideBlockBucket)).Put([]byte(hash), heightKey(height))
+	})
+}
+
+// 곁가지 블록 기록 제거 (메인 체인에 연결되었거나 확정 높이 아래로 내려간 블록)
+func (b *Batch) DeleteSideBlock(hash string) {
+	b.add(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(sideBlockBucket)).Delete([]byte(hash))
+	})
+}
+
+// 트랜잭션 위치 저장
+func (b *Batch) IndexTx(txID string, data []byte) {
+	b.add(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(txIndexBucket)).Put([]byte(txID), data)
+	})
+}
+
+// 트랜잭션 위치 제거
+func (b *Batch) DeleteTxIndex(txID string) {
+	b.add(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(txIndexBucket)).Delete([]byte(txID))
+	})
+}
+
+// 주소별 기록 추가
+func (b *Batch) AddHistory(address string, height int, txID string, data []byte) {
+	b.add(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(addressHistoryBucket)).Put(historyKey(address, height, txID), data)
+	})
+}
+
+// 주소별 기록 제거
+func (b *Batch) DeleteHistory(address string, height int, txID string) {
+	b.add(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(addressHistoryBucket)).Delete(historyKey(address, height, txID))
+	})
+}
+
+// 블록 높이 색인 전체 제거
+func (b *Batch) ClearHeights() {
+	b.add(func(tx *bolt.Tx) error {
+		return resetBucket(tx, heightBucket)
+	})
+}
+
+// 트랜잭션 색인 전체 제거
+func (b *Batch) ClearTxIndex() {
+	b.add(func(tx *bolt.Tx) error {
+		return resetBucket(tx, txIndexBucket)
+	})
+}
+
+// 주소별 기록 전체 제거
+func (b *Batch) ClearAddressHistory() {
+	b.add(func(tx *bolt.Tx) error {
+		return resetBucket(tx, addressHistoryBucket)
+	})
+}
+
+// 색인(또는 UTXO 셋)이 반영하고 있는 최신 블록 해시 저장
+func (b *Batch) SaveIndexTip(name, hash string) {
+	b.add(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(dataBucket)).Put([]byte(tipPrefix+name), []byte(hash))
+	})
+}
+
+// 블록 높이로 블록 해시 조회
+func findHashByHeight(height int) string {
+	var hash []byte
+	db.View(func(tx *bolt.Tx) error {
+		hash = copyBytes(tx.Bucket([]byte(heightBucket)).Get(heightKey(height)))
+		return nil
+	})
+	return string(hash)
+}
+
+// 곁가지 블록 기록 전체 조회 (블록 해시 -> 높이)
+func sideBlocks() map[string]int {
+	list := make(map[string]int)
+	db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(sideBlockBucket)).ForEach(func(k, v []byte) error {
+			list[string(k)] = int(binary.BigEndian.Uint64(v))
+			return nil
+		})
+	})
+	return list
+}
+
+// 트랜잭션 위치 조회
+func findTxIndex(txID string) []byte {
+	var data []byte
+	db.View(func(tx *bolt.Tx) error {
+		data = copyBytes(tx.Bucket([]byte(txIndexBucket)).Get([]byte(txID)))
+		return nil
+	})
+	return data
+}
+
+// 주소별 기록을 최신 순으로 최대 limit개 조회. cursor가 주어지면 해당 위치 이전의 기록부터 조회하며,
+// 더 조회할 기록이 남아 있다면 다음 페이지의 cursor를 함께 반환
+func addressHistory(address, cursor string, limit int) ([][]byte, string) {
+	var list [][]byte
+	var next string
+	prefix := historyPrefix(address)
+	db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket([]byte(addressHistoryBucket)).Cursor()
+		// cursor가 없다면 접두사 바로 다음 키 이전, 즉 해당 주소의 가장 최신 기록부터 조회
+		end := append(append([]byte{}, prefix[:len(prefix)-1]...), prefix[len(prefix)-1]+1)
+		if cursor != "" {
+			start, err := hex.DecodeString(cursor)
+			if err != nil {
+				return nil
+			}
+			end = append(append([]byte{}, prefix...), start...)
+		}
+		var last []byte
+		for k, v := seekBefore(c, end); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Prev() {
+			if len(list) == limit {
+				next = hex.EncodeToString(last[len(prefix):])
+				break
+			}
+			list = append(list, copyBytes(v))
+			last = copyBytes(k)
+		}
+		return nil
+	})
+	return list, next
+}
+
+// 주어진 키보다 작은 키 중 가장 큰 키로 이동
+func seekBefore(c *bolt.Cursor, key []byte) ([]byte, []byte) {
+	if k, _ := c.Seek(key); k == nil {
+		return c.Last()
+	}
+	return c.Prev()
+}
+
+// 색인(또는 UTXO 셋)이 반영하고 있는 최신 블록 해시 불러오기
+func loadIndexTip(name string) string {
+	var hash []byte
+	db.View(func(tx *bolt.Tx) error {
+		hash = copyBytes(tx.Bucket([]byte(dataBucket)).Get([]byte(tipPrefix + name)))
+		return nil
+	})
+	return string(hash)
+}
@@ -0,0 +1,123 @@
+package db
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestAddressHistoryPages(t *testing.T) {
+	openTestDB(t)
+	// 같은 높이에 여러 트랜잭션이 있는 기록과, 접두사가 같은 다른 주소의 기록
+	type record struct {
+		address string
+		height  int
+		txID    string
+	}
+	records := []record{
+		{"ab", 1, "aa"},
+		{"ab", 2, "bb"},
+		{"ab", 2, "cc"},
+		{"ab", 256, "dd"},
+		{"ab", 3, "ee"},
+		{"abcd", 4, "ff"},
+		{"a", 5, "11"},
+	}
+	write(t, func(b *Batch) {
+		for _, r := range records {
+			b.AddHistory(r.address, r.height, r.txID, []byte(fmt.Sprintf("%d/%s", r.height, r.txID)))
+		}
+	})
+	newestFirst := []string{"256/dd", "3/ee", "2/cc", "2/bb", "1/aa"}
+	store := DB{}
+	for _, limit := range []int{1, 2, 3, 5, 6} {
+		t.Run(fmt.Sprint("limit ", limit), func(t *testing.T) {
+			var got []string
+			cursor := ""
+			for pages := 0; ; pages++ {
+				if pages > len(newestFirst) {
+					t.Fatal("pagination does not end")
+				}
+				list, next := store.AddressHistory("ab", cursor, limit)
+				if len(list) > limit {
+					t.Fatalf("page has %d entries, limit %d", len(list), limit)
+				}
+				for _, v := range list {
+					got = append(got, string(v))
+				}
+				if next == "" {
+					break
+				}
+				cursor = next
+			}
+			if fmt.Sprint(got) != fmt.Sprint(newestFirst) {
+				t.Fatalf("history = %v, want %v", got, newestFirst)
+			}
+		})
+	}
+
+	tests := []struct {
+		name    string
+		address string
+		cursor  string
+		want    []string
+	}{
+		{"longer address sharing the prefix", "abcd", "", []string{"4/ff"}},
+		{"shorter address sharing the prefix", "a", "", []string{"5/11"}},
+		{"unknown address", "cd", "", nil},
+		{"malformed cursor", "ab", "zz", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, next := store.AddressHistory(tt.address, tt.cursor, 10)
+			var got []string
+			for _, v := range list {
+				got = append(got, string(v))
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) || next != "" {
+				t.Fatalf("history = %v (next %q), want %v", got, next, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeleteHistoryRemovesOnlyTheEntry(t *testing.T) {
+	openTestDB(t)
+	write(t, func(b *Batch) {
+		b.AddHistory("ab", 1, "aa", []byte("1/aa"))
+		b.AddHistory("ab", 2, "bb", []byte("2/bb"))
+	})
+	write(t, func(b *Batch) { b.DeleteHistory("ab", 2, "bb") })
+	list, _ := DB{}.AddressHistory("ab", "", 10)
+	if len(list) != 1 || string(list[0]) != "1/aa" {
+		t.Fatalf("history after delete = %q", list)
+	}
+}
+
+func TestHeightIndex(t *testing.T) {
+	openTestDB(t)
+	write(t, func(b *Batch) {
+		b.SaveHeight(1, "aa")
+		b.SaveHeight(2, "bb")
+		b.SaveIndexTip("heights", "bb")
+	})
+	write(t, func(b *Batch) { b.DeleteHeight(2) })
+	store := DB{}
+	if store.FindHashByHeight(1) != "aa" || store.FindHashByHeight(2) != "" {
+		t.Fatalf("heights = %q, %q", store.FindHashByHeight(1), store.FindHashByHeight(2))
+	}
+	if store.LoadIndexTip("heights") != "bb" || store.LoadIndexTip("txIndex") != "" {
+		t.Fatal("index tips are not stored per index")
+	}
+}
+
+func TestSideBlocks(t *testing.T) {
+	openTestDB(t)
+	write(t, func(b *Batch) {
+		b.SaveSideBlock("aa", 3)
+		b.SaveSideBlock("bb", 300)
+	})
+	write(t, func(b *Batch) { b.DeleteSideBlock("aa") })
+	if got := (DB{}).SideBlocks(); len(got) != 1 || got["bb"] != 300 {
+		t.Fatalf("side blocks = %v", got)
+	}
+}
@@ -0,0 +1,141 @@
+package db
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// UTXO 셋은 두 개의 버킷으로 관리한다
+//   - utxos:        "트랜잭션 해시:출력 인덱스" -> 주소
+//   - addressUtxos: 주소 키 + "트랜잭션 해시:출력 인덱스" -> UTXO 데이터
+//
+// 주소 키는 주소 길이(2바이트)를 앞에 붙여, 한 주소가 다른 주소의 접두사가 되더라도 탐색 범위가 겹치지 않게 한다
+//
+// 주소별 조회는 addressUtxos의 접두사 탐색으로, 입력 검증 시의 단건 조회는 두 버킷을 차례로 조회하여 처리한다
+// 체인 재구성 시 블록을 되돌릴 수 있도록, 블록이 사용한 출력 목록(undo 기록)을 undo 버킷에 블록 해시별로 보관한다
+const (
+	utxoBucket        = "utxos"
+	addressUtxoBucket = "addressUtxos"
+	undoBucket        = "undo"
+)
+
+// 주소별 버킷의 키 접두사 생성 (주소 길이 + 주소)
+func addressKey(address string) []byte {
+	key := make([]byte, 2, 2+len(address))
+	binary.BigEndian.PutUint16(key, uint16(len(address)))
+	return append(key, address...)
+}
+
+// 주소별 UTXO 키 생성
+func addressUtxoKey(address, outpoint string) []byte {
+	return append(addressKey(address), outpoint...)
+}
+
+func (DB) FindUTXO(outpoint string) []byte {
+	return findUTXO(outpoint)
+}
+func (DB) UTXOsByAddress(address string) [][]byte {
+	return utxosByAddress(address)
+}
+func (DB) FindUndo(hash string) []byte {
+	return findUndo(hash)
+}
+
+// 새로운 UTXO 추가
+func (b *Batch) AddUTXO(outpoint, address string, data []byte) {
+	b.add(func(tx *bolt.Tx) error {
+		if err := tx.Bucket([]byte(utxoBucket)).Put([]byte(outpoint), []byte(address)); err != nil {
+			return err
+		}
+		return tx.Bucket([]byte(addressUtxoBucket)).Put(addressUtxoKey(address, outpoint), data)
+	})
+}
+
+// 입력으로 사용된 UTXO 제거
+func (b *Batch) SpendUTXO(outpoint string) {
+	b.add(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(utxoBucket))
+		address := bucket.Get([]byte(outpoint))
+		if address == nil {
+			return nil
+		}
+		if err := tx.Bucket([]byte(addressUtxoBucket)).Delete(addressUtxoKey(string(address), outpoint)); err != nil {
+			return err
+		}
+		return bucket.Delete([]byte(outpoint))
+	})
+}
+
+// UTXO 셋 전체 제거 (체인 대체 또는 재색인 시 사용)
+func (b *Batch) ClearUTXOs() {
+	b.add(func(tx *bolt.Tx) error {
+		if err := resetBucket(tx, utxoBucket); err != nil {
+			return err
+		}
+		return resetBucket(tx, addressUtxoBucket)
+	})
+}
+
+// 블록의 undo 기록 저장
+func (b *Batch) SaveUndo(hash string, data []byte) {
+	b.add(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(undoBucket)).Put([]byte(hash), data)
+	})
+}
+
+// undo 기록 전체 제거
+func (b *Batch) ClearUndo() {
+	b.add(func(tx *bolt.Tx) error {
+		return resetBucket(tx, undoBucket)
+	})
+}
+
+// 특정 UTXO 조회
+func findUTXO(outpoint string) []byte {
+	var data []byte
+	db.View(func(tx *bolt.Tx) error {
+		address := tx.Bucket([]byte(utxoBucket)).Get([]byte(outpoint))
+		if address == nil {
+			return nil
+		}
+		data = copyBytes(tx.Bucket([]byte(addressUtxoBucket)).Get(addressUtxoKey(string(address), outpoint)))
+		return nil
+	})
+	return data
+}
+
+// 특정 주소의 UTXO 전체 조회
+func utxosByAddress(address string) [][]byte {
+	var list [][]byte
+	prefix := addressKey(address)
+	db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket([]byte(addressUtxoBucket)).Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			list = append(list, copyBytes(v))
+		}
+		return nil
+	})
+	return list
+}
+
+// 블록의 undo 기록 조회
+func findUndo(hash string) []byte {
+	var data []byte
+	db.View(func(tx *bolt.Tx) error {
+		data = copyBytes(tx.Bucket([]byte(undoBucket)).Get([]byte(hash)))
+		return nil
+	})
+	return data
+}
+
+// bolt의 값은 트랜잭션 안에서만 유효하므로 복사해서 반환
+func copyBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	c := make([]byte, len(b))
+	copy(c, b)
+	return c
+}
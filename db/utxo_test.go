@@ -0,0 +1,76 @@
+package db
+
+import (
+	"sort"
+	"testing"
+)
+
+func values(list [][]byte) []string {
+	var out []string
+	for _, v := range list {
+		out = append(out, string(v))
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestUTXOsByAddressDoesNotMatchOtherAddresses(t *testing.T) {
+	openTestDB(t)
+	// 한 주소가 다른 주소의 접두사이거나, 주소와 outpoint의 경계를 옮기면 같은 문자열이 되는 경우
+	utxos := []struct{ outpoint, address string }{
+		{"11:0", "ab"},
+		{"cd11:0", "ab"},
+		{"11:1", "abcd"},
+		{"22:0", "abcd"},
+		{"33:0", ""},
+	}
+	write(t, func(b *Batch) {
+		for _, u := range utxos {
+			b.AddUTXO(u.outpoint, u.address, []byte(u.address+"@"+u.outpoint))
+		}
+	})
+	tests := []struct {
+		address string
+		want    []string
+	}{
+		{"ab", []string{"ab@11:0", "ab@cd11:0"}},
+		{"abcd", []string{"abcd@11:1", "abcd@22:0"}},
+		{"a", nil},
+		{"", []string{"@33:0"}},
+	}
+	store := DB{}
+	for _, tt := range tests {
+		got := values(store.UTXOsByAddress(tt.address))
+		if len(got) != len(tt.want) {
+			t.Fatalf("UTXOs of %q = %v, want %v", tt.address, got, tt.want)
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Fatalf("UTXOs of %q = %v, want %v", tt.address, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestSpendUTXORemovesBothEntries(t *testing.T) {
+	openTestDB(t)
+	write(t, func(b *Batch) {
+		b.AddUTXO("aa:0", "ab", []byte("one"))
+		b.AddUTXO("aa:1", "ab", []byte("two"))
+	})
+	write(t, func(b *Batch) {
+		b.SpendUTXO("aa:0")
+		b.SpendUTXO("unknown:0") // 없는 UTXO는 무시
+	})
+	store := DB{}
+	if store.FindUTXO("aa:0") != nil {
+		t.Fatal("spent UTXO is still found")
+	}
+	if got := values(store.UTXOsByAddress("ab")); len(got) != 1 || got[0] != "two" {
+		t.Fatalf("UTXOs after spending = %v, want [two]", got)
+	}
+	write(t, func(b *Batch) { b.ClearUTXOs() })
+	if store.FindUTXO("aa:1") != nil || len(store.UTXOsByAddress("ab")) != 0 {
+		t.Fatal("UTXO set was not cleared")
+	}
+}
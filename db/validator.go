@@ -0,0 +1,46 @@
+package db
+
+import (
+	bolt "go.etcd.io/bbolt"
+)
+
+// 검증자 등록부 버킷
+//   - validators: "스테이킹 트랜잭션 해시:출력 인덱스" -> 검증자 등록 기록 (주소, 스테이킹 수량, 노드 포트, 상태)
+const validatorBucket = "validators"
+
+func (DB) Validators() [][]byte {
+	return validators()
+}
+
+// 검증자 등록 기록 저장 (같은 스테이킹 출력의 기록은 덮어씀)
+func (b *Batch) SaveValidator(stake string, data []byte) {
+	b.add(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(validatorBucket)).Put([]byte(stake), data)
+	})
+}
+
+// 검증자 등록 기록 제거
+func (b *Batch) DeleteValidator(stake string) {
+	b.add(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(validatorBucket)).Delete([]byte(stake))
+	})
+}
+
+// 검증자 등록 기록 전체 제거
+func (b *Batch) ClearValidators() {
+	b.add(func(tx *bolt.Tx) error {
+		return resetBucket(tx, validatorBucket)
+	})
+}
+
+// 검증자 등록 기록 전체 조회 (스테이킹 출력 순)
+func validators() [][]byte {
+	var list [][]byte
+	db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(validatorBucket)).ForEach(func(k, v []byte) error {
+			list = append(list, copyBytes(v))
+			return nil
+		})
+	})
+	return list
+}
@@ -2,12 +2,14 @@ package p2p
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/abcfe-op/abcfe-node/blockchain"
 	log "github.com/abcfe-op/abcfe-node/common/logger"
 	"github.com/abcfe-op/abcfe-node/common/utils"
+	"github.com/abcfe-op/abcfe-node/consensus"
 )
 
 // 메세지 번호
@@ -16,26 +18,31 @@ type MessageKind int
 // 메세지 식별자
 const (
 	MessageNewestBlock MessageKind = iota
-	MessageAllBlocksRequest
-	MessageAllBlocksResponse
+	MessageBlocksRequest
+	MessageBlocksResponse
 	MessageNewBlockNotify
 	MessageNewTxNotify
 	MessageNewPeerNotify
-	MessageNewProposerNotify
-	MessageNewValidatorNotify
-	MessageValidateRequest
-	MessageValidateResponse
-	MessageProposalResponse
+	MessageProposal
+	MessageVote
 )
 
-var validatedResults []*blockchain.ValidatedInfo
-
 // 메세지 구조체
 type Message struct {
 	Kind    MessageKind
 	Payload []byte
 }
 
+const (
+	syncPageSize  = 50                                // 동기화 시 한 번에 주고받는 블록 수
+	maxSyncBlocks = 2*blockchain.Epoch + syncPageSize // 분기점을 찾는 동안 모아두는 블록 수의 상한 (확정 높이 아래의 분기는 어차피 받아들이지 않음)
+)
+
+// 블록 범위 요청: peer의 메인 체인에서 To 높이부터 아래로 한 페이지 (To가 peer의 최신 블록보다 높다면 최신 블록부터)
+type BlocksRequest struct {
+	To int `json:"to"`
+}
+
 // peer에게 보낼 메세지 생성
 func makeMessage(kind MessageKind, payload interface{}) []byte {
 	jsonPayload, err := utils.ToJSON(payload)
@@ -58,72 +65,114 @@ func makeMessage(kind MessageKind, payload interface{}) []byte {
 // 새로 연결된 peer에게 저장된 데이터를 비교하기 위해, 최근 블록 전송
 func sendNewestBlock(p *peer) {
 	fmt.Printf("Sending newest block to %s\n", p.key)
-	block, err := blockchain.FindBlock(blockchain.Blockchain().NewestHash)
+	hash, _ := blockchain.Blockchain().Tip()
+	block, err := blockchain.FindBlock(hash)
 	if err != nil {
 		log.Error(err)
 	}
 	m := makeMessage(MessageNewestBlock, block)
-	p.inbox <- m
+	p.send(m)
+}
+
+// 상대 peer가 더 높은 블록 높이를 가지고 있을경우, 우리 최신 블록 위의 한 페이지를 요청
+func requestNextBlocks(p *peer, height int) {
+	requestBlocks(p, height+syncPageSize)
 }
 
-// 상대 peer가 더 높은 블록 높이를 가지고 있을경우, 대체하기 위해 모든 블록 요청
-func requestAllBlocks(p *peer) {
-	m := makeMessage(MessageAllBlocksRequest, nil)
-	p.inbox <- m
+// to 높이부터 아래로 한 페이지의 블록 요청
+func requestBlocks(p *peer, to int) {
+	m := makeMessage(MessageBlocksRequest, BlocksRequest{To: to})
+	p.send(m)
 }
 
-// requestAllBlocks의 응답으로 peer에게 모든 블록 전송
-func sendAllBlocks(p *peer) {
-	m := makeMessage(MessageAllBlocksResponse, blockchain.Blocks(blockchain.Blockchain()))
-	p.inbox <- m
+// requestBlocks의 응답으로 요청한 높이부터 아래로 한 페이지의 블록 전송 (최신 순)
+func sendBlocks(p *peer, req BlocksRequest) {
+	page, err := blockchain.BlocksByRange(blockchain.Blockchain(), 0, req.To, syncPageSize)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	m := makeMessage(MessageBlocksResponse, page)
+	p.send(m)
+}
+
+// 받은 블록 페이지 처리: 가장 오래된 블록의 부모를 모른다면 분기점을 찾을 때까지 아래 페이지를 더 요청하고,
+// 분기점을 찾았다면 모은 블록을 오래된 순으로 임포트한 뒤 peer가 더 높다면 다음 페이지를 요청
+func receiveBlocks(page *blockchain.BlocksPage, p *peer) {
+	p.syncBlocks = append(p.syncBlocks, page.Blocks...)
+	if len(p.syncBlocks) == 0 {
+		return
+	}
+	if oldest := p.syncBlocks[len(p.syncBlocks)-1]; oldest != nil && oldest.PrevHash != "" {
+		if _, err := blockchain.FindBlock(oldest.PrevHash); err != nil {
+			if page.NextCursor > 0 && len(p.syncBlocks) < maxSyncBlocks {
+				requestBlocks(p, page.NextCursor)
+				return
+			}
+			fmt.Printf("No fork point within %d blocks from %s\n", len(p.syncBlocks), p.key)
+			p.syncBlocks = nil
+			return
+		}
+	}
+	blocks := p.syncBlocks
+	p.syncBlocks = nil
+	err := blockchain.Blockchain().Replace(blocks)
+	if err != nil && !errors.Is(err, blockchain.ErrShorterChain) {
+		p.penalize(err)
+		return
+	}
+	if err == nil {
+		syncConsensus()
+	}
+	if newest := blocks[0]; newest != nil && int64(newest.Height) < p.height.Load() {
+		requestNextBlocks(p, newest.Height) // 아직 받지 않은 블록까지 합치면 peer의 체인이 선택될 수도 있음
+	} else if err != nil {
+		// peer의 최신 블록까지 받고도 포크 선택 규칙으로 현재 체인을 유지했다면 이 peer까지는 따라잡은 것으로 봄
+		_, height := blockchain.Blockchain().Tip()
+		p.height.Store(int64(height))
+	}
 }
 
-// 검증자에게 제안하고자 하는 블록을 보내 검증 요청
-func requestValidateBlock(v *validateRequest, p *peer) {
-	m := makeMessage(MessageValidateRequest, v)
-	p.inbox <- m
+// 제안 블록 전파 (검증자는 확인 후 투표하고, 모든 노드는 투표를 모으기 위해 저장 후 중계)
+func notifyProposal(proposal *consensus.Proposal, p *peer) {
+	m := makeMessage(MessageProposal, proposal)
+	p.send(m)
 }
 
 // 제안자가 모든 검증과정을 거치고 블록을 추가했을때, peer들에게 새로 추가된 블록을 저장하라고 알림
 func notifyNewBlock(b *blockchain.Block, p *peer) {
 	m := makeMessage(MessageNewBlockNotify, b)
-	p.inbox <- m
+	p.send(m)
 }
 
 // 트랜잭션이 생성되었을때, peer들에게 새로 추가된 트랜잭션을 저장하라고 알림
 func notifyNewTx(tx *blockchain.Tx, p *peer) {
 	m := makeMessage(MessageNewTxNotify, tx)
-	p.inbox <- m
+	p.send(m)
 }
 
 // 새로운 peer와 연결되었을때, 기존 연결되어있던 peer들에게 새로운 peer가 연결되었다고 알림
 func notifyNewPeer(address string, p *peer) {
 	m := makeMessage(MessageNewPeerNotify, address)
-	p.inbox <- m
-}
-
-// 새롭게 뽑힌 블록 제안자에게, 제안자로 선출되었다고 알림
-func notifyNewProposer(roleInfo *blockchain.RoleInfo, p *peer) {
-	m := makeMessage(MessageNewProposerNotify, roleInfo)
-	p.inbox <- m
+	p.send(m)
 }
 
-// 새롭게 뽑힌 검증자에게, 검증자로 선출되었다고 알림
-func notifyNewValidator(p *peer) {
-	m := makeMessage(MessageNewValidatorNotify, nil)
-	p.inbox <- m
+// 검증자의 서명된 투표를 가십
+func notifyVote(v *consensus.Vote, p *peer) {
+	m := makeMessage(MessageVote, v)
+	p.send(m)
 }
 
-// PoS 스테이킹 풀 제공자 노드에게 블록 검증 결과를 알림
-func notifyValidatedResult(validatedInfo *blockchain.ValidatedInfo, p *peer) {
-	m := makeMessage(MessageValidateResponse, validatedInfo)
-	p.inbox <- m
+// 동기화나 가십으로 블록을 연결했다면 합의 엔진을 새 높이로 이동
+func syncConsensus() {
+	if e := consensus.Current(); e != nil {
+		e.Sync()
+	}
 }
 
-// PoS 스테이킹 풀 제공자 노드가 블록 검증결과를 종합하고 과반수를 매겨 제안자에게 제안결과를 알림
-func notifyProposalResult(proposalResult *blockchain.ValidatedInfo, p *peer) {
-	m := makeMessage(MessageProposalResponse, proposalResult)
-	p.inbox <- m
+// 잘못된 서명이나 내용의 제안과 투표를 전달한 peer만 벌점 (지난 높이나 다른 역할 정보로 인한 거절은 정상적인 지연일 수 있음)
+func isInvalidConsensusMessage(err error) bool {
+	return errors.Is(err, consensus.ErrInvalidProposal) || errors.Is(err, consensus.ErrInvalidVote)
 }
 
 // 메세지를 수신과 관련된 핸들러
@@ -135,43 +184,77 @@ func handleMsg(m *Message, p *peer) {
 		if err := json.Unmarshal(m.Payload, &payload); err != nil {
 			log.Error(err)
 		}
-		b, err := blockchain.FindBlock(blockchain.Blockchain().NewestHash)
+		hash, _ := blockchain.Blockchain().Tip()
+		b, err := blockchain.FindBlock(hash)
 		if err != nil {
 			log.Error(err)
 		}
+		p.height.Store(int64(payload.Height))
 		if payload.Height > b.Height { // 우리 노드의 최신블록보다 블록높이가 높은지 확인 -> 뒤처지는지 앞서는지
-			fmt.Printf("Request all block from %s\n", p.key)
-			requestAllBlocks(p)
+			fmt.Printf("Request blocks from %s\n", p.key)
+			requestNextBlocks(p, b.Height)
 		} else if payload.Height < b.Height {
 			fmt.Printf("Sending newest block from %s\n", p.key)
 			sendNewestBlock(p)
 		}
 
-	case MessageAllBlocksRequest:
-		fmt.Printf("%s wants all the blocks.\n", p.key)
-		sendAllBlocks(p)
+	case MessageBlocksRequest:
+		var payload BlocksRequest
+		if err := json.Unmarshal(m.Payload, &payload); err != nil {
+			p.penalize(err)
+			break
+		}
+		sendBlocks(p, payload)
 
-	case MessageAllBlocksResponse:
-		fmt.Printf("Received all the blocks from %s\n", p.key)
-		var payload []*blockchain.Block
+	case MessageBlocksResponse:
+		var payload blockchain.BlocksPage
 		if err := json.Unmarshal(m.Payload, &payload); err != nil {
-			log.Error(err)
+			p.penalize(err)
+			break
 		}
-		blockchain.Blockchain().Replace(payload)
+		fmt.Printf("Received %d blocks from %s\n", len(payload.Blocks), p.key)
+		receiveBlocks(&payload, p)
 
 	case MessageNewBlockNotify:
 		var payload *blockchain.Block
 		if err := json.Unmarshal(m.Payload, &payload); err != nil {
 			log.Error(err)
 		}
-		blockchain.Blockchain().AddPeerBlock(payload)
+		if payload != nil && int64(payload.Height) > p.height.Load() {
+			p.height.Store(int64(payload.Height))
+		}
+		if err := blockchain.Blockchain().AddPeerBlock(payload); err != nil {
+			if errors.Is(err, blockchain.ErrPrevHashMismatch) || errors.Is(err, blockchain.ErrHeightMismatch) {
+				if _, height := blockchain.Blockchain().Tip(); payload.Height >= height { // 체인이 뒤처졌거나 모르는 곁가지라면 블록 범위를 요청
+					requestNextBlocks(p, height)
+				}
+				break
+			}
+			if errors.Is(err, blockchain.ErrBelowCommitted) { // 확정 높이 아래의 곁가지는 늦게 도착했을 뿐이므로 건너뜀
+				break
+			}
+			p.penalize(err)
+			break
+		}
+		syncConsensus()
 
 	case MessageNewTxNotify:
 		var payload *blockchain.Tx
 		if err := json.Unmarshal(m.Payload, &payload); err != nil {
-			log.Error(err)
+			p.penalize(err)
+			break
 		}
-		blockchain.Mempool().AddPeerTx(payload)
+		// 로컬 트랜잭션과 같은 멤풀 검증을 거치며, 유효하지 않은 트랜잭션을 전달한 피어는 벌점을 받음
+		// 멤풀에 새로 들어간 트랜잭션만 다른 peer에게 중계 (이미 가진 트랜잭션은 거절되므로 중계가 되돌아오지 않음)
+		if err := blockchain.Mempool().AddPeerTx(payload); err != nil {
+			if blockchain.IsInvalidRelay(err) {
+				p.penalize(err)
+				break
+			}
+			fmt.Printf("Rejected a transaction from %s: %v\n", p.key, err)
+			break
+		}
+		relay(p, func(to *peer) { notifyNewTx(payload, to) })
 
 	case MessageNewPeerNotify:
 		var payload string
@@ -181,88 +264,40 @@ func handleMsg(m *Message, p *peer) {
 		parts := strings.Split(payload, ":")
 		AddPeer(parts[0], parts[1], parts[2], false)
 
-	case MessageNewProposerNotify:
-		var payload *blockchain.RoleInfo
+	case MessageProposal:
+		var payload *consensus.Proposal
 		if err := json.Unmarshal(m.Payload, &payload); err != nil {
-			log.Error(err)
+			p.penalize(err)
+			break
 		}
-		fmt.Printf("At %d height, this %s node has been pointed as a Proposer\n", blockchain.Blockchain().Height+1, payload.ProposerPort)
-		newBlock := blockchain.CreateBlock(blockchain.Blockchain().NewestHash, blockchain.Blockchain().Height+1, payload.ProposerPort, payload, false)
-		strNewBlock, err := utils.ToString(newBlock)
-		if err != nil {
-			log.Error(err)
+		e := consensus.Current()
+		if e == nil {
+			break
 		}
-		fmt.Println("Just created new block :", strNewBlock)
-		SendProposalBlock(payload, newBlock)
-
-	case MessageNewValidatorNotify:
-		fmt.Printf("At %d height, this node has been pointed as a Validator for 3 blocks\n", blockchain.Blockchain().Height+1)
-
-	case MessageValidateRequest:
-		var payload *validateRequest
-		if err := json.Unmarshal(m.Payload, &payload); err != nil {
-			log.Error(err)
+		added, err := e.HandleProposal(payload)
+		if err != nil && isInvalidConsensusMessage(err) {
+			p.penalize(err)
 		}
-
-		strPayload, err := utils.ToString(payload)
-		if err != nil {
-			log.Error(err)
-		}
-		fmt.Println("comparisonBlock: ", strPayload)
-		newBlock := blockchain.CreateBlock(blockchain.Blockchain().NewestHash, blockchain.Blockchain().Height+1, payload.RoleInfo.ProposerPort, payload.RoleInfo, false)
-		strNewBlock, err := utils.ToString(newBlock)
-		if err != nil {
-			log.Error(err)
-		}
-		fmt.Println("comparisonBlock: ", strNewBlock)
-		result := blockchain.ValidateBlock(payload.RoleInfo, payload.Block, newBlock, payload.Port)
-		strResult, err := utils.ToString(result.Result)
-		if err != nil {
-			log.Error(err)
+		if added {
+			relay(p, func(to *peer) { notifyProposal(payload, to) })
 		}
-		fmt.Println("validate result: ", strResult)
-		SendValidatedResult(result)
 
-	case MessageValidateResponse:
-		var payload *blockchain.ValidatedInfo
+	case MessageVote:
+		var payload *consensus.Vote
 		if err := json.Unmarshal(m.Payload, &payload); err != nil {
-			log.Error(err)
+			p.penalize(err)
+			break
 		}
-		validatedResults = append(validatedResults, payload)
-		var validateSignature []*blockchain.ValidateSignature
-		if len(validatedResults) == 3 {
-			for _, v := range validatedResults {
-				fmt.Print(v.Port, " ")
-				validateSignature = append(validateSignature, v.Signature)
-			}
-			fmt.Println("\nAll Validator's message is arrived")
-			result := blockchain.CalculateMajority(validatedResults)
-			proposalResult := &blockchain.ValidatedInfo{
-				ProposerPort:  payload.ProposerPort,
-				ProposalBlock: payload.ProposalBlock,
-				Port:          utils.StakingNodePort,
-				Result:        result,
-				Signature:     blockchain.BlockSign(payload.ProposalBlock, utils.StakingNodePort),
-			}
-			proposalResult.ProposalBlock.Signature = validateSignature
-			SendProposalResult(proposalResult)
-			validatedResults = []*blockchain.ValidatedInfo{} // 그 뒤 다음블록의 새로운 검증자들의 결과를 받기위해서 초기화
-			// if proposalResult.Result == false {              // 악의적인 노드로 판명났다면, 해당 제안자의 스테이킹 자금을 소각
-			// 3000번 본인포트로부터, 소각 주소로 보내 트랜잭션 구성 및 멤풀에 추가
-			// 트랜잭션 구성시, 제안자 노드가 스테이킹했던 트랜잭션 UTXO를 이용해야함. (unstake 내용 확인)
-			// 스테이킹 인포부터 불러와야함
-			// }
+		e := consensus.Current()
+		if e == nil {
+			break
 		}
-
-	case MessageProposalResponse:
-		var payload *blockchain.ValidatedInfo
-		if err := json.Unmarshal(m.Payload, &payload); err != nil {
-			log.Error(err)
+		added, err := e.HandleVote(payload)
+		if err != nil && isInvalidConsensusMessage(err) {
+			p.penalize(err)
 		}
-		if payload.Port == utils.StakingNodePort && payload.Result {
-			blockchain.PersistBlock(payload.ProposalBlock)
-			blockchain.Blockchain().UpdateBlockchain(payload.ProposalBlock)
-			BroadcastNewBlock(payload.ProposalBlock)
+		if added {
+			relay(p, func(to *peer) { notifyVote(payload, to) })
 		}
 	}
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/abcfe-op/abcfe-node/blockchain"
 	"github.com/abcfe-op/abcfe-node/common/utils"
+	"github.com/abcfe-op/abcfe-node/consensus"
 	"github.com/gorilla/websocket"
 
 	log "github.com/abcfe-op/abcfe-node/common/logger"
@@ -14,12 +15,6 @@ import (
 
 var upgrader = websocket.Upgrader{}
 
-type validateRequest struct {
-	RoleInfo *blockchain.RoleInfo
-	Block    *blockchain.Block
-	Port     string
-}
-
 // Upgrade: 프로토콜간의 전환 (HTTP에서 WebSocket 통신으로 전환)
 func Upgrade(rw http.ResponseWriter, r *http.Request) {
 	openPort := r.URL.Query().Get("openPort")           // 링크의 쿼리문을 추출
@@ -32,7 +27,7 @@ func Upgrade(rw http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		log.Error(err)
 	}
-	initPeer(conn, ip, openPort)
+	sendNewestBlock(initPeer(conn, ip, openPort)) // 연결한 양쪽이 서로의 높이를 알도록 최신 블록 교환
 }
 
 // peer 추가
@@ -45,87 +40,57 @@ func AddPeer(address, port, openPort string, broadcast bool) { // 서로간에 c
 	p := initPeer(conn, address, port)
 	if broadcast {
 		BroadcastNewPeer(p) // 새로운 peer가 생겼다고 기존 peers에게 브로드캐스팅
-		return
 	}
 	sendNewestBlock(p)
 }
 
-// 새로 선출된 제안자 지목
-func PointingProposer(r *blockchain.RoleInfo) {
-	for _, p := range Peers.v {
-		if r.ProposerPort == p.port {
-			notifyNewProposer(r, p)
-		}
+// 연결된 peer가 있고, 모든 peer가 알려준 높이까지 체인을 따라잡았는가 (합의 엔진을 시작하기 전 동기화 확인)
+func Synced() bool {
+	list := Peers.snapshot(nil)
+	if len(list) == 0 {
+		return false
 	}
-}
-
-// 새로 선출된 검증자 지목
-func PointingValidator(r *blockchain.RoleInfo) {
-	for _, p := range Peers.v {
-		for _, port := range r.ValidatorPort {
-			if port == p.port {
-				notifyNewValidator(p)
-			}
+	_, tip := blockchain.Blockchain().Tip()
+	height := int64(tip)
+	for _, p := range list {
+		if h := p.height.Load(); h == 0 || h > height {
+			return false
 		}
 	}
+	return true
 }
 
-// 제안하고자 하는 블록을 검증자들에게 전달 후 검증 요청
-func SendProposalBlock(r *blockchain.RoleInfo, b *blockchain.Block) {
-	for _, p := range Peers.v {
-		for _, port := range r.ValidatorPort {
-			if port == p.port {
-				requestFormat := &validateRequest{
-					RoleInfo: r,
-					Block:    b,
-					Port:     p.port,
-				}
-				requestValidateBlock(requestFormat, p)
-			}
-		}
-	}
+// 합의 엔진의 제안을 peer들에게 전파 (검증자가 아닌 노드도 중계하며 투표를 모아 커밋할 수 있도록 모든 peer에게)
+func BroadcastProposal(proposal *consensus.Proposal) {
+	relay(nil, func(p *peer) { notifyProposal(proposal, p) })
 }
 
-// PoS 스테이킹 풀 제공자 노드에게 검증결과 전달
-func SendValidatedResult(validatedInfo *blockchain.ValidatedInfo) {
-	for _, p := range Peers.v {
-		if p.port == utils.StakingNodePort { // staking port
-			notifyValidatedResult(validatedInfo, p)
-		}
-	}
+// 검증자의 투표를 peer들에게 가십
+func BroadcastVote(v *consensus.Vote) {
+	relay(nil, func(p *peer) { notifyVote(v, p) })
 }
 
-// PoS 스테이킹 풀 제공자 노드가 제안자에게 제안 결과 전달
-func SendProposalResult(proposalResult *blockchain.ValidatedInfo) {
-	for _, p := range Peers.v {
-		if p.port == proposalResult.ProposerPort { // staking port
-			notifyProposalResult(proposalResult, p)
-		}
+// 메세지를 보낸 peer를 제외한 모든 peer에게 전달 (except가 nil이면 모두에게)
+func relay(except *peer, send func(p *peer)) {
+	for _, p := range Peers.snapshot(except) {
+		send(p)
 	}
 }
 
 // 제안자가 모든 검증결과를 마치고, 새로운 블록을 추가했을때 peer들에게 새로만든 블록을 전파
 func BroadcastNewBlock(b *blockchain.Block) {
-	Peers.m.Lock()
-	defer Peers.m.Unlock()
-	for _, p := range Peers.v {
-		notifyNewBlock(b, p)
-	}
+	relay(nil, func(p *peer) { notifyNewBlock(b, p) })
 }
 
 // 새로 만든 트랜잭션을 peer들에게 전파
 func BroadcastNewTx(tx *blockchain.Tx) {
-	for _, p := range Peers.v {
-		notifyNewTx(tx, p)
-	}
+	relay(nil, func(p *peer) { notifyNewTx(tx, p) })
 }
 
 // 기존 peer들에게 새로 연결된 peer의 정보를 전달
 func BroadcastNewPeer(newPeer *peer) {
-	for key, p := range Peers.v {
-		if key != newPeer.key {
-			payload := fmt.Sprintf("%s:%s", newPeer.key, p.port)
-			notifyNewPeer(payload, p)
-		}
-	}
+	relay(newPeer, func(p *peer) {
+		payload := fmt.Sprintf("%s:%s", newPeer.key, p.port)
+		notifyNewPeer(payload, p)
+	})
 }
@@ -3,8 +3,17 @@ package p2p
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 
 	"github.com/gorilla/websocket"
+
+	"github.com/abcfe-op/abcfe-node/blockchain"
+	log "github.com/abcfe-op/abcfe-node/common/logger"
+)
+
+const (
+	maxMisbehavior = 3   // 유효하지 않은 데이터를 이 횟수만큼 보낸 peer는 연결을 끊음
+	inboxSize      = 256 // peer마다 보내기를 기다리는 메세지의 최대 수
 )
 
 type peers struct {
@@ -24,7 +33,11 @@ type peer struct {
 	address string
 	port    string
 	conn    *websocket.Conn
-	inbox   chan []byte // 각각의 peer마다 bytes조각들을 보내는 inbox라는 채널을 줌. channel이므로 특정상황에 국한받지 않음
+	inbox   chan []byte  // 각각의 peer마다 bytes조각들을 보내는 inbox라는 채널을 줌. channel이므로 특정상황에 국한받지 않음
+	penalty int          // 유효하지 않은 데이터를 보낸 횟수
+	height  atomic.Int64 // peer가 알려준 체인 높이 (0이면 아직 모름)
+
+	syncBlocks []*blockchain.Block // 분기점을 찾는 동안 받은 블록 (최신 순, 메세지를 읽는 고루틴에서만 사용)
 }
 
 // 현재 연결된 peer들의 리스트 반환
@@ -38,6 +51,28 @@ func AllPeers(p *peers) []string {
 	return keys
 }
 
+// 현재 연결된 peer들의 복사본 (except는 제외). 메세지는 잠금을 풀고 보내야 느린 peer가 다른 peer의 연결과 해제를 막지 않음
+func (p *peers) snapshot(except *peer) []*peer {
+	p.m.Lock()
+	defer p.m.Unlock()
+	list := make([]*peer, 0, len(p.v))
+	for _, peer := range p.v {
+		if peer != except {
+			list = append(list, peer)
+		}
+	}
+	return list
+}
+
+// peer에게 보낼 메세지를 inbox에 넣음. inbox가 가득 찼다면 (peer가 메세지를 받지 못하고 있다면) 기다리지 않고 버림
+func (p *peer) send(m []byte) {
+	select {
+	case p.inbox <- m:
+	default:
+		log.Warn(fmt.Sprintf("peer %s inbox is full, dropping a message", p.key))
+	}
+}
+
 // 연결되어 있던 peer가 예기치 못한 오류로 종료될 시, 우리쪽의 peer 목록에서 삭제
 func (p *peer) close() {
 	Peers.m.Lock()
@@ -46,6 +81,17 @@ func (p *peer) close() {
 	delete(Peers.v, p.key) // golang map 내용 삭제방법
 }
 
+// 유효하지 않은 데이터를 보낸 peer를 기록하고, 누적 횟수가 한도를 넘으면 연결을 끊음
+func (p *peer) penalize(reason error) {
+	p.penalty++
+	fmt.Printf("Peer %s sent invalid data (%d/%d): %s\n", p.key, p.penalty, maxMisbehavior, reason)
+	log.Warn(fmt.Sprintf("peer %s misbehaved: %s", p.key, reason))
+	if p.penalty >= maxMisbehavior {
+		fmt.Printf("Disconnecting misbehaving peer %s\n", p.key)
+		p.close()
+	}
+}
+
 // peer에게서 온 메세지 후처리
 func (p *peer) read() {
 	defer p.close()
@@ -78,7 +124,7 @@ func initPeer(conn *websocket.Conn, address, port string) *peer {
 	key := fmt.Sprintf("%s:%s", address, port)
 	p := &peer{
 		conn:    conn,
-		inbox:   make(chan []byte),
+		inbox:   make(chan []byte, inboxSize),
 		address: address,
 		key:     key,
 		port:    port,
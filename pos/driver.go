@@ -0,0 +1,99 @@
+package pos
+
+import (
+	"errors"
+	"time"
+
+	"github.com/abcfe-op/abcfe-node/blockchain"
+	"github.com/abcfe-op/abcfe-node/common/utils"
+	"github.com/abcfe-op/abcfe-node/consensus"
+	"github.com/abcfe-op/abcfe-node/db"
+	"github.com/abcfe-op/abcfe-node/p2p"
+	"github.com/abcfe-op/abcfe-node/wallet"
+
+	log "github.com/abcfe-op/abcfe-node/common/logger"
+)
+
+// 합의 엔진이 체인, 지갑, p2p, 타이머에 접근하는 노드 측 구현
+type node struct {
+	port string
+}
+
+func (n *node) Height() int {
+	_, height := blockchain.Blockchain().Tip()
+	return height
+}
+
+func (n *node) Address() string {
+	return wallet.Wallet(n.port).Address
+}
+
+func (n *node) Roles(height int) (*blockchain.RoleInfo, error) {
+	roles, msg := blockchain.Blockchain().Selector(height)
+	if msg != "" {
+		return nil, errors.New(msg)
+	}
+	return roles, nil
+}
+
+func (n *node) Propose(height int, roles *blockchain.RoleInfo) *blockchain.Block {
+	hash, _ := blockchain.Blockchain().Tip()
+	return blockchain.CreateBlock(hash, height, n.port, roles)
+}
+
+// 제안 블록을 현재 체인의 최신 상태로 검증 (헤더, 라운드의 역할 정보, 선출 무작위 값, 트랜잭션)
+func (n *node) Validate(block *blockchain.Block, round int) error {
+	return blockchain.Blockchain().ValidateProposal(block, round)
+}
+
+func (n *node) Sign(payload string) *blockchain.ValidateSignature {
+	w := wallet.Wallet(n.port)
+	return &blockchain.ValidateSignature{
+		Port:      n.port,
+		Address:   w.Address,
+		Signature: wallet.Sign(payload, w),
+	}
+}
+
+// 서명 위치는 노드의 DB에 기록 (기록을 읽을 수 없다면 처음부터 서명)
+func (n *node) LastSigned() consensus.SignState {
+	var s consensus.SignState
+	if data := (db.DB{}).LoadSignState(); data != nil {
+		if err := utils.FromBytes(&s, data); err != nil {
+			log.Error(err)
+		}
+	}
+	return s
+}
+
+func (n *node) SaveSigned(s consensus.SignState) error {
+	data, err := utils.ToBytes(s)
+	if err != nil {
+		return err
+	}
+	batch := db.NewBatch()
+	batch.SaveSignState(data)
+	return db.DB{}.Write(batch)
+}
+
+func (n *node) BroadcastProposal(p *consensus.Proposal) {
+	p2p.BroadcastProposal(p)
+}
+
+func (n *node) BroadcastVote(v *consensus.Vote) {
+	p2p.BroadcastVote(v)
+}
+
+func (n *node) Schedule(t consensus.Timeout, d time.Duration) {
+	time.AfterFunc(d, func() {
+		consensus.Current().HandleTimeout(t)
+	})
+}
+
+func (n *node) Commit(block *blockchain.Block) error {
+	if err := blockchain.Blockchain().ImportBlock(block); err != nil {
+		return err
+	}
+	p2p.BroadcastNewBlock(block)
+	return nil
+}
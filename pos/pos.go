@@ -2,31 +2,40 @@
 package pos
 
 import (
+	"fmt"
+	"strconv"
 	"time"
 
-	"github.com/abcfe-op/abcfe-node/blockchain"
+	"github.com/abcfe-op/abcfe-node/consensus"
 	"github.com/abcfe-op/abcfe-node/p2p"
 	"github.com/abcfe-op/abcfe-node/rest"
+
+	log "github.com/abcfe-op/abcfe-node/common/logger"
 )
 
 const (
-	nodeSettingTime = 20 // 10개 노드 동시 실행시 세팅에 걸리는 시간으로, 간섭이 없도록 Lock
-	slotTime        = 12 // 블록 하나가 추가되는 이상적인 시간 (이더리움 기준)
+	syncPollInterval = time.Second      // 동기화 여부를 확인하는 간격
+	maxSyncWait      = 60 * time.Second // peer와 동기화하지 못해도 이 시간이 지나면 합의 시작 (peer 없이 홀로 시작한 노드)
 )
 
-// PoS의 기둥이 되는 함수. 슬롯과 에포크마다 스테이킹 리스트를 토대로 검증자와 제안자를 선출 후, 제안 성공 여부를 따지는 로직을 반복한다.
+// PoS의 기둥이 되는 함수. peer들과 체인을 동기화하면 BFT 합의 엔진을 시작한다.
+// 엔진은 높이마다 스테이킹 리스트를 토대로 선출된 제안자와 검증자로 라운드를 진행하며, 타임아웃이 나면 다음 라운드로 넘어간다.
 func PoS(aPort int) {
 	go rest.Start(aPort)
-	time.Sleep(nodeSettingTime * time.Second)
-	for {
-		lastHeight := blockchain.Blockchain().Height
-		roleInfo, err := blockchain.Blockchain().Selector()
-		if err != "" {
-			continue
+	waitForSync()
+	consensus.Start(&node{port: strconv.Itoa(aPort)}, consensus.DefaultTimeouts)
+	select {}
+}
+
+// 연결된 peer들이 알려준 높이까지 체인을 따라잡을 때까지 대기 (뒤처진 체인으로 합의에 참여하지 않도록)
+// 엔진은 시작한 뒤에도 가십과 동기화로 체인이 앞서 나가면 새 높이로 이동하므로, 최대 대기 시간이 지나면 동기화 없이 시작한다
+func waitForSync() {
+	deadline := time.Now().Add(maxSyncWait)
+	for !p2p.Synced() {
+		if time.Now().After(deadline) {
+			log.Warn(fmt.Sprintf("not synced with peers after %s, starting consensus anyway", maxSyncWait))
+			return
 		}
-		p2p.PointingProposer(roleInfo)
-		p2p.PointingValidator(roleInfo)
-		time.Sleep(slotTime * time.Second)
-		blockchain.Blockchain().CheckProposalSuccess(lastHeight)
+		time.Sleep(syncPollInterval)
 	}
 }
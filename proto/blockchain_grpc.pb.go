@@ -4,6 +4,10 @@
 // - protoc             v5.29.3
 // source: proto/blockchain.proto
 
+// 리비전 2: 금액은 uint64(기본 단위), 블록 높이와 타임스탬프는 int64
+// 부호가 있는 타입에서 uint64로 바뀐 금액 필드는 새 번호를 쓰고 이전 번호는 reserved로 막아, 이전 리비전의 값이 다른 의미로 읽히지 않게 함
+// (int32에서 int64로 넓힌 필드는 wire 형식이 같으므로 번호를 유지)
+
 package proto
 
 import (
@@ -21,24 +25,34 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	BlockchainService_GetBlocks_FullMethodName         = "/proto.BlockchainService/GetBlocks"
 	BlockchainService_GetBlock_FullMethodName          = "/proto.BlockchainService/GetBlock"
+	BlockchainService_GetBlockByHeight_FullMethodName  = "/proto.BlockchainService/GetBlockByHeight"
 	BlockchainService_GetStatus_FullMethodName         = "/proto.BlockchainService/GetStatus"
 	BlockchainService_GetBalance_FullMethodName        = "/proto.BlockchainService/GetBalance"
 	BlockchainService_GetMempool_FullMethodName        = "/proto.BlockchainService/GetMempool"
 	BlockchainService_CreateTransaction_FullMethodName = "/proto.BlockchainService/CreateTransaction"
+	BlockchainService_SubmitTransaction_FullMethodName = "/proto.BlockchainService/SubmitTransaction"
 	BlockchainService_GetWallet_FullMethodName         = "/proto.BlockchainService/GetWallet"
 	BlockchainService_Stake_FullMethodName             = "/proto.BlockchainService/Stake"
 	BlockchainService_Unstake_FullMethodName           = "/proto.BlockchainService/Unstake"
 	BlockchainService_GetStakingList_FullMethodName    = "/proto.BlockchainService/GetStakingList"
+	BlockchainService_GetMerkleProof_FullMethodName    = "/proto.BlockchainService/GetMerkleProof"
+	BlockchainService_GetTransaction_FullMethodName    = "/proto.BlockchainService/GetTransaction"
+	BlockchainService_GetAddressHistory_FullMethodName = "/proto.BlockchainService/GetAddressHistory"
+	BlockchainService_GetAsset_FullMethodName          = "/proto.BlockchainService/GetAsset"
+	BlockchainService_ListAssets_FullMethodName        = "/proto.BlockchainService/ListAssets"
+	BlockchainService_IssueAsset_FullMethodName        = "/proto.BlockchainService/IssueAsset"
 )
 
 // BlockchainServiceClient is the client API for BlockchainService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type BlockchainServiceClient interface {
-	// 블록 전체 조회
-	GetBlocks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BlocksResponse, error)
+	// 블록 범위 조회 (최신 순)
+	GetBlocks(ctx context.Context, in *BlocksRequest, opts ...grpc.CallOption) (*BlocksResponse, error)
 	// 특정 블록 조회
 	GetBlock(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*BlockResponse, error)
+	// 특정 높이의 블록 조회
+	GetBlockByHeight(ctx context.Context, in *BlockHeightRequest, opts ...grpc.CallOption) (*BlockResponse, error)
 	// 블록체인 상태 조회
 	GetStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StatusResponse, error)
 	// 잔액 조회
@@ -47,12 +61,25 @@ type BlockchainServiceClient interface {
 	GetMempool(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MempoolResponse, error)
 	// 트랜잭션 생성
 	CreateTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
+	// 클라이언트가 서명한 트랜잭션 제출
+	SubmitTransaction(ctx context.Context, in *SubmitTransactionRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
 	// 지갑 정보 조회
 	GetWallet(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*WalletResponse, error)
 	// 스테이킹 관련 기능들
 	Stake(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StakeResponse, error)
 	Unstake(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*UnstakeResponse, error)
 	GetStakingList(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StakingListResponse, error)
+	// 트랜잭션 머클 포함 증명 조회
+	GetMerkleProof(ctx context.Context, in *MerkleProofRequest, opts ...grpc.CallOption) (*MerkleProofResponse, error)
+	// 트랜잭션 색인으로 확정된 트랜잭션 조회
+	GetTransaction(ctx context.Context, in *TxRequest, opts ...grpc.CallOption) (*TxResponse, error)
+	// 주소 색인으로 주소별 잔액 변화 기록 조회
+	GetAddressHistory(ctx context.Context, in *AddressHistoryRequest, opts ...grpc.CallOption) (*AddressHistoryResponse, error)
+	// 자산 정보 조회
+	GetAsset(ctx context.Context, in *AssetRequest, opts ...grpc.CallOption) (*Asset, error)
+	ListAssets(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AssetListResponse, error)
+	// 노드의 지갑을 발행자로 자산 발행
+	IssueAsset(ctx context.Context, in *IssueAssetRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
 }
 
 type blockchainServiceClient struct {
@@ -63,7 +90,7 @@ func NewBlockchainServiceClient(cc grpc.ClientConnInterface) BlockchainServiceCl
 	return &blockchainServiceClient{cc}
 }
 
-func (c *blockchainServiceClient) GetBlocks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BlocksResponse, error) {
+func (c *blockchainServiceClient) GetBlocks(ctx context.Context, in *BlocksRequest, opts ...grpc.CallOption) (*BlocksResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(BlocksResponse)
 	err := c.cc.Invoke(ctx, BlockchainService_GetBlocks_FullMethodName, in, out, cOpts...)
@@ -83,6 +110,16 @@ func (c *blockchainServiceClient) GetBlock(ctx context.Context, in *BlockRequest
 	return out, nil
 }
 
+func (c *blockchainServiceClient) GetBlockByHeight(ctx context.Context, in *BlockHeightRequest, opts ...grpc.CallOption) (*BlockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BlockResponse)
+	err := c.cc.Invoke(ctx, BlockchainService_GetBlockByHeight_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *blockchainServiceClient) GetStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StatusResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(StatusResponse)
@@ -123,6 +160,16 @@ func (c *blockchainServiceClient) CreateTransaction(ctx context.Context, in *Tra
 	return out, nil
 }
 
+func (c *blockchainServiceClient) SubmitTransaction(ctx context.Context, in *SubmitTransactionRequest, opts ...grpc.CallOption) (*TransactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionResponse)
+	err := c.cc.Invoke(ctx, BlockchainService_SubmitTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *blockchainServiceClient) GetWallet(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*WalletResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(WalletResponse)
@@ -163,14 +210,76 @@ func (c *blockchainServiceClient) GetStakingList(ctx context.Context, in *Empty,
 	return out, nil
 }
 
+func (c *blockchainServiceClient) GetMerkleProof(ctx context.Context, in *MerkleProofRequest, opts ...grpc.CallOption) (*MerkleProofResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MerkleProofResponse)
+	err := c.cc.Invoke(ctx, BlockchainService_GetMerkleProof_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockchainServiceClient) GetTransaction(ctx context.Context, in *TxRequest, opts ...grpc.CallOption) (*TxResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TxResponse)
+	err := c.cc.Invoke(ctx, BlockchainService_GetTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockchainServiceClient) GetAddressHistory(ctx context.Context, in *AddressHistoryRequest, opts ...grpc.CallOption) (*AddressHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddressHistoryResponse)
+	err := c.cc.Invoke(ctx, BlockchainService_GetAddressHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockchainServiceClient) GetAsset(ctx context.Context, in *AssetRequest, opts ...grpc.CallOption) (*Asset, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Asset)
+	err := c.cc.Invoke(ctx, BlockchainService_GetAsset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockchainServiceClient) ListAssets(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AssetListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AssetListResponse)
+	err := c.cc.Invoke(ctx, BlockchainService_ListAssets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockchainServiceClient) IssueAsset(ctx context.Context, in *IssueAssetRequest, opts ...grpc.CallOption) (*TransactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionResponse)
+	err := c.cc.Invoke(ctx, BlockchainService_IssueAsset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BlockchainServiceServer is the server API for BlockchainService service.
 // All implementations must embed UnimplementedBlockchainServiceServer
 // for forward compatibility.
 type BlockchainServiceServer interface {
-	// 블록 전체 조회
-	GetBlocks(context.Context, *Empty) (*BlocksResponse, error)
+	// 블록 범위 조회 (최신 순)
+	GetBlocks(context.Context, *BlocksRequest) (*BlocksResponse, error)
 	// 특정 블록 조회
 	GetBlock(context.Context, *BlockRequest) (*BlockResponse, error)
+	// 특정 높이의 블록 조회
+	GetBlockByHeight(context.Context, *BlockHeightRequest) (*BlockResponse, error)
 	// 블록체인 상태 조회
 	GetStatus(context.Context, *Empty) (*StatusResponse, error)
 	// 잔액 조회
@@ -179,12 +288,25 @@ type BlockchainServiceServer interface {
 	GetMempool(context.Context, *Empty) (*MempoolResponse, error)
 	// 트랜잭션 생성
 	CreateTransaction(context.Context, *TransactionRequest) (*TransactionResponse, error)
+	// 클라이언트가 서명한 트랜잭션 제출
+	SubmitTransaction(context.Context, *SubmitTransactionRequest) (*TransactionResponse, error)
 	// 지갑 정보 조회
 	GetWallet(context.Context, *Empty) (*WalletResponse, error)
 	// 스테이킹 관련 기능들
 	Stake(context.Context, *Empty) (*StakeResponse, error)
 	Unstake(context.Context, *Empty) (*UnstakeResponse, error)
 	GetStakingList(context.Context, *Empty) (*StakingListResponse, error)
+	// 트랜잭션 머클 포함 증명 조회
+	GetMerkleProof(context.Context, *MerkleProofRequest) (*MerkleProofResponse, error)
+	// 트랜잭션 색인으로 확정된 트랜잭션 조회
+	GetTransaction(context.Context, *TxRequest) (*TxResponse, error)
+	// 주소 색인으로 주소별 잔액 변화 기록 조회
+	GetAddressHistory(context.Context, *AddressHistoryRequest) (*AddressHistoryResponse, error)
+	// 자산 정보 조회
+	GetAsset(context.Context, *AssetRequest) (*Asset, error)
+	ListAssets(context.Context, *Empty) (*AssetListResponse, error)
+	// 노드의 지갑을 발행자로 자산 발행
+	IssueAsset(context.Context, *IssueAssetRequest) (*TransactionResponse, error)
 	mustEmbedUnimplementedBlockchainServiceServer()
 }
 
@@ -195,12 +317,15 @@ type BlockchainServiceServer interface {
 // pointer dereference when methods are called.
 type UnimplementedBlockchainServiceServer struct{}
 
-func (UnimplementedBlockchainServiceServer) GetBlocks(context.Context, *Empty) (*BlocksResponse, error) {
+func (UnimplementedBlockchainServiceServer) GetBlocks(context.Context, *BlocksRequest) (*BlocksResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlocks not implemented")
 }
 func (UnimplementedBlockchainServiceServer) GetBlock(context.Context, *BlockRequest) (*BlockResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlock not implemented")
 }
+func (UnimplementedBlockchainServiceServer) GetBlockByHeight(context.Context, *BlockHeightRequest) (*BlockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockByHeight not implemented")
+}
 func (UnimplementedBlockchainServiceServer) GetStatus(context.Context, *Empty) (*StatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
 }
@@ -213,6 +338,9 @@ func (UnimplementedBlockchainServiceServer) GetMempool(context.Context, *Empty)
 func (UnimplementedBlockchainServiceServer) CreateTransaction(context.Context, *TransactionRequest) (*TransactionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateTransaction not implemented")
 }
+func (UnimplementedBlockchainServiceServer) SubmitTransaction(context.Context, *SubmitTransactionRequest) (*TransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitTransaction not implemented")
+}
 func (UnimplementedBlockchainServiceServer) GetWallet(context.Context, *Empty) (*WalletResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetWallet not implemented")
 }
@@ -225,6 +353,24 @@ func (UnimplementedBlockchainServiceServer) Unstake(context.Context, *Empty) (*U
 func (UnimplementedBlockchainServiceServer) GetStakingList(context.Context, *Empty) (*StakingListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStakingList not implemented")
 }
+func (UnimplementedBlockchainServiceServer) GetMerkleProof(context.Context, *MerkleProofRequest) (*MerkleProofResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMerkleProof not implemented")
+}
+func (UnimplementedBlockchainServiceServer) GetTransaction(context.Context, *TxRequest) (*TxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransaction not implemented")
+}
+func (UnimplementedBlockchainServiceServer) GetAddressHistory(context.Context, *AddressHistoryRequest) (*AddressHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAddressHistory not implemented")
+}
+func (UnimplementedBlockchainServiceServer) GetAsset(context.Context, *AssetRequest) (*Asset, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAsset not implemented")
+}
+func (UnimplementedBlockchainServiceServer) ListAssets(context.Context, *Empty) (*AssetListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAssets not implemented")
+}
+func (UnimplementedBlockchainServiceServer) IssueAsset(context.Context, *IssueAssetRequest) (*TransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IssueAsset not implemented")
+}
 func (UnimplementedBlockchainServiceServer) mustEmbedUnimplementedBlockchainServiceServer() {}
 func (UnimplementedBlockchainServiceServer) testEmbeddedByValue()                           {}
 
@@ -247,7 +393,7 @@ func RegisterBlockchainServiceServer(s grpc.ServiceRegistrar, srv BlockchainServ
 }
 
 func _BlockchainService_GetBlocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+	in := new(BlocksRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -259,7 +405,7 @@ func _BlockchainService_GetBlocks_Handler(srv interface{}, ctx context.Context,
 		FullMethod: BlockchainService_GetBlocks_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BlockchainServiceServer).GetBlocks(ctx, req.(*Empty))
+		return srv.(BlockchainServiceServer).GetBlocks(ctx, req.(*BlocksRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -282,6 +428,24 @@ func _BlockchainService_GetBlock_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlockchainService_GetBlockByHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockHeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainServiceServer).GetBlockByHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockchainService_GetBlockByHeight_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainServiceServer).GetBlockByHeight(ctx, req.(*BlockHeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BlockchainService_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
@@ -354,6 +518,24 @@ func _BlockchainService_CreateTransaction_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlockchainService_SubmitTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainServiceServer).SubmitTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockchainService_SubmitTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainServiceServer).SubmitTransaction(ctx, req.(*SubmitTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BlockchainService_GetWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
@@ -426,6 +608,114 @@ func _BlockchainService_GetStakingList_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlockchainService_GetMerkleProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MerkleProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainServiceServer).GetMerkleProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockchainService_GetMerkleProof_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainServiceServer).GetMerkleProof(ctx, req.(*MerkleProofRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockchainService_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainServiceServer).GetTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockchainService_GetTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainServiceServer).GetTransaction(ctx, req.(*TxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockchainService_GetAddressHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainServiceServer).GetAddressHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockchainService_GetAddressHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainServiceServer).GetAddressHistory(ctx, req.(*AddressHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockchainService_GetAsset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainServiceServer).GetAsset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockchainService_GetAsset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainServiceServer).GetAsset(ctx, req.(*AssetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockchainService_ListAssets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainServiceServer).ListAssets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockchainService_ListAssets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainServiceServer).ListAssets(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockchainService_IssueAsset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssueAssetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainServiceServer).IssueAsset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockchainService_IssueAsset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainServiceServer).IssueAsset(ctx, req.(*IssueAssetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BlockchainService_ServiceDesc is the grpc.ServiceDesc for BlockchainService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -441,6 +731,10 @@ var BlockchainService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetBlock",
 			Handler:    _BlockchainService_GetBlock_Handler,
 		},
+		{
+			MethodName: "GetBlockByHeight",
+			Handler:    _BlockchainService_GetBlockByHeight_Handler,
+		},
 		{
 			MethodName: "GetStatus",
 			Handler:    _BlockchainService_GetStatus_Handler,
@@ -457,6 +751,10 @@ var BlockchainService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateTransaction",
 			Handler:    _BlockchainService_CreateTransaction_Handler,
 		},
+		{
+			MethodName: "SubmitTransaction",
+			Handler:    _BlockchainService_SubmitTransaction_Handler,
+		},
 		{
 			MethodName: "GetWallet",
 			Handler:    _BlockchainService_GetWallet_Handler,
@@ -473,6 +771,30 @@ var BlockchainService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetStakingList",
 			Handler:    _BlockchainService_GetStakingList_Handler,
 		},
+		{
+			MethodName: "GetMerkleProof",
+			Handler:    _BlockchainService_GetMerkleProof_Handler,
+		},
+		{
+			MethodName: "GetTransaction",
+			Handler:    _BlockchainService_GetTransaction_Handler,
+		},
+		{
+			MethodName: "GetAddressHistory",
+			Handler:    _BlockchainService_GetAddressHistory_Handler,
+		},
+		{
+			MethodName: "GetAsset",
+			Handler:    _BlockchainService_GetAsset_Handler,
+		},
+		{
+			MethodName: "ListAssets",
+			Handler:    _BlockchainService_ListAssets_Handler,
+		},
+		{
+			MethodName: "IssueAsset",
+			Handler:    _BlockchainService_IssueAsset_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/blockchain.proto",
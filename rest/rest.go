@@ -3,9 +3,12 @@ package rest
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"math/rand"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 
@@ -24,9 +27,6 @@ var (
 	ResNotStaked = map[string]string{
 		"message": "Not staked.",
 	}
-	ResTimeRemained = map[string]string{
-		"message": "Staking Time is remained.",
-	}
 	ResUnstakeDone = map[string]string{
 		"message": "Unstaking Transaction is added to mempool.",
 	}
@@ -47,8 +47,10 @@ type urlDescription struct {
 }
 
 type BalanceResponse struct {
-	Address string `json:"address"`
-	Balance int    `json:"balance"`
+	Address string                     `json:"address"`
+	Balance blockchain.Amount          `json:"balance"`          // 기본 코인 잔액 (기본 단위)
+	Display string                     `json:"display"`          // 표시 단위로 나타낸 기본 코인 잔액
+	Assets  []*blockchain.AssetBalance `json:"assets,omitempty"` // 그 외 자산별 잔액
 }
 
 type myWalletResponse struct {
@@ -60,10 +62,124 @@ type errorResponse struct {
 	ErrorMessage string `json:"errorMessage"`
 }
 
+// 멤풀이 트랜잭션을 거절했을 때의 응답 (거절 사유 코드 포함)
+type rejectResponse struct {
+	ErrorMessage string                `json:"errorMessage"`
+	Code         blockchain.RejectCode `json:"code,omitempty"`
+}
+
 type addTxPayload struct {
-	To        string `json:"to"`
-	Amount    int    `json:"amount"`
-	InputData string `json:"inputData"`
+	To        string                   `json:"to"`
+	Amount    blockchain.Amount        `json:"amount"`
+	Fee       blockchain.Amount        `json:"fee"` // 생략하면 최소 수수료
+	InputData string                   `json:"inputData"`
+	Multisig  *blockchain.MultisigLock `json:"multisig,omitempty"`  // 있다면 to 대신 다중서명 주소로 보냄
+	Lock      *blockchain.TimeLock     `json:"lock,omitempty"`      // 받는 출력에 거는 시간 잠금
+	LockTime  int                      `json:"lockTime,omitempty"`  // 트랜잭션 LockTime (블록 높이 또는 유닉스 시간)
+	Script    string                   `json:"script,omitempty"`    // 있다면 to 대신 잠금 스크립트의 주소로 보냄
+	Asset     string                   `json:"asset,omitempty"`     // 보낼 자산 ID (생략하면 기본 코인)
+	Outputs   []*txOutPayload          `json:"outputs,omitempty"`   // 있다면 to, amount 등 대신 여러 받는 쪽에게 한 번에 보냄
+	Selection blockchain.CoinSelection `json:"selection,omitempty"` // 입력 선택 전략 (largest-first, bnb, random. 생략하면 체인 순서)
+}
+
+// 한 트랜잭션으로 여러 곳에 보낼 때의 받는 출력 하나
+type txOutPayload struct {
+	To       string                   `json:"to"`
+	Amount   blockchain.Amount        `json:"amount"`
+	Multisig *blockchain.MultisigLock `json:"multisig,omitempty"` // 있다면 to 대신 다중서명 주소로 보냄
+	Lock     *blockchain.TimeLock     `json:"lock,omitempty"`
+	Script   string                   `json:"script,omitempty"` // 있다면 to 대신 잠금 스크립트의 주소로 보냄
+	Asset    string                   `json:"asset,omitempty"`
+}
+
+// 받는 출력 구성 (다중서명 잠금 조건이나 잠금 스크립트가 있다면 그것으로 만든 주소로 보냄)
+func (p *txOutPayload) txOut() (*blockchain.TxOut, error) {
+	txOut := &blockchain.TxOut{Address: p.To, Amount: p.Amount, Lock: p.Lock, Asset: p.Asset}
+	if p.Multisig != nil {
+		address, err := p.Multisig.CheckedAddress()
+		if err != nil {
+			return nil, err
+		}
+		txOut.Address, txOut.Multisig = address, p.Multisig
+	}
+	if p.Script != "" {
+		txOut.Address, txOut.Script = blockchain.ScriptAddress(p.Script), p.Script
+	}
+	return txOut, nil
+}
+
+// 자산 발행 요청 (발행자는 노드의 지갑)
+type issueAssetPayload struct {
+	Name     string            `json:"name"`
+	Decimals int               `json:"decimals"`
+	Amount   blockchain.Amount `json:"amount"`
+	To       string            `json:"to,omitempty"` // 생략하면 발행자에게 지급
+	Fee      blockchain.Amount `json:"fee"`          // 생략하면 최소 수수료
+}
+
+// UTXO 통합 요청 (0인 값은 기본값)
+type consolidatePayload struct {
+	MaxAmount blockchain.Amount `json:"maxAmount"` // 이 금액 이하의 UTXO만 모음 (생략하면 금액 제한 없음)
+	MaxInputs int               `json:"maxInputs"` // 모을 최대 UTXO 수
+	Fee       blockchain.Amount `json:"fee"`       // 생략하면 최소 수수료
+}
+
+// HTLC 출력 생성 요청 (보낸 쪽은 노드의 지갑)
+type htlcPayload struct {
+	Recipient string            `json:"recipient"`
+	Hash      string            `json:"hash"`    // 비밀 값(32바이트)의 SHA-256 해시
+	Timeout   int               `json:"timeout"` // 기한 (블록 높이 또는 유닉스 시간)
+	Amount    blockchain.Amount `json:"amount"`
+	Fee       blockchain.Amount `json:"fee"` // 생략하면 최소 수수료
+}
+
+// HTLC 출력을 가져가거나(preimage 필요) 돌려받는 요청
+type htlcSpendPayload struct {
+	TxID     string            `json:"txId"`
+	Index    int               `json:"index"`
+	Preimage string            `json:"preimage,omitempty"` // 32바이트의 16진수
+	Fee      blockchain.Amount `json:"fee"`                // 생략하면 최소 수수료
+}
+
+type htlcResponse struct {
+	Tx    *blockchain.Tx `json:"tx"`
+	Index int            `json:"index"` // HTLC 출력의 인덱스
+	*blockchain.HTLC
+	Address string `json:"address"`
+}
+
+type scriptResponse struct {
+	Script  string `json:"script"`
+	Address string `json:"address"`
+}
+
+// 스크립트 실행 추적 요청: 트랜잭션의 입력(tx, input) 또는 임의의 스크립트(script, witness, payload)
+type scriptTracePayload struct {
+	Tx      *blockchain.Tx `json:"tx,omitempty"`
+	Input   int            `json:"input"`
+	Script  string         `json:"script,omitempty"`
+	Witness []string       `json:"witness,omitempty"`
+	Payload string         `json:"payload,omitempty"` // 서명 대상 (OP_CHECKSIG가 검증할 트랜잭션 해시 값)
+}
+
+// 다중서명 주소에서 보내는 서명 전 트랜잭션 구성 요청
+type multisigSpendPayload struct {
+	From      string            `json:"from"`
+	To        string            `json:"to"`
+	Amount    blockchain.Amount `json:"amount"`
+	Asset     string            `json:"asset"` // 생략하면 기본 코인
+	Fee       blockchain.Amount `json:"fee"`   // 생략하면 최소 수수료
+	InputData string            `json:"inputData"`
+}
+
+type multisigAddressResponse struct {
+	Address string                   `json:"address"`
+	Lock    *blockchain.MultisigLock `json:"lock"`
+}
+
+type multisigSignResponse struct {
+	Signed int            `json:"signed"` // 이번에 추가한 서명 수
+	Tx     *blockchain.Tx `json:"tx"`
 }
 
 type checkTx struct {
@@ -72,6 +188,11 @@ type checkTx struct {
 	Tx          *blockchain.Tx `json:"tx"`
 }
 
+type txResponse struct {
+	Transaction *blockchain.Tx `json:"transaction"`
+	*blockchain.TxLocation
+}
+
 type addPeerPayload struct {
 	Address, Port string
 }
@@ -87,7 +208,110 @@ func documentation(rw http.ResponseWriter, r *http.Request) {
 		{
 			URL:         url("/blocks"),
 			Method:      "GET",
-			Description: "See All Block",
+			Description: "See Blocks, newest first (pass nextCursor as 'to' for the next page)",
+			Payload:     "query: from, to, limit",
+		},
+		{
+			URL:         url("/blocks/height/{n}"),
+			Method:      "GET",
+			Description: "See the Block at a Height",
+		},
+		{
+			URL:         url("/blocks/{hash}/proof/{txid}"),
+			Method:      "GET",
+			Description: "See the Merkle Inclusion Proof of a Transaction",
+		},
+		{
+			URL:         url("/transaction"),
+			Method:      "POST",
+			Description: "Send from My Wallet to a Recipient, or to Several Recipients in One Transaction with outputs",
+			Payload:     "data:{to, amount, fee, inputData} or data:{outputs: [{to, amount}], fee, inputData}",
+		},
+		{
+			URL:         url("/transactions/raw"),
+			Method:      "POST",
+			Description: "Submit a Transaction Signed by the Client (ID and input signatures must be filled in)",
+			Payload:     "data:tx",
+		},
+		{
+			URL:         url("/multisig/address"),
+			Method:      "POST",
+			Description: "Get the Address of an M-of-N Multisig Lock",
+			Payload:     "data:{m, pubKeys}",
+		},
+		{
+			URL:         url("/multisig/spend"),
+			Method:      "POST",
+			Description: "Build an Unsigned Transaction Spending from a Multisig Address",
+			Payload:     "data:{from, to, amount, fee, inputData}",
+		},
+		{
+			URL:         url("/multisig/sign"),
+			Method:      "POST",
+			Description: "Add My Wallet's Signatures to the Multisig Inputs of a Transaction",
+			Payload:     "data:tx",
+		},
+		{
+			URL:         url("/assets"),
+			Method:      "GET",
+			Description: "See All Issued Assets",
+		},
+		{
+			URL:         url("/assets"),
+			Method:      "POST",
+			Description: "Issue an Asset with My Wallet as the Issuer (issuing again with the same name and decimals adds to the supply)",
+			Payload:     "data:{name, decimals, amount, to, fee}",
+		},
+		{
+			URL:         url("/assets/{id}"),
+			Method:      "GET",
+			Description: "See the Name, Decimals, Issuer and Supply of an Asset",
+		},
+		{
+			URL:         url("/htlc"),
+			Method:      "POST",
+			Description: "Lock Coins from My Wallet in a Hash Time-Locked Contract",
+			Payload:     "data:{recipient, hash, timeout, amount, fee}",
+		},
+		{
+			URL:         url("/htlc/claim"),
+			Method:      "POST",
+			Description: "Claim an HTLC Output to My Wallet by Revealing the Preimage",
+			Payload:     "data:{txId, index, preimage, fee}",
+		},
+		{
+			URL:         url("/htlc/refund"),
+			Method:      "POST",
+			Description: "Refund an HTLC Output to My Wallet after the Timeout",
+			Payload:     "data:{txId, index, fee}",
+		},
+		{
+			URL:         url("/htlc/{txid}/{index}"),
+			Method:      "GET",
+			Description: "See the State of an HTLC Output and the Revealed Preimage",
+		},
+		{
+			URL:         url("/script/template"),
+			Method:      "POST",
+			Description: "Build a Locking Script and Its Address from a Template (p2pk, p2pkh, multisig, timelock, hashlock)",
+			Payload:     "data:{type, pubKeys, m, lockTime, hash}",
+		},
+		{
+			URL:         url("/script/trace"),
+			Method:      "POST",
+			Description: "Run a Locking Script Step by Step and See the Stack and Cost (a transaction input, or a script with a witness)",
+			Payload:     "data:{tx, input} or {script, witness, payload}",
+		},
+		{
+			URL:         url("/transactions/{id}"),
+			Method:      "GET",
+			Description: "See a Confirmed Transaction and Its Location (requires the transaction index)",
+		},
+		{
+			URL:         url("/addresses/{address}/history"),
+			Method:      "GET",
+			Description: "See the Transaction History of an Address, newest first (requires the address index)",
+			Payload:     "query: cursor, limit",
 		},
 		{
 			URL:         url("/status"),
@@ -97,13 +321,24 @@ func documentation(rw http.ResponseWriter, r *http.Request) {
 		{
 			URL:         url("/balance"),
 			Method:      "GET",
-			Description: "See My Wallet's Balance",
+			Description: "See My Wallet's Balance of the Native Coin and Each Asset",
+		},
+		{
+			URL:         url("/balances/{address}"),
+			Method:      "GET",
+			Description: "See the UTXOs of an Address, or Its Balance of the Native Coin and Each Asset with ?total=true",
 		},
 		{
 			URL:         url("/wallet"),
 			Method:      "GET",
 			Description: "See My Wallet's Address",
 		},
+		{
+			URL:         url("/wallet/consolidate"),
+			Method:      "POST",
+			Description: "Sweep My Wallet's Small UTXOs into One Output (refused while the mempool is busy)",
+			Payload:     "data:{maxAmount, maxInputs, fee}",
+		},
 		{
 			URL:         url("/peer"),
 			Method:      "GET",
@@ -112,7 +347,18 @@ func documentation(rw http.ResponseWriter, r *http.Request) {
 		{
 			URL:         url("/staking"),
 			Method:      "GET",
-			Description: "See All Staking Member",
+			Description: "See the Active Validators in the On-chain Registry (?all=true includes exited and slashed records)",
+		},
+		{
+			URL:         url("/roles/{height}"),
+			Method:      "GET",
+			Description: "See the Proposer and Validators Elected for a Height (recomputed from the previous block and the stakers at that point)",
+		},
+		{
+			URL:         url("/slash"),
+			Method:      "POST",
+			Description: "Report a Double-Signing Validator and Add a Transaction Confiscating Its Stake to Mempool",
+			Payload:     "data:{offender, votes: [two {type, height, round, hash, signature} votes of the offender for different blocks in the same height, round and type]}",
 		},
 		{
 			URL:         url("/mempool"),
@@ -146,17 +392,42 @@ func loggerMiddleware(next http.Handler) http.Handler {
 	})
 }
 
-// (/blocks) GET: 전체 블록 조회, POST: 새로운 블록 추가
+// 음수가 아닌 정수 쿼리 값 (없다면 0)
+func intQuery(r *http.Request, name string) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid %s: %q", name, value)
+	}
+	return n, nil
+}
+
+// (/blocks) 블록 범위 조회 (from, to, limit 쿼리, 최신 순). 새로운 블록은 합의 엔진만 만든다
 func blocks(rw http.ResponseWriter, r *http.Request) {
-	switch r.Method {
-	case "GET":
-		if err := json.NewEncoder(rw).Encode(blockchain.Blocks(blockchain.Blockchain())); err != nil {
-			log.Error(err)
+	encoder := json.NewEncoder(rw)
+	var from, to, limit int
+	var err error
+	if from, err = intQuery(r, "from"); err == nil {
+		if to, err = intQuery(r, "to"); err == nil {
+			limit, err = intQuery(r, "limit")
 		}
-	case "POST":
-		newBlock := blockchain.Blockchain().AddBlock(port[1:], nil)
-		p2p.BroadcastNewBlock(newBlock)
-		rw.WriteHeader(http.StatusCreated)
+	}
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		encoder.Encode(errorResponse{err.Error()})
+		return
+	}
+	page, err := blockchain.BlocksByRange(blockchain.Blockchain(), from, to, limit)
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		encoder.Encode(errorResponse{err.Error()})
+		return
+	}
+	if err := encoder.Encode(page); err != nil {
+		log.Error(err)
 	}
 }
 
@@ -178,6 +449,103 @@ func block(rw http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// (/blocks/height/{n:[0-9]+}) 특정 높이의 블록 조회
+func blockByHeight(rw http.ResponseWriter, r *http.Request) {
+	height, _ := strconv.Atoi(mux.Vars(r)["n"])
+	block, err := blockchain.FindBlockByHeight(height)
+	encoder := json.NewEncoder(rw)
+	if err != nil {
+		rw.WriteHeader(http.StatusNotFound)
+		encoder.Encode(errorResponse{err.Error()})
+		return
+	}
+	if err := encoder.Encode(block); err != nil {
+		log.Error(err)
+	}
+}
+
+// (/roles/{height:[0-9]+}) 높이의 선출 결과 조회 (라운드 0 기준)
+func roles(rw http.ResponseWriter, r *http.Request) {
+	height, _ := strconv.Atoi(mux.Vars(r)["height"])
+	roleInfo, msg := blockchain.Blockchain().Selector(height)
+	encoder := json.NewEncoder(rw)
+	if msg != "" {
+		rw.WriteHeader(http.StatusBadRequest)
+		encoder.Encode(errorResponse{msg})
+		return
+	}
+	if err := encoder.Encode(roleInfo); err != nil {
+		log.Error(err)
+	}
+}
+
+// (/blocks/{hash:[a-f0-9]+}/proof/{txid:[a-f0-9]+}) 블록 내 특정 트랜잭션의 머클 포함 증명 조회
+func merkleProof(rw http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	block, err := blockchain.FindBlock(vars["hash"])
+	encoder := json.NewEncoder(rw)
+	if err != nil {
+		rw.WriteHeader(http.StatusNotFound)
+		encoder.Encode(errorResponse{err.Error()})
+		return
+	}
+	proof, err := block.MerkleProof(vars["txid"])
+	if err != nil {
+		rw.WriteHeader(http.StatusNotFound)
+		encoder.Encode(errorResponse{err.Error()})
+		return
+	}
+	if err := encoder.Encode(proof); err != nil {
+		log.Error(err)
+	}
+}
+
+// 색인 조회 에러에 맞는 HTTP 상태 코드
+func indexErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, blockchain.ErrTxIndexDisabled), errors.Is(err, blockchain.ErrAddressIndexDisabled):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, blockchain.ErrTxNotFound), errors.Is(err, blockchain.ErrNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// (/transactions/{id:[a-f0-9]+}) 트랜잭션 색인으로 확정된 트랜잭션과 위치 조회
+func transactionByID(rw http.ResponseWriter, r *http.Request) {
+	tx, location, err := blockchain.FindTransaction(mux.Vars(r)["id"])
+	encoder := json.NewEncoder(rw)
+	if err != nil {
+		rw.WriteHeader(indexErrorStatus(err))
+		encoder.Encode(errorResponse{err.Error()})
+		return
+	}
+	if err := encoder.Encode(txResponse{tx, location}); err != nil {
+		log.Error(err)
+	}
+}
+
+// (/addresses/{address}/history) 주소 색인으로 특정 주소의 잔액 변화 기록을 최신 순으로 조회 (cursor, limit 쿼리로 페이지 이동)
+func addressHistory(rw http.ResponseWriter, r *http.Request) {
+	encoder := json.NewEncoder(rw)
+	limit, err := intQuery(r, "limit")
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		encoder.Encode(errorResponse{err.Error()})
+		return
+	}
+	page, err := blockchain.AddressHistory(mux.Vars(r)["address"], r.URL.Query().Get("cursor"), limit)
+	if err != nil {
+		rw.WriteHeader(indexErrorStatus(err))
+		encoder.Encode(errorResponse{err.Error()})
+		return
+	}
+	if err := encoder.Encode(page); err != nil {
+		log.Error(err)
+	}
+}
+
 // (/status) 체인의 현 상태 확인
 func status(rw http.ResponseWriter, r *http.Request) {
 	blockchain.Status(blockchain.Blockchain(), rw)
@@ -190,8 +558,13 @@ func balance(rw http.ResponseWriter, r *http.Request) {
 	total := r.URL.Query().Get("total") // url에 total이 있는지 확인
 	switch total {
 	case "true":
-		amount := blockchain.BalanceByAddress(address, blockchain.Blockchain())
-		json.NewEncoder(rw).Encode(BalanceResponse{address, amount})
+		res, err := balanceResponse(address)
+		if err != nil {
+			rw.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(rw).Encode(errorResponse{err.Error()})
+			return
+		}
+		json.NewEncoder(rw).Encode(res)
 	default:
 		if err := json.NewEncoder(rw).Encode(blockchain.UTxOutsByAddress(address, blockchain.Blockchain())); err != nil {
 			log.Error(err)
@@ -201,13 +574,31 @@ func balance(rw http.ResponseWriter, r *http.Request) {
 
 // (/balance) 요청한 노드의 지갑 잔액을 확인
 func myBalance(rw http.ResponseWriter, r *http.Request) {
-	amount := blockchain.BalanceByAddress(wallet.Wallet(port[1:]).Address, blockchain.Blockchain())
-	json.NewEncoder(rw).Encode(BalanceResponse{wallet.Wallet(port[1:]).Address, amount})
+	res, err := balanceResponse(wallet.Wallet(port[1:]).Address)
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(rw).Encode(errorResponse{err.Error()})
+		return
+	}
+	json.NewEncoder(rw).Encode(res)
+}
+
+// 주소의 기본 코인과 자산별 잔액 조회
+func balanceResponse(address string) (*BalanceResponse, error) {
+	amount, err := blockchain.BalanceByAddress(address, blockchain.Blockchain())
+	if err != nil {
+		return nil, err
+	}
+	assets, err := blockchain.AssetBalancesByAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	return &BalanceResponse{address, amount, amount.Format(), assets}, nil
 }
 
 // (/mempool) 멤풀에 속해있는 트랜잭션을 확인
 func mempool(rw http.ResponseWriter, r *http.Request) {
-	if err := json.NewEncoder(rw).Encode(blockchain.Mempool().Txs); err != nil {
+	if err := json.NewEncoder(rw).Encode(blockchain.Mempool().Transactions()); err != nil {
 		log.Error(err)
 	}
 }
@@ -218,24 +609,275 @@ func transaction(rw http.ResponseWriter, r *http.Request) {
 	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
 		log.Error(err)
 	}
-	tx, err := blockchain.Mempool().AddTx(payload.To, payload.Amount, payload.InputData, port[1:])
+	if payload.Fee == 0 {
+		payload.Fee = blockchain.MinTxFee
+	}
+	single := &txOutPayload{payload.To, payload.Amount, payload.Multisig, payload.Lock, payload.Script, payload.Asset}
+	outputs := payload.Outputs
+	if len(outputs) == 0 {
+		outputs = []*txOutPayload{single}
+	} else if *single != (txOutPayload{}) {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(errorResponse{"outputs cannot be combined with a single recipient"})
+		return
+	}
+	txOuts := make([]*blockchain.TxOut, 0, len(outputs))
+	for _, o := range outputs {
+		if o == nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(rw).Encode(errorResponse{"empty output"})
+			return
+		}
+		txOut, err := o.txOut()
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(rw).Encode(errorResponse{err.Error()})
+			return
+		}
+		txOuts = append(txOuts, txOut)
+	}
+	tx, err := blockchain.Mempool().AddTxOuts(txOuts, payload.Fee, payload.Selection, payload.InputData, payload.LockTime, port[1:])
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(rejectResponse{err.Error(), blockchain.RejectReason(err)})
+		return
+	}
+	p2p.BroadcastNewTx(tx)
+	rw.WriteHeader(http.StatusCreated)
+}
+
+// (/assets) GET: 발행된 자산 전체 조회, POST: 노드의 지갑을 발행자로 자산을 발행하는 트랜잭션을 멤풀에 추가
+func assets(rw http.ResponseWriter, r *http.Request) {
+	encoder := json.NewEncoder(rw)
+	switch r.Method {
+	case "GET":
+		list, err := blockchain.Assets()
+		if err != nil {
+			rw.WriteHeader(http.StatusInternalServerError)
+			encoder.Encode(errorResponse{err.Error()})
+			return
+		}
+		encoder.Encode(list)
+	case "POST":
+		var payload issueAssetPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			encoder.Encode(errorResponse{err.Error()})
+			return
+		}
+		if payload.Fee == 0 {
+			payload.Fee = blockchain.MinTxFee
+		}
+		tx, err := blockchain.Mempool().IssueAsset(payload.Name, payload.Decimals, payload.Amount, payload.To, payload.Fee, port[1:])
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			encoder.Encode(rejectResponse{err.Error(), blockchain.RejectReason(err)})
+			return
+		}
+		p2p.BroadcastNewTx(tx)
+		rw.WriteHeader(http.StatusCreated)
+		encoder.Encode(tx)
+	}
+}
+
+// (/assets/{id}) 자산의 이름, 소수점 자릿수, 발행자, 총 발행량 조회
+func asset(rw http.ResponseWriter, r *http.Request) {
+	info, err := blockchain.FindAsset(mux.Vars(r)["id"])
 	if err != nil {
+		rw.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(rw).Encode(errorResponse{err.Error()})
+		return
+	}
+	json.NewEncoder(rw).Encode(info)
+}
+
+// (/htlc) 노드의 지갑에서 HTLC 출력으로 보내는 트랜잭션을 멤풀에 추가
+func createHTLC(rw http.ResponseWriter, r *http.Request) {
+	var payload htlcPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
 		rw.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(rw).Encode(errorResponse{err.Error()})
 		return
 	}
+	if payload.Fee == 0 {
+		payload.Fee = blockchain.MinTxFee
+	}
+	tx, htlc, err := blockchain.Mempool().AddHTLC(payload.Recipient, payload.Hash, payload.Timeout, payload.Amount, payload.Fee, port[1:])
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(rejectResponse{err.Error(), blockchain.RejectReason(err)})
+		return
+	}
 	p2p.BroadcastNewTx(tx)
 	rw.WriteHeader(http.StatusCreated)
+	index := len(tx.TxOuts) - 1
+	json.NewEncoder(rw).Encode(htlcResponse{tx, index, htlc, tx.TxOuts[index].Address})
+}
+
+// (/htlc/claim, /htlc/refund) HTLC 출력을 비밀 값으로 가져가거나, 기한이 지난 뒤 돌려받는 트랜잭션을 멤풀에 추가
+func spendHTLC(claim bool) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		var payload htlcSpendPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(rw).Encode(errorResponse{err.Error()})
+			return
+		}
+		if payload.Fee == 0 {
+			payload.Fee = blockchain.MinTxFee
+		}
+		var tx *blockchain.Tx
+		var err error
+		if claim {
+			tx, err = blockchain.Mempool().ClaimHTLC(payload.TxID, payload.Index, payload.Preimage, payload.Fee, port[1:])
+		} else {
+			tx, err = blockchain.Mempool().RefundHTLC(payload.TxID, payload.Index, payload.Fee, port[1:])
+		}
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(rw).Encode(rejectResponse{err.Error(), blockchain.RejectReason(err)})
+			return
+		}
+		p2p.BroadcastNewTx(tx)
+		rw.WriteHeader(http.StatusCreated)
+		json.NewEncoder(rw).Encode(tx)
+	}
+}
+
+// (/htlc/{txid}/{index}) HTLC 출력의 상태와 공개된 비밀 값 조회
+func htlcStatus(rw http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	encoder := json.NewEncoder(rw)
+	index, _ := strconv.Atoi(vars["index"])
+	status, err := blockchain.FindHTLC(vars["txid"], index)
+	if err != nil {
+		rw.WriteHeader(http.StatusNotFound)
+		encoder.Encode(errorResponse{err.Error()})
+		return
+	}
+	if err := encoder.Encode(status); err != nil {
+		log.Error(err)
+	}
+}
+
+// (/script/template) 템플릿(p2pk, p2pkh, multisig, timelock, hashlock)으로 잠금 스크립트와 주소 생성
+func scriptTemplate(rw http.ResponseWriter, r *http.Request) {
+	var template blockchain.ScriptTemplate
+	if err := json.NewDecoder(r.Body).Decode(&template); err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(errorResponse{err.Error()})
+		return
+	}
+	script, err := blockchain.BuildScript(&template)
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(errorResponse{err.Error()})
+		return
+	}
+	json.NewEncoder(rw).Encode(scriptResponse{script, blockchain.ScriptAddress(script)})
+}
+
+// (/script/trace) 잠금 스크립트를 현재 체인 기준으로 실행하고 단계별 스택과 비용을 반환 (디버깅용, 멤풀에는 추가하지 않음)
+func scriptTrace(rw http.ResponseWriter, r *http.Request) {
+	var payload scriptTracePayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(errorResponse{err.Error()})
+		return
+	}
+	if payload.Tx == nil {
+		json.NewEncoder(rw).Encode(blockchain.TraceScript(payload.Script, payload.Witness, payload.Payload))
+		return
+	}
+	trace, err := blockchain.TraceInput(payload.Tx, payload.Input)
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(errorResponse{err.Error()})
+		return
+	}
+	json.NewEncoder(rw).Encode(trace)
+}
+
+// (/multisig/address) 다중서명 잠금 조건의 주소 계산
+func multisigAddress(rw http.ResponseWriter, r *http.Request) {
+	var lock *blockchain.MultisigLock
+	if err := json.NewDecoder(r.Body).Decode(&lock); err != nil || lock == nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(errorResponse{"invalid multisig lock"})
+		return
+	}
+	address, err := lock.CheckedAddress()
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(errorResponse{err.Error()})
+		return
+	}
+	json.NewEncoder(rw).Encode(multisigAddressResponse{address, lock})
+}
+
+// (/multisig/spend) 다중서명 주소에서 보내는 서명 전 트랜잭션 구성 (공동 서명자들이 서명한 뒤 /transactions/raw로 제출)
+func multisigSpend(rw http.ResponseWriter, r *http.Request) {
+	var payload multisigSpendPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(errorResponse{err.Error()})
+		return
+	}
+	if payload.Fee == 0 {
+		payload.Fee = blockchain.MinTxFee
+	}
+	tx, err := blockchain.MakeMultisigTx(payload.From, &blockchain.TxOut{Address: payload.To, Amount: payload.Amount, Asset: payload.Asset}, payload.Fee, payload.InputData)
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(errorResponse{err.Error()})
+		return
+	}
+	json.NewEncoder(rw).Encode(tx)
+}
+
+// (/multisig/sign) 트랜잭션의 다중서명 입력 중 이 노드의 지갑이 공동 서명자인 입력에 서명 추가
+func multisigSign(rw http.ResponseWriter, r *http.Request) {
+	var tx *blockchain.Tx
+	if err := json.NewDecoder(r.Body).Decode(&tx); err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(errorResponse{err.Error()})
+		return
+	}
+	signed, err := blockchain.SignMultisig(tx, port[1:])
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(errorResponse{err.Error()})
+		return
+	}
+	json.NewEncoder(rw).Encode(multisigSignResponse{signed, tx})
+}
+
+// (/transactions/raw) 클라이언트가 직접 서명한 트랜잭션을 검증 후 멤풀에 추가하고 전파
+func rawTransaction(rw http.ResponseWriter, r *http.Request) {
+	var tx *blockchain.Tx
+	if err := json.NewDecoder(r.Body).Decode(&tx); err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(errorResponse{err.Error()})
+		return
+	}
+	if err := blockchain.Mempool().SubmitTx(tx); err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(rejectResponse{err.Error(), blockchain.RejectReason(err)})
+		return
+	}
+	p2p.BroadcastNewTx(tx)
+	rw.WriteHeader(http.StatusCreated)
+	json.NewEncoder(rw).Encode(tx)
 }
 
 // (/randomTransaction) 랜덤한 트랜잭션을 멤풀에 추가. 테스트용
 func randomTransaction(rw http.ResponseWriter, r *http.Request) {
 	var randomQuantity = rand.Intn(50)
 	var randomTo = utils.Hash(randomQuantity)
-	tx, err := blockchain.Mempool().AddTx(randomTo, randomQuantity, "Random Transaction", port[1:])
+	tx, err := blockchain.Mempool().AddTx(randomTo, blockchain.Amount(randomQuantity), blockchain.MinTxFee, "Random Transaction", port[1:])
 	if err != nil {
 		rw.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(rw).Encode(errorResponse{err.Error()})
+		json.NewEncoder(rw).Encode(rejectResponse{err.Error(), blockchain.RejectReason(err)})
 		return
 	}
 	p2p.BroadcastNewTx(tx)
@@ -254,6 +896,32 @@ func myWallet(rw http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(rw).Encode(myWalletResponse{Port: port[1:], Address: address})
 }
 
+// (/wallet/consolidate) 노드 지갑의 작은 UTXO들을 하나로 합치는 트랜잭션을 멤풀에 추가
+func consolidate(rw http.ResponseWriter, r *http.Request) {
+	var payload consolidatePayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil && !errors.Is(err, io.EOF) {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(errorResponse{err.Error()})
+		return
+	}
+	if payload.Fee == 0 {
+		payload.Fee = blockchain.MinTxFee
+	}
+	tx, err := blockchain.Mempool().Consolidate(payload.MaxAmount, payload.MaxInputs, payload.Fee, port[1:])
+	if err != nil {
+		if blockchain.RejectReason(err) == blockchain.RejectBusy {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+		} else {
+			rw.WriteHeader(http.StatusBadRequest)
+		}
+		json.NewEncoder(rw).Encode(rejectResponse{err.Error(), blockchain.RejectReason(err)})
+		return
+	}
+	p2p.BroadcastNewTx(tx)
+	rw.WriteHeader(http.StatusCreated)
+	json.NewEncoder(rw).Encode(tx)
+}
+
 // (/peer) GET: 연결된 peer 노드 리스트 출력, POST: 새로운 노드와 peer 맺기
 func peers(rw http.ResponseWriter, r *http.Request) {
 	switch r.Method {
@@ -269,10 +937,10 @@ func peers(rw http.ResponseWriter, r *http.Request) {
 
 // (/stake) PoS에 참여하기 위해, 스테이킹 트랜잭션을 멤풀에 추가 - (수량: 100, 기간: 1달)
 func stake(rw http.ResponseWriter, r *http.Request) {
-	tx, err := blockchain.Mempool().AddTx(utils.StakingAddress, utils.StakingQuantity, port[1:], port[1:])
+	tx, err := blockchain.Mempool().AddStakeTx(blockchain.MinTxFee, port[1:])
 	if err != nil {
 		rw.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(rw).Encode(errorResponse{err.Error()})
+		json.NewEncoder(rw).Encode(rejectResponse{err.Error(), blockchain.RejectReason(err)})
 		return
 	}
 	p2p.BroadcastNewTx(tx)
@@ -282,31 +950,16 @@ func stake(rw http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// (/unstake) 언스테이킹 트랜잭션을 멤풀에 추가 (락업 기한이 지났다면, 스테이킹 노드에게서 인출 요청)
+// (/unstake) 노드 지갑의 스테이킹 출력을 돌려받는 언스테이킹 트랜잭션을 멤풀에 추가 (스테이킹 출력의 시간 잠금이 풀리기 전이라면 멤풀이 locked 코드로 거절)
 func unstake(rw http.ResponseWriter, r *http.Request) {
-	myAddress := wallet.Wallet(port[1:]).Address
-	_, stakingWalletTx, indexes := blockchain.UTxOutsByStakingAddress(utils.StakingAddress, blockchain.Blockchain())
-	stakingInfoList := blockchain.GetStakingList(stakingWalletTx, blockchain.Blockchain())
-	myStakingInfo := blockchain.CheckStaking(stakingInfoList, myAddress)
-
-	if myStakingInfo == nil {
+	tx, err := blockchain.Mempool().AddUnstakeTx(blockchain.MinTxFee, port[1:])
+	if errors.Is(err, blockchain.ErrNotStaked) {
 		json.NewEncoder(rw).Encode(ResNotStaked)
 		return
 	}
-
-	ok, remainTime := blockchain.CheckLockupPeriod(myStakingInfo.TimeStamp)
-	if !ok {
-		ResTimeRemained["message"] = utils.FormatTimeFromSeconds(remainTime)
-		if err := json.NewEncoder(rw).Encode(ResTimeRemained); err != nil {
-			log.Error(err)
-		}
-		return
-	}
-
-	tx, err := blockchain.Mempool().AddTxFromStakingAddress(utils.StakingAddress, myAddress, "unstaking ordered", utils.StakingNodePort, utils.StakingQuantity, myStakingInfo, indexes)
 	if err != nil {
 		rw.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(rw).Encode(errorResponse{err.Error()})
+		json.NewEncoder(rw).Encode(rejectResponse{err.Error(), blockchain.RejectReason(err)})
 		return
 	}
 	p2p.BroadcastNewTx(tx)
@@ -316,11 +969,43 @@ func unstake(rw http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// (/staking) 현재 스테이킹 중인 노드와 지갑정보를 조회
+// (/slash) 이중 서명 증거를 제출하여 해당 검증자의 스테이킹 출력을 몰수하는 트랜잭션을 멤풀에 추가 (신고 보상은 노드의 지갑이 받음)
+func slash(rw http.ResponseWriter, r *http.Request) {
+	var evidence blockchain.SlashPayload
+	if err := json.NewDecoder(r.Body).Decode(&evidence); err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(errorResponse{err.Error()})
+		return
+	}
+	tx, err := blockchain.Mempool().AddSlashTx(&evidence, port[1:])
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(rejectResponse{err.Error(), blockchain.RejectReason(err)})
+		return
+	}
+	p2p.BroadcastNewTx(tx)
+	rw.WriteHeader(http.StatusCreated)
+	json.NewEncoder(rw).Encode(tx)
+}
+
+// (/staking) 검증자 등록부에서 현재 스테이킹 중인 검증자를 조회 (?all=true라면 언스테이킹, 몰수된 기록까지 전부)
 func checkStaking(rw http.ResponseWriter, r *http.Request) {
-	_, stakingWalletTx, _ := blockchain.UTxOutsByStakingAddress(utils.StakingAddress, blockchain.Blockchain())
-	stakingInfoList := blockchain.GetStakingList(stakingWalletTx, blockchain.Blockchain())
-	if err := json.NewEncoder(rw).Encode(stakingInfoList); err != nil {
+	var list []*blockchain.Validator
+	var err error
+	if r.URL.Query().Get("all") == "true" {
+		list, err = blockchain.Validators()
+	} else {
+		list, err = blockchain.GetStakingList()
+	}
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(rw).Encode(errorResponse{err.Error()})
+		return
+	}
+	if list == nil {
+		list = []*blockchain.Validator{}
+	}
+	if err := json.NewEncoder(rw).Encode(list); err != nil {
 		log.Error(err)
 	}
 }
@@ -332,19 +1017,38 @@ func Start(aPort int) {
 	router.Use(jsonContentTypeMiddleware, loggerMiddleware) // 모든 라우터가 이 middleware사용
 	router.HandleFunc("/", documentation).Methods("GET")
 	router.HandleFunc("/status", status)
-	router.HandleFunc("/blocks", blocks).Methods("GET", "POST")
+	router.HandleFunc("/blocks", blocks).Methods("GET")
 	router.HandleFunc("/blocks/{hash:[a-f0-9]+}", block).Methods("GET") // hash: hexadecimal 타입 // [a-f0-9] 이렇게해야 둘다 받을 수 있음
+	router.HandleFunc("/blocks/height/{n:[0-9]+}", blockByHeight).Methods("GET")
+	router.HandleFunc("/blocks/{hash:[a-f0-9]+}/proof/{txid:[a-f0-9]+}", merkleProof).Methods("GET")
+	router.HandleFunc("/transactions/{id:[a-f0-9]+}", transactionByID).Methods("GET")
+	router.HandleFunc("/addresses/{address}/history", addressHistory).Methods("GET")
 	router.HandleFunc("/balance", myBalance).Methods("GET")
 	router.HandleFunc("/balances/{address}", balance).Methods("GET")
 	router.HandleFunc("/mempool", mempool).Methods("GET")
 	router.HandleFunc("/wallet", myWallet).Methods("GET")
+	router.HandleFunc("/wallet/consolidate", consolidate).Methods("POST")
 	router.HandleFunc("/randomTransaction", randomTransaction).Methods("GET")
 	router.HandleFunc("/transaction", transaction).Methods("POST")
+	router.HandleFunc("/transactions/raw", rawTransaction).Methods("POST")
+	router.HandleFunc("/multisig/address", multisigAddress).Methods("POST")
+	router.HandleFunc("/script/template", scriptTemplate).Methods("POST")
+	router.HandleFunc("/assets", assets).Methods("GET", "POST")
+	router.HandleFunc("/assets/{id:[a-f0-9]+}", asset).Methods("GET")
+	router.HandleFunc("/htlc", createHTLC).Methods("POST")
+	router.HandleFunc("/htlc/claim", spendHTLC(true)).Methods("POST")
+	router.HandleFunc("/htlc/refund", spendHTLC(false)).Methods("POST")
+	router.HandleFunc("/htlc/{txid:[a-f0-9]+}/{index:[0-9]+}", htlcStatus).Methods("GET")
+	router.HandleFunc("/script/trace", scriptTrace).Methods("POST")
+	router.HandleFunc("/multisig/spend", multisigSpend).Methods("POST")
+	router.HandleFunc("/multisig/sign", multisigSign).Methods("POST")
 	router.HandleFunc("/ws", p2p.Upgrade).Methods("GET")
 	router.HandleFunc("/peer", peers).Methods("GET", "POST")
 	router.HandleFunc("/stake", stake).Methods("POST")
 	router.HandleFunc("/unstake", unstake).Methods("POST")
 	router.HandleFunc("/staking", checkStaking).Methods("GET")
+	router.HandleFunc("/roles/{height:[0-9]+}", roles).Methods("GET")
+	router.HandleFunc("/slash", slash).Methods("POST")
 	// Gorilla Mux 공식문서에 나와있는대로
 	fmt.Printf("Listening on http://localhost%s\n", port)
 	if err := http.ListenAndServe(port, router); err != nil {
@@ -2,11 +2,12 @@ package rpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 
 	"github.com/abcfe-op/abcfe-node/blockchain"
-	"github.com/abcfe-op/abcfe-node/common/utils"
+	"github.com/abcfe-op/abcfe-node/p2p"
 	"github.com/abcfe-op/abcfe-node/proto"
 	"github.com/abcfe-op/abcfe-node/wallet"
 	"google.golang.org/grpc"
@@ -38,25 +39,20 @@ type server struct {
 // 	return &proto.BlocksResponse{Blocks: protoBlocks}, nil
 // }
 
-func (s *server) GetBlocks(ctx context.Context, empty *proto.Empty) (*proto.BlocksResponse, error) {
-	blocks := blockchain.Blocks(blockchain.Blockchain())
-	protoBlocks := make([]*proto.Block, 0, len(blocks))
-
-	for _, b := range blocks {
-		protoBlock := &proto.Block{
-			Hash:        b.Hash,
-			PrevHash:    b.PrevHash,
-			Height:      int32(b.Height),
-			Timestamp:   int32(b.Timestamp),
-			Transaction: setTransactions(b.Transaction),
-			RoleInfo:    setProtoRoleInfo(b.RoleInfo),
-			Signature:   setSignatures(b.Signature),
-		}
-		protoBlocks = append(protoBlocks, protoBlock)
+func (s *server) GetBlocks(ctx context.Context, req *proto.BlocksRequest) (*proto.BlocksResponse, error) {
+	page, err := blockchain.BlocksByRange(blockchain.Blockchain(), int(req.From), int(req.To), int(req.Limit))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	protoBlocks := make([]*proto.Block, 0, len(page.Blocks))
+
+	for _, b := range page.Blocks {
+		protoBlocks = append(protoBlocks, setProtoBlock(b))
 	}
 
 	return &proto.BlocksResponse{
-		Blocks: protoBlocks,
+		Blocks:     protoBlocks,
+		NextCursor: int64(page.NextCursor),
 	}, nil
 }
 
@@ -66,26 +62,142 @@ func (s *server) GetBlock(ctx context.Context, req *proto.BlockRequest) (*proto.
 		return nil, status.Errorf(codes.NotFound, "Block not found")
 	}
 
-	protoBlock := &proto.Block{
-		Hash:        block.Hash,
-		PrevHash:    block.PrevHash,
-		Height:      int32(block.Height),
-		Timestamp:   int32(block.Timestamp),
-		Transaction: setTransactions(block.Transaction),
-		RoleInfo:    setProtoRoleInfo(block.RoleInfo),
-		Signature:   setSignatures(block.Signature),
+	return &proto.BlockResponse{
+		Block: setProtoBlock(block),
+	}, nil
+}
+
+func (s *server) GetBlockByHeight(ctx context.Context, req *proto.BlockHeightRequest) (*proto.BlockResponse, error) {
+	block, err := blockchain.FindBlockByHeight(int(req.Height))
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "Block not found")
 	}
 
 	return &proto.BlockResponse{
-		Block: protoBlock,
+		Block: setProtoBlock(block),
+	}, nil
+}
+
+func (s *server) GetMerkleProof(ctx context.Context, req *proto.MerkleProofRequest) (*proto.MerkleProofResponse, error) {
+	block, err := blockchain.FindBlock(req.BlockHash)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "Block not found")
+	}
+	proof, err := block.MerkleProof(req.TxId)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "Transaction not found in block")
+	}
+
+	protoPath := make([]*proto.MerkleStep, 0, len(proof.Path))
+	for _, step := range proof.Path {
+		protoPath = append(protoPath, &proto.MerkleStep{
+			Hash: step.Hash,
+			Left: step.Left,
+		})
+	}
+	return &proto.MerkleProofResponse{
+		TxId:       proof.TxID,
+		BlockHash:  proof.BlockHash,
+		MerkleRoot: proof.MerkleRoot,
+		Index:      int32(proof.Index),
+		Path:       protoPath,
+	}, nil
+}
+
+func (s *server) GetTransaction(ctx context.Context, req *proto.TxRequest) (*proto.TxResponse, error) {
+	tx, location, err := blockchain.FindTransaction(req.Id)
+	if err != nil {
+		return nil, indexError(err)
+	}
+	return &proto.TxResponse{
+		Transaction: setTransactions([]*blockchain.Tx{tx})[0],
+		BlockHash:   location.BlockHash,
+		Height:      int64(location.Height),
+		Position:    int32(location.Position),
+	}, nil
+}
+
+func (s *server) GetAddressHistory(ctx context.Context, req *proto.AddressHistoryRequest) (*proto.AddressHistoryResponse, error) {
+	page, err := blockchain.AddressHistory(req.Address, req.Cursor, int(req.Limit))
+	if err != nil {
+		return nil, indexError(err)
+	}
+	protoHistory := make([]*proto.HistoryEntry, 0, len(page.History))
+	for _, entry := range page.History {
+		protoHistory = append(protoHistory, &proto.HistoryEntry{
+			Height:    int64(entry.Height),
+			TxId:      entry.TxID,
+			Delta:     entry.Delta,
+			Timestamp: int64(entry.Timestamp),
+			Kind:      string(entry.Kind),
+			Received:  uint64(entry.Received),
+			Sent:      uint64(entry.Sent),
+		})
+	}
+	return &proto.AddressHistoryResponse{
+		Address:    page.Address,
+		History:    protoHistory,
+		NextCursor: page.NextCursor,
+	}, nil
+}
+
+func (s *server) CreateTransaction(ctx context.Context, req *proto.TransactionRequest) (*proto.TransactionResponse, error) {
+	fee := blockchain.Amount(req.Fee)
+	if fee == 0 { // 생략하면 최소 수수료
+		fee = blockchain.MinTxFee
+	}
+	outputs := req.Outputs
+	if len(outputs) == 0 {
+		outputs = []*proto.TxOut{{Address: req.To, Amount: req.Amount, Multisig: req.Multisig, Lock: req.Lock, Script: req.Script, Asset: req.Asset}}
+	} else if req.To != "" || req.Amount != 0 || req.Multisig != nil || req.Lock != nil || req.Script != "" || req.Asset != "" {
+		return nil, status.Error(codes.InvalidArgument, "outputs cannot be combined with a single recipient")
+	}
+	txOuts, err := getPaymentTxOuts(outputs)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	selection := blockchain.CoinSelection(req.Selection)
+	tx, err := blockchain.Mempool().AddTxOuts(txOuts, fee, selection, req.InputData, int(req.LockTime), s.port[1:])
+	if err != nil {
+		return &proto.TransactionResponse{
+			Success:    false,
+			Message:    err.Error(),
+			RejectCode: string(blockchain.RejectReason(err)),
+		}, nil
+	}
+	p2p.BroadcastNewTx(tx)
+	return &proto.TransactionResponse{
+		Success:     true,
+		Message:     "Transaction is added to mempool.",
+		Transaction: setTransactions([]*blockchain.Tx{tx})[0],
+	}, nil
+}
+
+func (s *server) SubmitTransaction(ctx context.Context, req *proto.SubmitTransactionRequest) (*proto.TransactionResponse, error) {
+	if req.Transaction == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Transaction is missing")
+	}
+	tx := getTransaction(req.Transaction)
+	if err := blockchain.Mempool().SubmitTx(tx); err != nil {
+		return &proto.TransactionResponse{
+			Success:    false,
+			Message:    err.Error(),
+			RejectCode: string(blockchain.RejectReason(err)),
+		}, nil
+	}
+	p2p.BroadcastNewTx(tx)
+	return &proto.TransactionResponse{
+		Success:     true,
+		Message:     "Transaction is added to mempool.",
+		Transaction: req.Transaction,
 	}, nil
 }
 
 func (s *server) GetStatus(ctx context.Context, req *proto.Empty) (*proto.StatusResponse, error) {
-	blockchain.Blockchain()
+	hash, height := blockchain.Blockchain().Tip()
 	return &proto.StatusResponse{
-		CurrentHeight: int64(blockchain.Blockchain().Height),
-		CurrentHash:   blockchain.Blockchain().NewestHash,
+		CurrentHeight: int64(height),
+		CurrentHash:   hash,
 	}, nil
 }
 
@@ -98,24 +210,92 @@ func (s *server) GetWallet(ctx context.Context, req *proto.Empty) (*proto.Wallet
 }
 
 func (s *server) GetBalance(ctx context.Context, req *proto.BalanceRequest) (*proto.BalanceResponse, error) {
-	amount := blockchain.BalanceByAddress(req.Address, blockchain.Blockchain())
+	amount, err := blockchain.BalanceByAddress(req.Address, blockchain.Blockchain())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	balances, err := blockchain.AssetBalancesByAddress(req.Address)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	protoAssets := make([]*proto.AssetBalance, 0, len(balances))
+	for _, b := range balances {
+		protoAssets = append(protoAssets, &proto.AssetBalance{
+			Asset:    b.Asset,
+			Name:     b.Name,
+			Decimals: int32(b.Decimals),
+			Balance:  uint64(b.Balance),
+		})
+	}
 	return &proto.BalanceResponse{
 		Address: req.Address,
-		Balance: int64(amount),
+		Balance: uint64(amount),
+		Assets:  protoAssets,
+		Display: amount.Format(),
+	}, nil
+}
+
+func (s *server) GetAsset(ctx context.Context, req *proto.AssetRequest) (*proto.Asset, error) {
+	asset, err := blockchain.FindAsset(req.Id)
+	if err != nil {
+		if errors.Is(err, blockchain.ErrAssetNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return setProtoAsset(asset), nil
+}
+
+func (s *server) ListAssets(ctx context.Context, req *proto.Empty) (*proto.AssetListResponse, error) {
+	assets, err := blockchain.Assets()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	protoAssets := make([]*proto.Asset, 0, len(assets))
+	for _, asset := range assets {
+		protoAssets = append(protoAssets, setProtoAsset(asset))
+	}
+	return &proto.AssetListResponse{Assets: protoAssets}, nil
+}
+
+func (s *server) IssueAsset(ctx context.Context, req *proto.IssueAssetRequest) (*proto.TransactionResponse, error) {
+	fee := blockchain.Amount(req.Fee)
+	if fee == 0 { // 생략하면 최소 수수료
+		fee = blockchain.MinTxFee
+	}
+	tx, err := blockchain.Mempool().IssueAsset(req.Name, int(req.Decimals), blockchain.Amount(req.Amount), req.To, fee, s.port[1:])
+	if err != nil {
+		return &proto.TransactionResponse{
+			Success:    false,
+			Message:    err.Error(),
+			RejectCode: string(blockchain.RejectReason(err)),
+		}, nil
+	}
+	p2p.BroadcastNewTx(tx)
+	return &proto.TransactionResponse{
+		Success:     true,
+		Message:     "Issuance is added to mempool.",
+		Transaction: setTransactions([]*blockchain.Tx{tx})[0],
 	}, nil
 }
 
 func (s *server) GetStakingList(ctx context.Context, req *proto.Empty) (*proto.StakingListResponse, error) {
-	_, stakingWalletTx, _ := blockchain.UTxOutsByStakingAddress(utils.StakingAddress, blockchain.Blockchain())
-	stakingInfoList := blockchain.GetStakingList(stakingWalletTx, blockchain.Blockchain())
-	protoStakingList := make([]*proto.StakingInfo, 0, len(stakingInfoList))
+	validators, err := blockchain.GetStakingList()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	protoStakingList := make([]*proto.StakingInfo, 0, len(validators))
 
-	for _, s := range stakingInfoList {
+	for _, v := range validators {
 		staker := &proto.StakingInfo{
-			Hash:      s.ID,
-			Address:   s.Address,
-			Port:      s.Port,
-			Timestamp: int32(s.TimeStamp),
+			Hash:      v.TxID,
+			Address:   v.Address,
+			Port:      v.Port,
+			Timestamp: int64(v.Timestamp),
+			Stake:     uint64(v.Stake),
+			Status:    string(v.Status),
+			Index:     int32(v.Index),
+			Height:    int64(v.Height),
 		}
 		protoStakingList = append(protoStakingList, staker)
 	}
@@ -123,7 +303,7 @@ func (s *server) GetStakingList(ctx context.Context, req *proto.Empty) (*proto.S
 }
 
 func (s *server) GetMempool(ctx context.Context, req *proto.Empty) (*proto.MempoolResponse, error) {
-	txs := blockchain.Mempool().Txs
+	txs := blockchain.Mempool().Transactions()
 	protoTxs := make([]*proto.Transaction, 0, len(txs))
 	for _, tx := range txs {
 		protoTxs = append(protoTxs, setTransactions([]*blockchain.Tx{tx})[0])
@@ -151,6 +331,48 @@ func Start(port int) {
 	}
 }
 
+// 색인 조회 에러를 gRPC 상태 코드로 변환
+func indexError(err error) error {
+	switch {
+	case errors.Is(err, blockchain.ErrTxIndexDisabled), errors.Is(err, blockchain.ErrAddressIndexDisabled):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, blockchain.ErrTxNotFound), errors.Is(err, blockchain.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func setProtoBlock(b *blockchain.Block) *proto.Block {
+	return &proto.Block{
+		Hash:         b.Hash,
+		PrevHash:     b.PrevHash,
+		Height:       int64(b.Height),
+		Timestamp:    int64(b.Timestamp),
+		Transaction:  setTransactions(b.Transaction),
+		RoleInfo:     setProtoRoleInfo(b.RoleInfo),
+		Signature:    setSignatures(b.Signature),
+		MerkleRoot:   b.MerkleRoot,
+		WitnessRoot:  b.WitnessRoot,
+		RoleInfoHash: b.RoleInfoHash,
+		RandaoReveal: b.RandaoReveal,
+		RandaoMix:    b.RandaoMix,
+	}
+}
+
+func setProtoAsset(asset *blockchain.Asset) *proto.Asset {
+	return &proto.Asset{
+		Id:        asset.ID,
+		Name:      asset.Name,
+		Decimals:  int32(asset.Decimals),
+		Issuer:    asset.Issuer,
+		Supply:    uint64(asset.Supply),
+		Issuances: int32(asset.Issuances),
+		Height:    int64(asset.Height),
+		TxId:      asset.TxID,
+	}
+}
+
 func setProtoRoleInfo(info *blockchain.RoleInfo) *proto.RoleInfo {
 	if info == nil {
 		return nil
@@ -158,10 +380,10 @@ func setProtoRoleInfo(info *blockchain.RoleInfo) *proto.RoleInfo {
 	return &proto.RoleInfo{
 		ProposerAddress:         info.ProposerAddress,
 		ProposerPort:            info.ProposerPort,
-		ProposerSelectedHeight:  int32(info.ProposerSelectedHeight),
+		ProposerSelectedHeight:  int64(info.ProposerSelectedHeight),
 		ValidatorAddress:        info.ValidatorAddress,
 		ValidatorPort:           info.ValidatorPort,
-		ValidatorSelectedHeight: int32(info.ValidatorSelectedHeight),
+		ValidatorSelectedHeight: int64(info.ValidatorSelectedHeight),
 	}
 }
 
@@ -175,6 +397,7 @@ func setSignatures(sigs []*blockchain.ValidateSignature) []*proto.ValidateSignat
 			Port:      sig.Port,
 			Address:   sig.Address,
 			Signature: sig.Signature,
+			Round:     int64(sig.Round),
 		}
 		protoSigs = append(protoSigs, protoSig)
 	}
@@ -189,16 +412,64 @@ func setTransactions(txs []*blockchain.Tx) []*proto.Transaction {
 	for _, tx := range txs {
 		protoTx := &proto.Transaction{
 			Id:        tx.ID,
-			Timestamp: int32(tx.Timestamp),
+			Timestamp: int64(tx.Timestamp),
 			TxIns:     setTxIns(tx.TxIns),
 			TxOuts:    setTxOuts(tx.TxOuts),
 			InputData: tx.InputData,
+			LockTime:  int64(tx.LockTime),
+			Kind:      string(tx.Kind),
+			Payload:   setPayload(tx.Payload),
 		}
 		protoTxs = append(protoTxs, protoTx)
 	}
 	return protoTxs
 }
 
+func setPayload(payload *blockchain.TxPayload) *proto.TxPayload {
+	if payload == nil {
+		return nil
+	}
+	protoPayload := &proto.TxPayload{Version: int32(payload.Version)}
+	if p := payload.Stake; p != nil {
+		protoPayload.Stake = &proto.StakePayload{Staker: p.Staker, Port: p.Port}
+	}
+	if p := payload.Unstake; p != nil {
+		protoPayload.Unstake = &proto.UnstakePayload{StakeTxId: p.StakeTxID, Index: int32(p.Index)}
+	}
+	if p := payload.Coinbase; p != nil {
+		protoPayload.Coinbase = &proto.CoinbasePayload{Height: int64(p.Height)}
+	}
+	if p := payload.Slash; p != nil {
+		protoPayload.Slash = &proto.SlashPayload{Offender: p.Offender}
+		for _, v := range p.Votes {
+			protoPayload.Slash.Votes = append(protoPayload.Slash.Votes, setSignedVote(v))
+		}
+	}
+	if i := payload.Issue; i != nil {
+		protoPayload.Issue = &proto.Issuance{
+			Issuer:    i.Issuer,
+			Name:      i.Name,
+			Decimals:  int32(i.Decimals),
+			Amount:    uint64(i.Amount),
+			Signature: i.Signature,
+		}
+	}
+	return protoPayload
+}
+
+func setSignedVote(v *blockchain.SignedVote) *proto.SignedVote {
+	if v == nil {
+		return nil
+	}
+	return &proto.SignedVote{
+		Type:      v.Type,
+		Height:    int64(v.Height),
+		Round:     int64(v.Round),
+		Hash:      v.Hash,
+		Signature: v.Signature,
+	}
+}
+
 func setTxIns(txIns []*blockchain.TxIn) []*proto.TxIn {
 	if txIns == nil {
 		return nil
@@ -206,9 +477,11 @@ func setTxIns(txIns []*blockchain.TxIn) []*proto.TxIn {
 	protoTxIns := make([]*proto.TxIn, 0, len(txIns))
 	for _, txIn := range txIns {
 		protoTxIn := &proto.TxIn{
-			TxId:      txIn.TxID,
-			Index:     int32(txIn.Index),
-			Signature: txIn.Signature,
+			TxId:       txIn.TxID,
+			Index:      int32(txIn.Index),
+			Signature:  txIn.Signature,
+			Signatures: txIn.Signatures,
+			Witness:    txIn.Witness,
 		}
 		protoTxIns = append(protoTxIns, protoTxIn)
 	}
@@ -223,9 +496,155 @@ func setTxOuts(txOuts []*blockchain.TxOut) []*proto.TxOut {
 	for _, txOut := range txOuts {
 		protoTxOut := &proto.TxOut{
 			Address: txOut.Address,
-			Amount:  int32(txOut.Amount),
+			Amount:  uint64(txOut.Amount),
+			Script:  txOut.Script,
+			Asset:   txOut.Asset,
+		}
+		if txOut.Multisig != nil {
+			protoTxOut.Multisig = &proto.MultisigLock{
+				M:       int32(txOut.Multisig.M),
+				PubKeys: txOut.Multisig.PubKeys,
+			}
+		}
+		if txOut.Lock != nil {
+			protoTxOut.Lock = &proto.TimeLock{
+				Kind:     string(txOut.Lock.Kind),
+				Relative: txOut.Lock.Relative,
+				Value:    int64(txOut.Lock.Value),
+			}
 		}
 		protoTxOuts = append(protoTxOuts, protoTxOut)
 	}
 	return protoTxOuts
 }
+
+func getPayload(protoPayload *proto.TxPayload) *blockchain.TxPayload {
+	if protoPayload == nil {
+		return nil
+	}
+	payload := &blockchain.TxPayload{Version: int(protoPayload.Version)}
+	if p := protoPayload.Stake; p != nil {
+		payload.Stake = &blockchain.StakePayload{Staker: p.Staker, Port: p.Port}
+	}
+	if p := protoPayload.Unstake; p != nil {
+		payload.Unstake = &blockchain.UnstakePayload{StakeTxID: p.StakeTxId, Index: int(p.Index)}
+	}
+	if p := protoPayload.Coinbase; p != nil {
+		payload.Coinbase = &blockchain.CoinbasePayload{Height: int(p.Height)}
+	}
+	if p := protoPayload.Slash; p != nil {
+		payload.Slash = getSlashPayload(p)
+	}
+	if i := protoPayload.Issue; i != nil {
+		payload.Issue = &blockchain.Issuance{
+			Issuer:    i.Issuer,
+			Name:      i.Name,
+			Decimals:  int(i.Decimals),
+			Amount:    blockchain.Amount(i.Amount),
+			Signature: i.Signature,
+		}
+	}
+	return payload
+}
+
+func getSlashPayload(p *proto.SlashPayload) *blockchain.SlashPayload {
+	slash := &blockchain.SlashPayload{Offender: p.Offender}
+	for _, v := range p.Votes {
+		slash.Votes = append(slash.Votes, getSignedVote(v))
+	}
+	return slash
+}
+
+func getSignedVote(v *proto.SignedVote) *blockchain.SignedVote {
+	if v == nil {
+		return nil
+	}
+	return &blockchain.SignedVote{
+		Type:      v.Type,
+		Height:    int(v.Height),
+		Round:     int(v.Round),
+		Hash:      v.Hash,
+		Signature: v.Signature,
+	}
+}
+
+func getTransaction(protoTx *proto.Transaction) *blockchain.Tx {
+	tx := &blockchain.Tx{
+		ID:        protoTx.Id,
+		Timestamp: int(protoTx.Timestamp),
+		InputData: protoTx.InputData,
+		LockTime:  int(protoTx.LockTime),
+		Kind:      blockchain.TxKind(protoTx.Kind),
+		Payload:   getPayload(protoTx.Payload),
+	}
+	for _, txIn := range protoTx.TxIns {
+		tx.TxIns = append(tx.TxIns, &blockchain.TxIn{
+			TxID:       txIn.TxId,
+			Index:      int(txIn.Index),
+			Signature:  txIn.Signature,
+			Signatures: txIn.Signatures,
+			Witness:    txIn.Witness,
+		})
+	}
+	for _, txOut := range protoTx.TxOuts {
+		tx.TxOuts = append(tx.TxOuts, &blockchain.TxOut{
+			Address:  txOut.Address,
+			Amount:   blockchain.Amount(txOut.Amount),
+			Multisig: getMultisigLock(txOut.Multisig),
+			Lock:     getTimeLock(txOut.Lock),
+			Script:   txOut.Script,
+			Asset:    txOut.Asset,
+		})
+	}
+	return tx
+}
+
+// 여러 곳에 보낼 받는 출력 구성 (다중서명 잠금 조건이나 잠금 스크립트가 있다면 그것으로 만든 주소로 보냄)
+func getPaymentTxOuts(protoTxOuts []*proto.TxOut) ([]*blockchain.TxOut, error) {
+	txOuts := make([]*blockchain.TxOut, 0, len(protoTxOuts))
+	for _, o := range protoTxOuts {
+		if o == nil {
+			return nil, errors.New("empty output")
+		}
+		txOut := &blockchain.TxOut{
+			Address:  o.Address,
+			Amount:   blockchain.Amount(o.Amount),
+			Multisig: getMultisigLock(o.Multisig),
+			Lock:     getTimeLock(o.Lock),
+			Asset:    o.Asset,
+		}
+		if txOut.Multisig != nil {
+			address, err := txOut.Multisig.CheckedAddress()
+			if err != nil {
+				return nil, err
+			}
+			txOut.Address = address
+		}
+		if o.Script != "" {
+			txOut.Address, txOut.Script = blockchain.ScriptAddress(o.Script), o.Script
+		}
+		txOuts = append(txOuts, txOut)
+	}
+	return txOuts, nil
+}
+
+func getTimeLock(protoLock *proto.TimeLock) *blockchain.TimeLock {
+	if protoLock == nil {
+		return nil
+	}
+	return &blockchain.TimeLock{
+		Kind:     blockchain.LockKind(protoLock.Kind),
+		Relative: protoLock.Relative,
+		Value:    int(protoLock.Value),
+	}
+}
+
+func getMultisigLock(protoLock *proto.MultisigLock) *blockchain.MultisigLock {
+	if protoLock == nil {
+		return nil
+	}
+	return &blockchain.MultisigLock{
+		M:       int(protoLock.M),
+		PubKeys: protoLock.PubKeys,
+	}
+}
@@ -0,0 +1,138 @@
+package wallet
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+
+	log "github.com/abcfe-op/abcfe-node/common/logger"
+)
+
+// VRF (검증 가능한 랜덤 함수): 개인 키의 주인만 입력에 대한 출력을 만들 수 있고, 입력마다 출력은 하나로 정해지며,
+// 누구나 주소와 증명으로 출력을 검증할 수 있다. RFC 9381의 ECVRF-P256-SHA256-TAI 구성을 따른다
+// (증명 = Gamma의 압축 좌표 33바이트 || c 16바이트 || s 32바이트)
+const (
+	vrfSuite         byte = 0x01
+	vrfPointSize          = 33
+	vrfChallengeSize      = 16
+	vrfProofSize          = vrfPointSize + vrfChallengeSize + intSize
+)
+
+var curve = elliptic.P256()
+
+// 입력을 곡선 위의 점으로 변환 (해시 값을 x 좌표로 하는 점이 나올 때까지 카운터를 늘려가며 시도)
+func vrfHashToCurve(publicKey, alpha []byte) (*big.Int, *big.Int) {
+	for ctr := 0; ctr < 256; ctr++ {
+		data := append([]byte{vrfSuite, 0x01}, publicKey...)
+		data = append(data, alpha...)
+		data = append(data, byte(ctr), 0x00)
+		h := sha256.Sum256(data)
+		if x, y := elliptic.UnmarshalCompressed(curve, append([]byte{0x02}, h[:]...)); x != nil {
+			return x, y
+		}
+	}
+	return nil, nil
+}
+
+// 증명의 챌린지 값 계산
+func vrfChallenge(points ...[]byte) []byte {
+	data := []byte{vrfSuite, 0x02}
+	for _, p := range points {
+		data = append(data, p...)
+	}
+	h := sha256.Sum256(append(data, 0x00))
+	return h[:vrfChallengeSize]
+}
+
+// 점 Gamma로 VRF 출력 계산
+func vrfOutput(gamma []byte) string {
+	data := append([]byte{vrfSuite, 0x03}, gamma...)
+	h := sha256.Sum256(append(data, 0x00))
+	return hex.EncodeToString(h[:])
+}
+
+// 점의 부호를 뒤집음 (x, -y)
+func negate(x, y *big.Int) (*big.Int, *big.Int) {
+	return x, new(big.Int).Sub(curve.Params().P, y)
+}
+
+// 주어진 지갑으로 16진수 입력에 대한 VRF 증명 생성
+func Prove(alpha string, w *wallet) string {
+	alphaBytes, err := hex.DecodeString(alpha)
+	log.HandleErr(err)
+	key := w.privateKey
+	publicKey := elliptic.MarshalCompressed(curve, key.X, key.Y)
+	hx, hy := vrfHashToCurve(publicKey, alphaBytes)
+	gx, gy := curve.ScalarMult(hx, hy, key.D.Bytes())
+	k, err := rand.Int(rand.Reader, curve.Params().N)
+	log.HandleErr(err)
+	ux, uy := curve.ScalarBaseMult(k.Bytes())
+	vx, vy := curve.ScalarMult(hx, hy, k.Bytes())
+	gamma := elliptic.MarshalCompressed(curve, gx, gy)
+	c := vrfChallenge(publicKey, elliptic.MarshalCompressed(curve, hx, hy), gamma,
+		elliptic.MarshalCompressed(curve, ux, uy), elliptic.MarshalCompressed(curve, vx, vy))
+	s := new(big.Int).Mul(new(big.Int).SetBytes(c), key.D)
+	s.Add(s, k).Mod(s, curve.Params().N)
+	proof := make([]byte, vrfProofSize)
+	copy(proof, gamma)
+	copy(proof[vrfPointSize:], c)
+	s.FillBytes(proof[vrfPointSize+vrfChallengeSize:])
+	return hex.EncodeToString(proof)
+}
+
+// 직접 만든 VRF 증명의 출력 (다른 노드에게 받은 증명은 VerifyProof로 검증하며 출력을 얻어야 함)
+func ProofOutput(proof string) string {
+	proofBytes, err := hex.DecodeString(proof)
+	if err != nil || len(proofBytes) != vrfProofSize {
+		return ""
+	}
+	return vrfOutput(proofBytes[:vrfPointSize])
+}
+
+// 주소의 공개 키로 16진수 입력에 대한 VRF 증명을 검증하고 출력을 반환
+// 증명, 주소, 입력은 외부에서 전달받은 값일 수 있으므로, 형식이 잘못되었다면 검증 실패로 처리
+func VerifyProof(proof, alpha, address string) (string, bool) {
+	proofBytes, err := hex.DecodeString(proof)
+	if err != nil || len(proofBytes) != vrfProofSize {
+		return "", false
+	}
+	alphaBytes, err := hex.DecodeString(alpha)
+	if err != nil {
+		return "", false
+	}
+	x, y, ok := restorePublicKey(address)
+	if !ok {
+		return "", false
+	}
+	gamma := proofBytes[:vrfPointSize]
+	gx, gy := elliptic.UnmarshalCompressed(curve, gamma)
+	if gx == nil {
+		return "", false
+	}
+	c := proofBytes[vrfPointSize : vrfPointSize+vrfChallengeSize]
+	s := new(big.Int).SetBytes(proofBytes[vrfPointSize+vrfChallengeSize:])
+	if s.Cmp(curve.Params().N) >= 0 {
+		return "", false
+	}
+	publicKey := elliptic.MarshalCompressed(curve, x, y)
+	hx, hy := vrfHashToCurve(publicKey, alphaBytes)
+	if hx == nil {
+		return "", false
+	}
+	// U = s*B - c*Y, V = s*H - c*Gamma
+	sbx, sby := curve.ScalarBaseMult(s.Bytes())
+	cyx, cyy := negate(curve.ScalarMult(x, y, c))
+	ux, uy := curve.Add(sbx, sby, cyx, cyy)
+	shx, shy := curve.ScalarMult(hx, hy, s.Bytes())
+	cgx, cgy := negate(curve.ScalarMult(gx, gy, c))
+	vx, vy := curve.Add(shx, shy, cgx, cgy)
+	expected := vrfChallenge(publicKey, elliptic.MarshalCompressed(curve, hx, hy), gamma,
+		elliptic.MarshalCompressed(curve, ux, uy), elliptic.MarshalCompressed(curve, vx, vy))
+	if !bytes.Equal(expected, c) {
+		return "", false
+	}
+	return vrfOutput(gamma), true
+}
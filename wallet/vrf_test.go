@@ -0,0 +1,84 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/abcfe-op/abcfe-node/common/utils"
+)
+
+func TestVRFOutputIsUniquePerInput(t *testing.T) {
+	w := testWallet(t)
+	alpha := utils.HashBytes([]byte("alpha"))
+	first, second := Prove(alpha, w), Prove(alpha, w)
+	if first == second {
+		t.Fatal("proofs do not use a fresh nonce")
+	}
+	out1, ok1 := VerifyProof(first, alpha, w.Address)
+	out2, ok2 := VerifyProof(second, alpha, w.Address)
+	if !ok1 || !ok2 {
+		t.Fatal("valid proofs do not verify")
+	}
+	if out1 != out2 || out1 != ProofOutput(first) || len(out1) != 64 {
+		t.Fatalf("outputs %q and %q differ for the same input", out1, out2)
+	}
+	other, _ := VerifyProof(Prove(utils.HashBytes([]byte("beta")), w), utils.HashBytes([]byte("beta")), w.Address)
+	if other == out1 {
+		t.Fatal("different inputs give the same output")
+	}
+	if another := testWallet(t); ProofOutput(Prove(alpha, another)) == out1 {
+		t.Fatal("different keys give the same output")
+	}
+}
+
+func TestVerifyProof(t *testing.T) {
+	w := testWallet(t)
+	other := testWallet(t)
+	alpha := utils.HashBytes([]byte("alpha"))
+	proof := Prove(alpha, w)
+	// 증명의 한 바이트를 바꿈 (at: 바이트 위치)
+	flip := func(at int) string {
+		b := mustDecode(proof)
+		b[at] ^= 0x01
+		return hex.EncodeToString(b)
+	}
+	maxS := hex.EncodeToString(append(mustDecode(proof)[:vrfPointSize+vrfChallengeSize], mustDecode(strings.Repeat("ff", intSize))...))
+	tests := []struct {
+		name                  string
+		proof, alpha, address string
+		want                  bool
+	}{
+		{"valid", proof, alpha, w.Address, true},
+		{"other input", proof, utils.HashBytes([]byte("beta")), w.Address, false},
+		{"other prover", proof, alpha, other.Address, false},
+		{"changed gamma", flip(1), alpha, w.Address, false},
+		{"changed challenge", flip(vrfPointSize), alpha, w.Address, false},
+		{"changed response", flip(vrfProofSize - 1), alpha, w.Address, false},
+		{"response out of range", maxS, alpha, w.Address, false},
+		{"short proof", proof[:len(proof)-2], alpha, w.Address, false},
+		{"malformed proof", "zz", alpha, w.Address, false},
+		{"malformed input", proof, "zz", w.Address, false},
+		{"malformed address", proof, alpha, "zz", false},
+		{"address off the curve", proof, alpha, strings.Repeat("01", 2*intSize), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, ok := VerifyProof(tt.proof, tt.alpha, tt.address)
+			if ok != tt.want || (!ok && output != "") {
+				t.Fatalf("VerifyProof = %q, %v, want %v", output, ok, tt.want)
+			}
+		})
+	}
+	if ProofOutput("zz") != "" || ProofOutput(proof[:len(proof)-2]) != "" {
+		t.Fatal("malformed proof has an output")
+	}
+}
+
+func mustDecode(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
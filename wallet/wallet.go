@@ -13,7 +13,6 @@ import (
 	"os"
 
 	log "github.com/abcfe-op/abcfe-node/common/logger"
-	"github.com/abcfe-op/abcfe-node/common/utils"
 )
 
 // 지갑 파일의 기본 이름
@@ -80,29 +79,42 @@ func restoreKey(fileNamebyPort string) (key *ecdsa.PrivateKey) {
 	return
 }
 
-// 두 개의 big.Int를 결합하고 16진수 문자열로 인코딩
+// 좌표와 서명 값 하나의 바이트 길이 (P-256)
+const intSize = 32
+
+// 두 개의 big.Int를 각각 고정 길이로 맞춰 결합하고 16진수 문자열로 인코딩 (서명에 사용)
+// (앞자리가 0인 값이 짧게 인코딩되면 복원할 때 두 값의 경계가 어긋남)
 func encodeBigInts(a, b []byte) string {
-	z := append(a, b...)
+	z := make([]byte, 2*intSize)
+	copy(z[intSize-len(a):intSize], a)
+	copy(z[2*intSize-len(b):], b)
 	return fmt.Sprintf("%x", z)
 }
 
 // ECDSA 퍼블릭 키를 생성하는 메서드
+// 주소는 좌표를 패딩하지 않은 기존 형식을 유지 (패딩하면 앞자리가 0인 좌표를 가진 키의 주소가 바뀌어, 기존 주소에 묶인 UTXO를 사용할 수 없게 됨)
 func aFromK(key *ecdsa.PrivateKey) string {
-	return encodeBigInts(key.X.Bytes(), key.Y.Bytes())
+	return fmt.Sprintf("%x%x", key.X.Bytes(), key.Y.Bytes())
 }
 
-// 주어진 지갑 정보로 페이로드를 서명
-func Sign(payload string, w *wallet) string {
-	payloadAsBytes, err := hex.DecodeString(payload)
-	log.HandleErr(err)
-	r, s, err := ecdsa.Sign(rand.Reader, w.privateKey, payloadAsBytes)
-	log.HandleErr(err)
-	return encodeBigInts(r.Bytes(), s.Bytes())
+// 주소로 공개 키 좌표 복원. 패딩하지 않은 기존 형식에서는 앞자리가 0인 좌표가 짧아 두 좌표의 경계가 모호하므로,
+// 가능한 경계 중 곡선 위의 점이 되는 것을 고름 (32바이트씩 패딩한 주소도 그대로 복원됨)
+func restorePublicKey(address string) (*big.Int, *big.Int, bool) {
+	bytes, err := hex.DecodeString(address)
+	if err != nil || len(bytes) > 2*intSize {
+		return nil, nil, false
+	}
+	for split := max(1, len(bytes)-intSize); split <= intSize && split < len(bytes); split++ {
+		x, y := new(big.Int).SetBytes(bytes[:split]), new(big.Int).SetBytes(bytes[split:])
+		if elliptic.P256().IsOnCurve(x, y) {
+			return x, y, true
+		}
+	}
+	return nil, nil, false
 }
 
-// 대리 서명을 생성하는 메서드로, 타인의 지갑 정보로 서명
-func DelegateSign(payload string) string {
-	w := DelegateWallet()
+// 주어진 지갑 정보로 페이로드를 서명
+func Sign(payload string, w *wallet) string {
 	payloadAsBytes, err := hex.DecodeString(payload)
 	log.HandleErr(err)
 	r, s, err := ecdsa.Sign(rand.Reader, w.privateKey, payloadAsBytes)
@@ -125,44 +137,46 @@ func restoreBigInts(payload string) (*big.Int, *big.Int, error) {
 }
 
 // 서명된 데이터의 유효성을 검증
+// 서명, 주소, 페이로드는 외부에서 전달받은 값일 수 있으므로, 형식이 잘못되었다면 검증 실패로 처리
 func Verify(signature, payload, address string) bool {
 	r, s, err := restoreBigInts(signature)
-	log.HandleErr(err)
-	x, y, err := restoreBigInts(address)
-	log.HandleErr(err)
+	if err != nil {
+		return false
+	}
+	x, y, ok := restorePublicKey(address)
+	if !ok {
+		return false
+	}
 	publicKey := ecdsa.PublicKey{
 		Curve: elliptic.P256(),
 		X:     x,
 		Y:     y,
 	}
 	payloadBytes, err := hex.DecodeString(payload)
-	log.HandleErr(err)
-	ok := ecdsa.Verify(&publicKey, payloadBytes, r, s)
-	return ok
-}
-
-// 대리 서명을 위한 지갑 정보를 생성
-func DelegateWallet() *wallet {
-	wallet := &wallet{}
-	path := "./wallets/" + utils.StakingNodePort + fileName
-	wallet.privateKey = restoreKey(path)
-	wallet.Address = aFromK(wallet.privateKey)
-	return wallet
+	if err != nil {
+		return false
+	}
+	return ecdsa.Verify(&publicKey, payloadBytes, r, s)
+}
+
+// 지갑 파일에서 지갑을 불러옴 (파일이 없다면 새 개인 키를 만들어 저장)
+func Load(path string) *wallet {
+	loaded := &wallet{}
+	if files.hasWalletFile(path) {
+		loaded.privateKey = restoreKey(path)
+	} else {
+		key := createPrivateKey()
+		persistKey(path, key)
+		loaded.privateKey = key
+	}
+	loaded.Address = aFromK(loaded.privateKey)
+	return loaded
 }
 
 // 주어진 포트에 해당하는 지갑 정보를 반환
 func Wallet(port string) *wallet {
 	if w == nil {
-		w = &wallet{}
-		path := "./wallets/" + port + fileName
-		if files.hasWalletFile(path) {
-			w.privateKey = restoreKey(path)
-		} else {
-			key := createPrivateKey()
-			persistKey(path, key)
-			w.privateKey = key
-		}
-		w.Address = aFromK(w.privateKey)
+		w = Load("./wallets/" + port + fileName)
 	}
 	return w
 }
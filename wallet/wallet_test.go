@@ -0,0 +1,145 @@
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/abcfe-op/abcfe-node/common/utils"
+)
+
+func testWallet(t *testing.T) *wallet {
+	t.Helper()
+	return Load(filepath.Join(t.TempDir(), "test"+fileName))
+}
+
+func TestEncodeBigIntsPadsEachHalf(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []byte
+	}{
+		{"short first value", []byte{1}, make([]byte, intSize)},
+		{"short second value", make([]byte, intSize), []byte{2, 3}},
+		{"both short", []byte{4}, []byte{5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded := encodeBigInts(tt.a, tt.b)
+			if len(encoded) != 4*intSize {
+				t.Fatalf("encoded length = %d, want %d", len(encoded), 4*intSize)
+			}
+			a, b, err := restoreBigInts(encoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if a.Cmp(new(big.Int).SetBytes(tt.a)) != 0 || b.Cmp(new(big.Int).SetBytes(tt.b)) != 0 {
+				t.Fatalf("restored (%x, %x), want (%x, %x)", a, b, tt.a, tt.b)
+			}
+		})
+	}
+}
+
+func TestSignaturesWithShortValuesVerify(t *testing.T) {
+	w := testWallet(t)
+	payload := utils.HashBytes([]byte("payload"))
+	digest, _ := hex.DecodeString(payload)
+	// r나 s의 앞 바이트가 0인 서명은 대략 128번에 한 번 나오므로, 찾을 때까지 서명
+	for i := 0; i < 5000; i++ {
+		r, s, err := ecdsa.Sign(rand.Reader, w.privateKey, digest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(r.Bytes()) == intSize && len(s.Bytes()) == intSize {
+			continue
+		}
+		if !Verify(encodeBigInts(r.Bytes(), s.Bytes()), payload, w.Address) {
+			t.Fatal("signature with a short value does not verify")
+		}
+		return
+	}
+	t.Fatal("no signature with a short value found")
+}
+
+func TestSignAndVerify(t *testing.T) {
+	w := testWallet(t)
+	other := testWallet(t)
+	payload := utils.HashBytes([]byte("payload"))
+	signature := Sign(payload, w)
+	tests := []struct {
+		name                        string
+		signature, payload, address string
+		want                        bool
+	}{
+		{"valid", signature, payload, w.Address, true},
+		{"other payload", signature, utils.HashBytes([]byte("other")), w.Address, false},
+		{"other signer", signature, payload, other.Address, false},
+		{"malformed signature", "zz", payload, w.Address, false},
+		{"empty signature", "", payload, w.Address, false},
+		{"malformed address", signature, payload, "zz", false},
+		{"malformed payload", signature, "zz", w.Address, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Verify(tt.signature, tt.payload, tt.address); got != tt.want {
+				t.Fatalf("Verify = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadRestoresThePersistedKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test"+fileName)
+	created := Load(path)
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("key was not persisted: %v", err)
+	}
+	if restored := Load(path); restored.Address != created.Address {
+		t.Fatal("restored wallet has a different address")
+	}
+	x, y, ok := restorePublicKey(created.Address)
+	if !ok || x.Cmp(created.privateKey.X) != 0 || y.Cmp(created.privateKey.Y) != 0 {
+		t.Fatal("address does not restore to the public key")
+	}
+}
+
+func TestAddressesWithShortCoordinates(t *testing.T) {
+	payload := utils.HashBytes([]byte("payload"))
+	// 좌표의 앞 바이트가 0인 키는 대략 64번에 한 번 나오므로, 짧은 X와 짧은 Y를 모두 찾을 때까지 생성
+	found := map[string]bool{}
+	for i := 0; i < 20000 && len(found) < 2; i++ {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var kind string
+		switch {
+		case len(key.X.Bytes()) < intSize:
+			kind = "x"
+		case len(key.Y.Bytes()) < intSize:
+			kind = "y"
+		default:
+			continue
+		}
+		found[kind] = true
+		w := &wallet{privateKey: key, Address: aFromK(key)}
+		if w.Address != fmt.Sprintf("%x%x", key.X.Bytes(), key.Y.Bytes()) {
+			t.Fatalf("address of a key with a short %s changed its encoding", kind)
+		}
+		signature := Sign(payload, w)
+		padded := encodeBigInts(key.X.Bytes(), key.Y.Bytes())
+		for _, address := range []string{w.Address, padded} {
+			if !Verify(signature, payload, address) {
+				t.Fatalf("key with a short %s does not verify against %s", kind, address)
+			}
+		}
+	}
+	if len(found) < 2 {
+		t.Fatal("no keys with short coordinates found")
+	}
+}